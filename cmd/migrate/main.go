@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalmigrations "github.com/ralvarezdev/connect-movies/internal/databases/postgres/migrations"
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+	internallogger "github.com/ralvarezdev/connect-movies/internal/logger"
+)
+
+const (
+	// Usage is the usage of the migrate command
+	Usage = `Usage: migrate [-mode=dev|prod] <command> [steps]
+
+Commands:
+  up [steps]     apply the pending migrations, all of them if no steps are given
+  down [steps]   revert the applied migrations, one of them if no steps are given
+  status         list the migrations and when they were applied
+`
+)
+
+var (
+	// ModeFlag is the mode flag
+	ModeFlag = goflagsmode.NewFlag(
+		goflagsmode.Dev,
+		goflagsmode.AllowedModes,
+	)
+)
+
+// init initializes the flags and calls the load functions
+func init() {
+	// Define the mode flag
+	goflagsmode.SetFlag(ModeFlag)
+
+	// Print the commands with the flags
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), Usage)
+		flag.PrintDefaults()
+	}
+
+	// Parse the flags
+	flag.Parse()
+
+	// Call the load functions
+	internallogger.Load(ModeFlag)
+	internalloader.Load(ModeFlag, internallogger.Logger)
+	internalpostgres.Load(ModeFlag)
+}
+
+// parseSteps parses the optional steps argument of the up and down commands
+//
+// Parameters:
+//
+// - args: the command arguments
+// - defaultSteps: the steps if the argument is not given
+//
+// Returns:
+//
+// - int: the steps
+func parseSteps(args []string, defaultSteps int) int {
+	if len(args) < 2 {
+		return defaultSteps
+	}
+	steps, err := strconv.Atoi(args[1])
+	if err != nil || steps <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	return steps
+}
+
+// printMigrations logs the applied or reverted migrations
+//
+// Parameters:
+//
+// - message: the log message
+// - migrations: the migrations
+func printMigrations(message string, migrations []internalmigrations.Migration) {
+	if len(migrations) == 0 {
+		internallogger.Logger.Info("No migrations to run")
+		return
+	}
+	for _, migration := range migrations {
+		internallogger.Logger.Info(
+			message,
+			slog.Int("version", migration.Version),
+			slog.String("name", migration.Name),
+		)
+	}
+}
+
+// printStatus prints the status of the migrations as a table
+//
+// Parameters:
+//
+// - statuses: the migration statuses
+func printStatus(statuses []internalmigrations.MigrationStatus) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt.Valid {
+			appliedAt = status.AppliedAt.Time.Format(time.RFC3339)
+		}
+		if !status.Embedded {
+			appliedAt += " (not embedded)"
+		}
+		fmt.Fprintf(writer, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	// nolint:errcheck
+	writer.Flush()
+}
+
+func main() {
+	args := flag.Args()
+	if len(args) == 0 || len(args) > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Create a context that is canceled on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+
+	// Create the Postgres database service
+	postgresPool, err := pgxpool.NewWithConfig(
+		ctx,
+		internalpostgres.PoolConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer postgresPool.Close()
+
+	// Create the migrator of the embedded migrations
+	migrator, err := internalmigrations.NewMigrator(
+		postgresPool,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Run the command
+	switch args[0] {
+	case "up":
+		appliedMigrations, upErr := migrator.Up(ctx, parseSteps(args, 0))
+		if upErr != nil {
+			panic(upErr)
+		}
+		printMigrations("Applied migration", appliedMigrations)
+	case "down":
+		revertedMigrations, downErr := migrator.Down(ctx, parseSteps(args, 1))
+		if downErr != nil {
+			panic(downErr)
+		}
+		printMigrations("Reverted migration", revertedMigrations)
+	case "status":
+		statuses, statusErr := migrator.Status(ctx)
+		if statusErr != nil {
+			panic(statusErr)
+		}
+		printStatus(statuses)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
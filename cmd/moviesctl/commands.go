@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalcommunity "github.com/ralvarezdev/connect-movies/internal/community"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalredis "github.com/ralvarezdev/connect-movies/internal/databases/redis"
+	internallogger "github.com/ralvarezdev/connect-movies/internal/logger"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+	internaluserdata "github.com/ralvarezdev/connect-movies/internal/userdata"
+)
+
+const (
+	// TMDBCheckTimeout is the timeout of the TMDB API check
+	TMDBCheckTimeout = 10 * time.Second
+)
+
+var (
+	ErrPurgeCacheTarget    = errors.New("exactly one of -movie or -pattern is required")
+	ErrPurgeCachePattern   = errors.New("refusing to purge a pattern outside movies:* and feed:* without -yes")
+	ErrUserRequired        = errors.New("-user is required")
+	ErrInvalidUserID       = errors.New("-user must be a numeric user ID")
+	ErrDeleteNotConfirmed  = errors.New("refusing to erase the user data without -yes")
+	ErrTMDBAPIKeyRejected  = errors.New("TMDB API key was rejected")
+	ErrUnexpectedArguments = errors.New("unexpected arguments")
+)
+
+// parseFlags parses the flags of a command, rejecting the positional arguments
+//
+// Parameters:
+//
+// - flagSet: the flag set of the command
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the flags could not be parsed
+func parseFlags(flagSet *flag.FlagSet, args []string) error {
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if flagSet.NArg() > 0 {
+		return ErrUnexpectedArguments
+	}
+	return nil
+}
+
+// openPostgres loads the Postgres configuration and opens a connection pool
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - *pgxpool.Pool: the Postgres connection pool
+// - error: if the pool could not be opened
+func openPostgres(ctx context.Context) (*pgxpool.Pool, error) {
+	internalpostgres.Load(ModeFlag)
+	return pgxpool.NewWithConfig(ctx, internalpostgres.PoolConfig)
+}
+
+// openCache loads the Redis and cache configurations and creates the cache
+//
+// Returns:
+//
+// - *internalcache.Cache: the Redis cache
+// - error: if the cache could not be created
+func openCache() (*internalcache.Cache, error) {
+	internalredis.Load()
+	internalcache.Load()
+	return internalcache.NewCache(internalredis.Client, internallogger.Logger)
+}
+
+// printJSON prints a value as indented JSON to the standard output
+//
+// Parameters:
+//
+// - value: the value to print
+//
+// Returns:
+//
+// - error: if the value could not be encoded
+func printJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// isCachePattern checks if a pattern only matches the keys of the cache, which start with the cache prefixes. The
+// Redis instance is shared with other data, like the leaderboards and the usernames, that the pattern must not match
+//
+// Parameters:
+//
+// - pattern: the Redis glob-style pattern
+//
+// Returns:
+//
+// - bool: whether the pattern starts with a cache prefix
+func isCachePattern(pattern string) bool {
+	for _, prefix := range []string{internalcache.MoviesKeyPrefix, internalcache.FeedKeyPrefix} {
+		if strings.HasPrefix(pattern, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// purgeCache deletes the cached values of a movie or the cached keys matching a pattern. The patterns outside the
+// cache prefixes must be confirmed with -yes
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the cache could not be purged
+func purgeCache(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("purge-cache", flag.ExitOnError)
+	movieID := flagSet.Int("movie", 0, "TMDB ID of the movie whose cached values are deleted")
+	pattern := flagSet.String("pattern", "", "Redis glob-style pattern of the cached keys to delete")
+	confirmed := flagSet.Bool("yes", false, "confirm a pattern outside the cache prefixes (movies:, feed:)")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if (*movieID == 0) == (*pattern == "") {
+		return ErrPurgeCacheTarget
+	}
+	if *pattern != "" && !*confirmed && !isCachePattern(*pattern) {
+		return ErrPurgeCachePattern
+	}
+	if *movieID != 0 {
+		*pattern = fmt.Sprintf(internalcache.MovieKeyPattern, *movieID)
+	}
+
+	cache, err := openCache()
+	if err != nil {
+		return err
+	}
+	defer internalredis.Client.Close()
+
+	deletedKeysCount, err := cache.DeleteMatching(ctx, *pattern)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d keys matching %s\n", deletedKeysCount, *pattern)
+	return nil
+}
+
+// reindex rebuilds the community top movies leaderboards from the user reviews, without waiting for the server to
+// refresh them
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the leaderboards could not be rebuilt
+func reindex(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("reindex", flag.ExitOnError)
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+
+	pool, err := openPostgres(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	internalredis.Load()
+	defer internalredis.Client.Close()
+	internaltmdb.Load()
+	internalcommunity.Load()
+
+	leaderboard, err := internalcommunity.NewLeaderboard(
+		pool,
+		internalredis.Client,
+		internaltmdb.TMDBClient,
+		internallogger.Logger,
+	)
+	if err != nil {
+		return err
+	}
+
+	startedAt := time.Now()
+	if err = leaderboard.Refresh(ctx); err != nil {
+		return err
+	}
+	fmt.Printf("Rebuilt the community leaderboards in %s\n", time.Since(startedAt).Round(time.Millisecond))
+	return nil
+}
+
+// openUserDataManager opens the Postgres pool and the cache and creates the user data manager
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - *internaluserdata.Manager: the user data manager
+// - func(): the function that closes the Postgres pool and the Redis client
+// - error: if the user data manager could not be created
+func openUserDataManager(ctx context.Context) (*internaluserdata.Manager, func(), error) {
+	pool, err := openPostgres(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cache, err := openCache()
+	if err != nil {
+		pool.Close()
+		return nil, nil, err
+	}
+	closeFn := func() {
+		pool.Close()
+		// nolint:errcheck
+		internalredis.Client.Close()
+	}
+
+	manager, err := internaluserdata.NewManager(pool, cache, internallogger.Logger)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	return manager, closeFn, nil
+}
+
+// parseUserID parses the user ID flag of a command
+//
+// Parameters:
+//
+// - userID: the user ID flag value
+//
+// Returns:
+//
+// - error: if the user ID is missing or is not a number
+func parseUserID(userID string) error {
+	if userID == "" {
+		return ErrUserRequired
+	}
+	if _, err := strconv.ParseInt(userID, 10, 64); err != nil {
+		return ErrInvalidUserID
+	}
+	return nil
+}
+
+// exportReviews exports every review of a user as JSON
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the reviews could not be exported
+func exportReviews(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("export-reviews", flag.ExitOnError)
+	userID := flagSet.String("user", "", "ID of the user whose reviews are exported")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if err := parseUserID(*userID); err != nil {
+		return err
+	}
+
+	manager, closeFn, err := openUserDataManager(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	reviews, err := manager.ListReviews(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	return printJSON(
+		map[string]any{
+			"user_id":     *userID,
+			"exported_at": time.Now().UTC(),
+			"reviews":     reviews,
+		},
+	)
+}
+
+// deleteUser erases the data of a user
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the data could not be erased
+func deleteUser(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("delete-user", flag.ExitOnError)
+	userID := flagSet.String("user", "", "ID of the user whose data is erased")
+	confirmed := flagSet.Bool("yes", false, "confirm the erasure, which cannot be undone")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if err := parseUserID(*userID); err != nil {
+		return err
+	}
+	if !*confirmed {
+		return ErrDeleteNotConfirmed
+	}
+
+	manager, closeFn, err := openUserDataManager(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	report, err := manager.Erase(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	return printJSON(report)
+}
+
+// checkTMDB checks the TMDB API is reachable and the API key is valid
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the TMDB API is not reachable or the API key is not valid
+func checkTMDB(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("check-tmdb", flag.ExitOnError)
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	internaltmdb.Load()
+
+	ctx, cancel := context.WithTimeout(ctx, TMDBCheckTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	response, statusCode, err := internaltmdb.TMDBClient.CheckAPIKey(ctx)
+	latency := time.Since(startedAt).Round(time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("TMDB API check failed with status code %d after %s: %w", statusCode, latency, err)
+	}
+	if !response.Success {
+		return ErrTMDBAPIKeyRejected
+	}
+	fmt.Printf("TMDB API is reachable and the API key is valid (status code %d, %s)\n", statusCode, latency)
+	return nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalcommunity "github.com/ralvarezdev/connect-movies/internal/community"
+	internalconnect "github.com/ralvarezdev/connect-movies/internal/connect"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalredis "github.com/ralvarezdev/connect-movies/internal/databases/redis"
+	internalimports "github.com/ralvarezdev/connect-movies/internal/imports"
+	internaljwt "github.com/ralvarezdev/connect-movies/internal/jwt"
+	internalmoderation "github.com/ralvarezdev/connect-movies/internal/moderation"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+	internaluserdata "github.com/ralvarezdev/connect-movies/internal/userdata"
+	internalwebhooks "github.com/ralvarezdev/connect-movies/internal/webhooks"
+)
+
+const (
+	// RedactedValue replaces the secret configuration values
+	RedactedValue = "[REDACTED]"
+
+	// UnsetValue is printed for the configuration variables that are not set
+	UnsetValue = "<unset>"
+)
+
+type (
+	// configVariable is an environment variable read by the internal loaders
+	configVariable struct {
+		key    string
+		secret bool
+	}
+)
+
+var (
+	// configVariables are the environment variables read by the internal loaders, in the order of the .env example
+	configVariables = []configVariable{
+		{key: internalconnect.EnvPort},
+		{key: internalconnect.EnvAuthServiceAddress},
+		{key: internalpostgres.EnvDSN, secret: true},
+		{key: internalpostgres.EnvMaxIdleConnections},
+		{key: internalpostgres.EnvMaxOpenConnections},
+		{key: internalredis.EnvRedisAddress},
+		{key: internalredis.EnvRedisUsername},
+		{key: internalredis.EnvRedisPassword, secret: true},
+		{key: internalredis.EnvRedisDB},
+		{key: internaljwt.EnvPublicKey},
+		{key: internaljwt.EnvAccessTokenDuration},
+		{key: internaljwt.EnvRefreshTokenDuration},
+		{key: internalcache.EnvMovieListsTTL},
+		{key: internalcache.EnvMoviesTTL},
+		{key: internalcache.EnvFeedTTL},
+		{key: internalcommunity.EnvRefreshInterval},
+		{key: internalcommunity.EnvHalfLife},
+		{key: internalcommunity.EnvMinReviews},
+		{key: internalmoderation.EnvProfanityWordlistPaths},
+		{key: internalmoderation.EnvMinLength},
+		{key: internalmoderation.EnvMaxLength},
+		{key: internalmoderation.EnvMaxLinks},
+		{key: internalmoderation.EnvReportsHideThreshold},
+		{key: internaloutbox.EnvStream},
+		{key: internaloutbox.EnvDeadLetterStream},
+		{key: internaloutbox.EnvStreamMaxLength},
+		{key: internaloutbox.EnvPollInterval},
+		{key: internaloutbox.EnvBatchSize},
+		{key: internaloutbox.EnvMaxAttempts},
+		{key: internaloutbox.EnvRetryBaseDelay},
+		{key: internaloutbox.EnvRetryMaxDelay},
+		{key: internalwebhooks.EnvConsumerGroup},
+		{key: internalwebhooks.EnvConsumerName},
+		{key: internalwebhooks.EnvPollInterval},
+		{key: internalwebhooks.EnvBatchSize},
+		{key: internalwebhooks.EnvMaxAttempts},
+		{key: internalwebhooks.EnvRetryBaseDelay},
+		{key: internalwebhooks.EnvRetryMaxDelay},
+		{key: internalwebhooks.EnvRequestTimeout},
+		{key: internaluserdata.EnvExportPollInterval},
+		{key: internaluserdata.EnvExportBatchSize},
+		{key: internaluserdata.EnvExportTTL},
+		{key: internalimports.EnvPollInterval},
+		{key: internalimports.EnvBatchSize},
+		{key: internaltmdb.EnvTMDBAPIKey, secret: true},
+		{key: internaltmdb.EnvCastMemberProfileImageWidthSize},
+		{key: internaltmdb.EnvCrewMemberProfileImageWidthSize},
+		{key: internaltmdb.EnvSimpleMoviePosterImageWidthSize},
+		{key: internaltmdb.EnvProductionCompanyLogoImageWidthSize},
+		{key: internaltmdb.EnvMovieDetailsPosterImageWidthSize},
+		{key: internaltmdb.EnvAvatarImageWidthSize},
+		{key: internaltmdb.EnvWatchProviderLogoImageWidthSize},
+		{key: internaltmdb.EnvMovieImageWidthSize},
+	}
+
+	// dsnPasswordRegexp matches the password of a key/value Postgres DSN
+	dsnPasswordRegexp = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+)
+
+// redactDSN redacts the password of a Postgres DSN, keeping the rest of it readable. URL passwords are redacted by
+// url.URL.Redacted
+//
+// Parameters:
+//
+// - dsn: the Postgres DSN, either as a URL or as key/value pairs
+//
+// Returns:
+//
+// - string: the redacted DSN
+func redactDSN(dsn string) string {
+	parsedURL, err := url.Parse(dsn)
+	if err == nil && (parsedURL.Scheme == "postgres" || parsedURL.Scheme == "postgresql") {
+		query := parsedURL.Query()
+		if query.Has("password") {
+			query.Set("password", RedactedValue)
+			parsedURL.RawQuery = query.Encode()
+		}
+		return parsedURL.Redacted()
+	}
+	return dsnPasswordRegexp.ReplaceAllString(dsn, "${1}"+RedactedValue)
+}
+
+// printConfig prints the effective configuration read by the internal loaders, with the secrets redacted
+//
+// Parameters:
+//
+// - ctx: the context
+// - args: the command arguments
+//
+// Returns:
+//
+// - error: if the flags could not be parsed
+func printConfig(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("config", flag.ExitOnError)
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "MODE\t%s\n", ModeFlag.Value())
+	for _, variable := range configVariables {
+		value, ok := os.LookupEnv(variable.key)
+		switch {
+		case !ok:
+			value = UnsetValue
+		case variable.key == internalpostgres.EnvDSN:
+			value = redactDSN(value)
+		case variable.secret && value != "":
+			value = RedactedValue
+		case strings.ContainsRune(value, '\n'):
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", variable.key, value)
+	}
+	return writer.Flush()
+}
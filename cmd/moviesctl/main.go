@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+	internallogger "github.com/ralvarezdev/connect-movies/internal/logger"
+)
+
+const (
+	// Usage is the usage of the moviesctl command
+	Usage = `Usage: moviesctl [-mode=dev|prod] <command> [flags]
+
+Commands:
+  purge-cache      delete the cached values of a movie or the cached keys matching a pattern (movies:*, feed:*)
+  reindex          rebuild the community top movies leaderboards from the user reviews
+  export-reviews   export every review of a user as JSON
+  delete-user      erase the data of a user
+  check-tmdb       check the TMDB API is reachable and the API key is valid
+  config           print the effective configuration with the secrets redacted
+
+Run moviesctl <command> -h for the flags of a command.
+`
+)
+
+var (
+	// ModeFlag is the mode flag
+	ModeFlag = goflagsmode.NewFlag(
+		goflagsmode.Dev,
+		goflagsmode.AllowedModes,
+	)
+
+	// commands are the moviesctl commands by name
+	commands = map[string]func(ctx context.Context, args []string) error{
+		"purge-cache":    purgeCache,
+		"reindex":        reindex,
+		"export-reviews": exportReviews,
+		"delete-user":    deleteUser,
+		"check-tmdb":     checkTMDB,
+		"config":         printConfig,
+	}
+)
+
+// init initializes the flags and calls the load functions shared by every command
+func init() {
+	// Define the mode flag
+	goflagsmode.SetFlag(ModeFlag)
+
+	// Print the commands with the flags
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), Usage)
+		flag.PrintDefaults()
+	}
+
+	// Parse the flags
+	flag.Parse()
+
+	// Call the load functions, logging to stderr so the command output can be piped
+	internallogger.Load(ModeFlag)
+	internallogger.Logger = slog.New(slog.NewTextHandler(os.Stderr, internallogger.Options))
+	internalloader.Load(ModeFlag, internallogger.Logger)
+}
+
+func main() {
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Create a context that is canceled on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer stop()
+
+	// Run the command
+	if err := run(ctx, args[1:]); err != nil {
+		internallogger.Logger.Error(
+			"Command failed",
+			slog.String("command", args[0]),
+			slog.String("error", err.Error()),
+		)
+		stop()
+		os.Exit(1)
+	}
+}
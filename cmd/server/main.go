@@ -25,17 +25,25 @@ import (
 	redisauthtypes "github.com/ralvarezdev/redis-auth-types-go"
 
 	authv1connect "github.com/ralvarezdev/proto-auth/gen/go/ralvarezdev/v1/v1connect"
-	protomovies "github.com/ralvarezdev/proto-movies/gen/go"
 	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
 
+	internalauthorization "github.com/ralvarezdev/connect-movies/internal/authorization"
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalcommunity "github.com/ralvarezdev/connect-movies/internal/community"
 	internalconnect "github.com/ralvarezdev/connect-movies/internal/connect"
 	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalmigrations "github.com/ralvarezdev/connect-movies/internal/databases/postgres/migrations"
 	internalredis "github.com/ralvarezdev/connect-movies/internal/databases/redis"
+	internalimports "github.com/ralvarezdev/connect-movies/internal/imports"
 	internaljwt "github.com/ralvarezdev/connect-movies/internal/jwt"
 	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
 	internallogger "github.com/ralvarezdev/connect-movies/internal/logger"
+	internalmoderation "github.com/ralvarezdev/connect-movies/internal/moderation"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
 	internalservice "github.com/ralvarezdev/connect-movies/internal/service"
 	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+	internaluserdata "github.com/ralvarezdev/connect-movies/internal/userdata"
+	internalwebhooks "github.com/ralvarezdev/connect-movies/internal/webhooks"
 )
 
 var (
@@ -53,6 +61,13 @@ var (
 		&DefaultPublicKeyPath,
 	)
 
+	// MigrateFlag is the flag to apply the pending migrations on startup
+	MigrateFlag = flag.Bool(
+		"migrate",
+		false,
+		"apply the pending database migrations before serving",
+	)
+
 	// ListenConfig is the net.ListenConfig to use
 	ListenConfig = net.ListenConfig{}
 )
@@ -73,6 +88,13 @@ func init() {
 	internalredis.Load()
 	internaljwt.Load(ModeFlag, PublicKeyPathFlag, internalredis.Client, internallogger.Logger)
 	internaltmdb.Load()
+	internalcache.Load()
+	internalcommunity.Load()
+	internalmoderation.Load()
+	internaloutbox.Load()
+	internalwebhooks.Load()
+	internaluserdata.Load()
+	internalimports.Load()
 	internalconnect.Load()
 
 	// Log that the load functions were called
@@ -111,6 +133,29 @@ func main() {
 	}
 	defer postgresPool.Close()
 
+	// Create the migrator of the embedded migrations
+	migrator, err := internalmigrations.NewMigrator(
+		postgresPool,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Apply the pending migrations if requested, and refuse to serve if the schema is behind the binary
+	if *MigrateFlag {
+		if _, err = migrator.Up(ctx, 0); err != nil {
+			panic(err)
+		}
+	}
+	if err = migrator.CheckCurrent(ctx); err != nil {
+		internallogger.Logger.Error(
+			"Refusing to serve, run the migrate command or start the server with -migrate",
+			slog.String("error", err.Error()),
+		)
+		panic(err)
+	}
+
 	// Create the Redis username handler
 	redisUsernameHandler, err := redisauthtypes.NewUsernameHandler(
 		internalredis.Client,
@@ -119,11 +164,86 @@ func main() {
 		panic(err)
 	}
 
+	// Create the Redis cache
+	cache, err := internalcache.NewCache(
+		internalredis.Client,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the community leaderboard and refresh it in the background
+	leaderboard, err := internalcommunity.NewLeaderboard(
+		postgresPool,
+		internalredis.Client,
+		internaltmdb.TMDBClient,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	go leaderboard.Run(ctx)
+
+	// Create the outbox relay and publish the domain events in the background
+	outboxRelay, err := internaloutbox.NewRelay(
+		postgresPool,
+		internalredis.Client,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	go outboxRelay.Run(ctx)
+
+	// Create the webhook dispatcher and deliver the domain events to the webhook subscriptions in the background
+	webhookDispatcher, err := internalwebhooks.NewDispatcher(
+		postgresPool,
+		internalredis.Client,
+		nil,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	go webhookDispatcher.Run(ctx)
+
+	// Create the user data manager
+	userDataManager, err := internaluserdata.NewManager(
+		postgresPool,
+		cache,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the user data exporter and build the requested data exports in the background
+	userDataExporter, err := internaluserdata.NewExporter(
+		postgresPool,
+		userDataManager,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	go userDataExporter.Run(ctx)
+
+	// Create the moderation rules engine
+	moderationEngine, err := internalmoderation.NewDefaultEngine()
+	if err != nil {
+		panic(err)
+	}
+
 	// Create the service
 	service, err := internalservice.NewService(
 		internaltmdb.TMDBClient,
 		postgresPool,
 		redisUsernameHandler,
+		cache,
+		leaderboard,
+		moderationEngine,
+		userDataManager,
 		// internalconnect.RequestInjector,
 		// internalconnect.ResponseInjector,
 	)
@@ -131,6 +251,18 @@ func main() {
 		panic(err)
 	}
 
+	// Create the review importer and process the uploaded review imports in the background
+	reviewImporter, err := internalimports.NewImporter(
+		postgresPool,
+		internaltmdb.TMDBClient,
+		service,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+	go reviewImporter.Run(ctx)
+
 	// Create the gRPC auth Server
 	connectServer, err := internalconnect.NewServer(
 		service,
@@ -140,6 +272,24 @@ func main() {
 		panic(err)
 	}
 
+	// Create the gRPC admin server
+	connectAdminServer, err := internalconnect.NewAdminServer(
+		service,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the gRPC internal server
+	connectInternalServer, err := internalconnect.NewInternalServer(
+		service,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	// Create the refresh token function
 	refreshTokenFn, err := goconnectralvarezdevv1auth.CreateRefreshTokenFn(
 		ctx,
@@ -155,7 +305,7 @@ func main() {
 	authInterceptor, err := goconnectauth.NewInterceptor(
 		ModeFlag,
 		internaljwt.Validator,
-		protomovies.Interceptions,
+		internalconnect.NewInterceptions(),
 		&goconnectauth.Options{
 			RefreshTokenFn: refreshTokenFn,
 		},
@@ -165,23 +315,65 @@ func main() {
 		panic(err)
 	}
 
+	// Initialize the stream auth interceptor, as the auth interceptor only intercepts the unary calls
+	streamAuthInterceptor, err := internalconnect.NewStreamAuthInterceptor(
+		internaljwt.Validator,
+		internalconnect.NewInterceptions(),
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Initialize the authorization interceptor
+	authorizationInterceptor, err := internalauthorization.NewInterceptor(
+		internalauthorization.Policies,
+		internallogger.Logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	// Initialize the error handler interceptor
 	errorHandler, err := goconnecterrorhandler.NewInterceptor(ModeFlag, internallogger.Logger)
 	if err != nil {
 		panic(err)
 	}
 
-	// Create the Connect mux and register the auth service handler
+	// Initialize the stream error handler interceptor, as the error handler interceptor only recovers the unary calls
+	streamErrorHandler, err := internalconnect.NewStreamErrorHandlerInterceptor(ModeFlag, internallogger.Logger)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the interceptors, authorizing the calls after authenticating them
+	interceptors := connect.WithInterceptors(
+		validate.NewInterceptor(),
+		errorHandler.HandleError(),
+		streamErrorHandler,
+		authInterceptor.Authenticate(),
+		streamAuthInterceptor,
+		authorizationInterceptor.Authorize(),
+		authorizationInterceptor,
+	)
+
+	// Create the Connect mux and register the movies, admin and internal service handlers
 	mux := http.NewServeMux()
 	path, handler := v1connect.NewMoviesServiceHandler(
 		connectServer,
-		connect.WithInterceptors(
-			validate.NewInterceptor(),
-			errorHandler.HandleError(),
-			authInterceptor.Authenticate(),
-		),
+		interceptors,
 	)
 	mux.Handle(path, handler)
+	adminPath, adminHandler := v1connect.NewAdminServiceHandler(
+		connectAdminServer,
+		interceptors,
+	)
+	mux.Handle(adminPath, adminHandler)
+	internalPath, internalHandler := v1connect.NewInternalServiceHandler(
+		connectInternalServer,
+		interceptors,
+	)
+	mux.Handle(internalPath, internalHandler)
 
 	// Add a health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -193,6 +385,8 @@ func main() {
 	// Rgister reflection service on gRPC server.
 	reflector := grpcreflect.NewStaticReflector(
 		v1connect.MoviesServiceName,
+		v1connect.AdminServiceName,
+		v1connect.InternalServiceName,
 	)
 	mux.Handle(grpcreflect.NewHandlerV1(reflector))
 
@@ -4,4 +4,12 @@ set -e
 echo "Compiling server..."
 go mod tidy
 go build -o bin/server/server ./cmd/server
-echo "Compiling server... Done"
\ No newline at end of file
+echo "Compiling server... Done"
+
+echo "Compiling migrate..."
+go build -o bin/migrate/migrate ./cmd/migrate
+echo "Compiling migrate... Done"
+
+echo "Compiling moviesctl..."
+go build -o bin/moviesctl/moviesctl ./cmd/moviesctl
+echo "Compiling moviesctl... Done"
\ No newline at end of file
@@ -3,6 +3,7 @@ module github.com/ralvarezdev/connect-movies
 go 1.25.4
 
 require (
+	buf.build/go/protovalidate v1.0.0
 	connectrpc.com/connect v1.19.1
 	connectrpc.com/grpcreflect v1.3.0
 	connectrpc.com/validate v0.6.0
@@ -17,16 +18,16 @@ require (
 	github.com/ralvarezdev/go-loader v0.2.22
 	github.com/ralvarezdev/go-tmdb-api v0.3.2
 	github.com/ralvarezdev/proto-auth/gen/go v0.1.7
-	github.com/ralvarezdev/proto-movies/gen/go v0.3.0
+	github.com/ralvarezdev/proto-movies/gen/go v0.4.0
 	github.com/ralvarezdev/redis-auth-types-go v0.1.0
 	github.com/ralvarezdev/sql-movies/go v0.1.0
 	github.com/redis/go-redis/v9 v9.16.0
+	golang.org/x/sync v0.18.0
 	google.golang.org/protobuf v1.36.10
 )
 
 require (
 	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.10-20250912141014-52f32327d4b0.1 // indirect
-	buf.build/go/protovalidate v1.0.0 // indirect
 	cel.dev/expr v0.24.0 // indirect
 	github.com/antlr4-go/antlr/v4 v4.13.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -42,7 +43,6 @@ require (
 	github.com/stoewer/go-strcase v1.3.1 // indirect
 	golang.org/x/crypto v0.44.0 // indirect
 	golang.org/x/exp v0.0.0-20250911091902-df9299821621 // indirect
-	golang.org/x/sync v0.18.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250922171735-9219d122eba9 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251111163417-95abcf5c77ba // indirect
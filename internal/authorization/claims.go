@@ -0,0 +1,127 @@
+package authorization
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+)
+
+// getClaimValues gets the values of a JWT claim that can be either a single string, split by spaces if requested, or
+// an array of strings
+//
+// Parameters:
+//
+// - value: the claim value
+// - splitBySpaces: true to split a single string value by spaces
+//
+// Returns:
+//
+// - []string: the claim values
+func getClaimValues(value any, splitBySpaces bool) []string {
+	switch typedValue := value.(type) {
+	case string:
+		if splitBySpaces {
+			return strings.Fields(typedValue)
+		}
+		return []string{typedValue}
+	case []string:
+		return typedValue
+	case []any:
+		values := make([]string, 0, len(typedValue))
+		for _, item := range typedValue {
+			if stringItem, ok := item.(string); ok {
+				values = append(values, stringItem)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// GetRoles gets the roles of the authenticated user from the role and roles JWT claims
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - []string: the roles of the authenticated user
+// - error: if the token claims are not in the context
+func GetRoles(ctx context.Context) ([]string, error) {
+	claims, err := gojwtgrpc.GetCtxTokenClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(getClaimValues(claims[RoleClaim], false), getClaimValues(claims[RolesClaim], false)...), nil
+}
+
+// GetScopes gets the scopes of the authenticated user from the scope and scopes JWT claims
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - []string: the scopes of the authenticated user
+// - error: if the token claims are not in the context
+func GetScopes(ctx context.Context) ([]string, error) {
+	claims, err := gojwtgrpc.GetCtxTokenClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(getClaimValues(claims[ScopeClaim], true), getClaimValues(claims[ScopesClaim], true)...), nil
+}
+
+// HasAnyRole checks if the authenticated user has any of the given roles
+//
+// Parameters:
+//
+// - ctx: the context
+// - roles: the roles to check
+//
+// Returns:
+//
+// - bool: true if the authenticated user has any of the roles, false otherwise
+func HasAnyRole(ctx context.Context, roles ...string) bool {
+	userRoles, err := GetRoles(ctx)
+	if err != nil {
+		return false
+	}
+	for _, role := range roles {
+		if slices.Contains(userRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllScopes checks if the authenticated user has every given scope
+//
+// Parameters:
+//
+// - ctx: the context
+// - scopes: the scopes to check
+//
+// Returns:
+//
+// - bool: true if the authenticated user has every scope, false otherwise
+func HasAllScopes(ctx context.Context, scopes ...string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	userScopes, err := GetScopes(ctx)
+	if err != nil {
+		return false
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(userScopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package authorization
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+)
+
+// contextWithClaims creates a context with the given token claims, as set by the authentication interceptors
+//
+// Parameters:
+//
+// - claims: the token claims
+//
+// Returns:
+//
+// - context.Context: the context with the token claims
+func contextWithClaims(claims map[string]any) context.Context {
+	return gojwtgrpc.SetCtxTokenClaims(context.Background(), claims)
+}
+
+func TestGetClaimValues(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		value         any
+		splitBySpaces bool
+		want          []string
+	}{
+		{"missing claim", nil, false, nil},
+		{"single value", "admin", false, []string{"admin"}},
+		{"single value with spaces", "movies:read movies:write", false, []string{"movies:read movies:write"}},
+		{"space-separated values", " movies:read  movies:write ", true, []string{"movies:read", "movies:write"}},
+		{"string array", []string{"admin", "moderator"}, false, []string{"admin", "moderator"}},
+		{"JSON array", []any{"admin", 1, "moderator"}, false, []string{"admin", "moderator"}},
+		{"unexpected type", 1, true, nil},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := getClaimValues(test.value, test.splitBySpaces); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("getClaimValues() = %q, want %q", got, test.want)
+				}
+			},
+		)
+	}
+}
+
+func TestGetRolesAndScopes(t *testing.T) {
+	ctx := contextWithClaims(
+		map[string]any{
+			RoleClaim:   RoleModerator,
+			RolesClaim:  []any{RoleAdmin},
+			ScopeClaim:  "movies:read movies:write",
+			ScopesClaim: []any{"webhooks:manage"},
+		},
+	)
+
+	roles, err := GetRoles(ctx)
+	if err != nil {
+		t.Fatalf("GetRoles() error = %v", err)
+	}
+	if want := []string{RoleModerator, RoleAdmin}; !reflect.DeepEqual(roles, want) {
+		t.Errorf("GetRoles() = %q, want %q", roles, want)
+	}
+
+	scopes, err := GetScopes(ctx)
+	if err != nil {
+		t.Fatalf("GetScopes() error = %v", err)
+	}
+	if want := []string{"movies:read", "movies:write", "webhooks:manage"}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("GetScopes() = %q, want %q", scopes, want)
+	}
+}
+
+func TestGetRolesWithoutClaims(t *testing.T) {
+	if _, err := GetRoles(context.Background()); err == nil {
+		t.Error("GetRoles() error = nil, want an error")
+	}
+	if _, err := GetScopes(context.Background()); err == nil {
+		t.Error("GetScopes() error = nil, want an error")
+	}
+}
+
+func TestHasAnyRole(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		claims map[string]any
+		roles  []string
+		want   bool
+	}{
+		{"role claim", map[string]any{RoleClaim: RoleModerator}, []string{RoleAdmin, RoleModerator}, true},
+		{"roles claim", map[string]any{RolesClaim: []any{RoleAdmin}}, []string{RoleAdmin}, true},
+		{"other role", map[string]any{RoleClaim: RoleModerator}, []string{RoleAdmin}, false},
+		{"no roles", map[string]any{}, []string{RoleAdmin}, false},
+		{"no required roles", map[string]any{RoleClaim: RoleAdmin}, nil, false},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := HasAnyRole(contextWithClaims(test.claims), test.roles...); got != test.want {
+					t.Errorf("HasAnyRole() = %t, want %t", got, test.want)
+				}
+			},
+		)
+	}
+
+	if HasAnyRole(context.Background(), RoleAdmin) {
+		t.Error("HasAnyRole() = true without token claims, want false")
+	}
+}
+
+func TestHasAllScopes(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		claims map[string]any
+		scopes []string
+		want   bool
+	}{
+		{"scope claim", map[string]any{ScopeClaim: "a b"}, []string{"a", "b"}, true},
+		{"scope and scopes claims", map[string]any{ScopeClaim: "a", ScopesClaim: []any{"b"}}, []string{"a", "b"}, true},
+		{"missing scope", map[string]any{ScopeClaim: "a"}, []string{"a", "b"}, false},
+		{"no required scopes", map[string]any{}, nil, true},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := HasAllScopes(contextWithClaims(test.claims), test.scopes...); got != test.want {
+					t.Errorf("HasAllScopes() = %t, want %t", got, test.want)
+				}
+			},
+		)
+	}
+
+	if HasAllScopes(context.Background(), "a") {
+		t.Error("HasAllScopes() = true without token claims, want false")
+	}
+}
@@ -0,0 +1,61 @@
+package authorization
+
+import (
+	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
+)
+
+const (
+	// RoleClaim is the JWT claim with the role of the user
+	RoleClaim = "role"
+
+	// RolesClaim is the JWT claim with the roles of the user
+	RolesClaim = "roles"
+
+	// ScopeClaim is the JWT claim with the space-separated scopes of the user
+	ScopeClaim = "scope"
+
+	// ScopesClaim is the JWT claim with the scopes of the user
+	ScopesClaim = "scopes"
+)
+
+const (
+	// RoleAdmin is the role of the admin users
+	RoleAdmin = "admin"
+
+	// RoleModerator is the role of the moderator users
+	RoleModerator = "moderator"
+
+	// RoleService is the role of the internal services, such as the auth service, calling the internal procedures
+	RoleService = "service"
+)
+
+var (
+	// StaffPolicy is the policy of the procedures that admins and moderators can call
+	StaffPolicy = Policy{Roles: []string{RoleAdmin, RoleModerator}}
+
+	// AdminPolicy is the policy of the procedures that only admins can call
+	AdminPolicy = Policy{Roles: []string{RoleAdmin}}
+
+	// ServicePolicy is the policy of the procedures that only the internal services can call
+	ServicePolicy = Policy{Roles: []string{RoleService}}
+
+	// Policies is the authorization policy of each procedure that requires more than a valid token. The procedures
+	// not listed here can be called by any user that passes the authentication interceptor
+	Policies = map[string]Policy{
+		v1connect.MoviesServiceListModerationQueueProcedure:                StaffPolicy,
+		v1connect.MoviesServiceApproveUserMovieReviewProcedure:             StaffPolicy,
+		v1connect.MoviesServiceRejectUserMovieReviewProcedure:              StaffPolicy,
+		v1connect.MoviesServiceListUserMovieReviewModerationAuditProcedure: StaffPolicy,
+		v1connect.MoviesServiceListContentReportsProcedure:                 StaffPolicy,
+		v1connect.MoviesServiceResolveContentReportProcedure:               StaffPolicy,
+		v1connect.AdminServiceDeleteAnyUserMovieReviewProcedure:            StaffPolicy,
+		v1connect.AdminServicePurgeMovieCacheProcedure:                     StaffPolicy,
+		v1connect.AdminServiceGetSystemStatsProcedure:                      StaffPolicy,
+		v1connect.AdminServiceListAdminAuditLogProcedure:                   StaffPolicy,
+		v1connect.AdminServiceCreateWebhookSubscriptionProcedure:           AdminPolicy,
+		v1connect.AdminServiceListWebhookSubscriptionsProcedure:            AdminPolicy,
+		v1connect.AdminServiceDeleteWebhookSubscriptionProcedure:           AdminPolicy,
+		v1connect.AdminServiceListWebhookDeliveriesProcedure:               AdminPolicy,
+		v1connect.InternalServiceDeleteUserDataProcedure:                   ServicePolicy,
+	}
+)
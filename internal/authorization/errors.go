@@ -0,0 +1,17 @@
+package authorization
+
+import (
+	"errors"
+
+	"connectrpc.com/connect"
+)
+
+var (
+	ErrNilPolicies        = errors.New("authorization policies are nil")
+	ErrMissingRole        = errors.New("the user does not have any of the roles required to call this procedure")
+	ErrMissingScope       = errors.New("the user does not have every scope required to call this procedure")
+	ErrNilTokenClaims     = errors.New("token claims not found in the context")
+	ConnErrMissingRole    = connect.NewError(connect.CodePermissionDenied, ErrMissingRole)
+	ConnErrMissingScope   = connect.NewError(connect.CodePermissionDenied, ErrMissingScope)
+	ConnErrNilTokenClaims = connect.NewError(connect.CodeUnauthenticated, ErrNilTokenClaims)
+)
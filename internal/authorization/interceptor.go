@@ -0,0 +1,161 @@
+package authorization
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+)
+
+type (
+	// Policy is the authorization policy of a procedure. The user must have any of the roles, if any, and every scope
+	Policy struct {
+		Roles  []string
+		Scopes []string
+	}
+
+	// Interceptor is the authorization interceptor, checking the roles and scopes of the authenticated user against
+	// the policy of the called procedure. It must run after the authentication interceptors. Authorize checks the
+	// unary calls, while the interceptor itself checks the streaming calls
+	Interceptor struct {
+		policies map[string]Policy
+		logger   *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new authorization interceptor
+//
+// Parameters:
+//
+// - policies: the authorization policy of each procedure
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Interceptor: the authorization interceptor
+// - error: if there was an error creating the authorization interceptor
+func NewInterceptor(policies map[string]Policy, logger *slog.Logger) (*Interceptor, error) {
+	// Check if the policies are nil
+	if policies == nil {
+		return nil, ErrNilPolicies
+	}
+
+	// Create the logger for the interceptor
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "authorization_interceptor"),
+		)
+	}
+
+	return &Interceptor{
+		policies: policies,
+		logger:   logger,
+	}, nil
+}
+
+// authorize checks the roles and the scopes of the authenticated user against the policy of a procedure
+//
+// Parameters:
+//
+// - ctx: the context
+// - procedure: the called procedure
+//
+// Returns:
+//
+// - error: if the user is not allowed to call the procedure
+func (i Interceptor) authorize(ctx context.Context, procedure string) error {
+	// Get the policy of the procedure
+	policy, ok := i.policies[procedure]
+	if !ok {
+		return nil
+	}
+
+	// Check if the token claims were set by the authentication interceptor
+	if _, err := gojwtgrpc.GetCtxTokenClaims(ctx); err != nil {
+		return ConnErrNilTokenClaims
+	}
+
+	// Check the roles and the scopes of the user
+	var connErr *connect.Error
+	if len(policy.Roles) > 0 && !HasAnyRole(ctx, policy.Roles...) {
+		connErr = ConnErrMissingRole
+	} else if !HasAllScopes(ctx, policy.Scopes...) {
+		connErr = ConnErrMissingScope
+	}
+	if connErr != nil {
+		if i.logger != nil {
+			i.logger.Warn(
+				"Denied call to procedure",
+				slog.String("procedure", procedure),
+				slog.String("error", connErr.Error()),
+			)
+		}
+		return connErr
+	}
+	return nil
+}
+
+// Authorize is a unary server interceptor that checks the policy of the called procedure
+//
+// Returns:
+//
+// - connect.UnaryInterceptorFunc: the unary server interceptor
+func (i Interceptor) Authorize() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(
+			ctx context.Context,
+			req connect.AnyRequest,
+		) (connect.AnyResponse, error) {
+			if err := i.authorize(ctx, req.Spec().Procedure); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// WrapUnary passes the unary calls through, as they are checked by the Authorize unary interceptor
+//
+// Parameters:
+//
+// - next: the next unary function
+//
+// Returns:
+//
+// - connect.UnaryFunc: the next unary function
+func (i Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+// WrapStreamingClient passes the outgoing streams through
+//
+// Parameters:
+//
+// - next: the next streaming client function
+//
+// Returns:
+//
+// - connect.StreamingClientFunc: the next streaming client function
+func (i Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler checks the policy of the called streaming procedure, which the Authorize unary interceptor
+// does not intercept
+//
+// Parameters:
+//
+// - next: the next streaming handler function
+//
+// Returns:
+//
+// - connect.StreamingHandlerFunc: the streaming handler function
+func (i Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.authorize(ctx, conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,131 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
+)
+
+// streamingHandlerConn is a streaming handler connection of a procedure
+type streamingHandlerConn struct {
+	connect.StreamingHandlerConn
+	procedure string
+}
+
+// Spec gets the specification of the procedure of the connection
+//
+// Returns:
+//
+// - connect.Spec: the procedure specification
+func (c streamingHandlerConn) Spec() connect.Spec {
+	return connect.Spec{Procedure: c.procedure, StreamType: connect.StreamTypeServer}
+}
+
+func TestPolicies(t *testing.T) {
+	for _, test := range []struct {
+		procedure string
+		roles     []string
+	}{
+		{v1connect.MoviesServiceListModerationQueueProcedure, []string{RoleAdmin, RoleModerator}},
+		{v1connect.MoviesServiceResolveContentReportProcedure, []string{RoleAdmin, RoleModerator}},
+		{v1connect.AdminServiceDeleteAnyUserMovieReviewProcedure, []string{RoleAdmin, RoleModerator}},
+		{v1connect.AdminServiceCreateWebhookSubscriptionProcedure, []string{RoleAdmin}},
+		{v1connect.AdminServiceListWebhookDeliveriesProcedure, []string{RoleAdmin}},
+		{v1connect.InternalServiceDeleteUserDataProcedure, []string{RoleService}},
+	} {
+		t.Run(
+			test.procedure, func(t *testing.T) {
+				policy, ok := Policies[test.procedure]
+				if !ok {
+					t.Fatal("procedure has no policy")
+				}
+				if !slices.Equal(policy.Roles, test.roles) {
+					t.Errorf("policy roles = %q, want %q", policy.Roles, test.roles)
+				}
+			},
+		)
+	}
+
+	// Every policy must require a role, as a policy without roles or scopes lets any user through
+	for procedure, policy := range Policies {
+		if len(policy.Roles) == 0 {
+			t.Errorf("policy of %s does not require any role", procedure)
+		}
+	}
+}
+
+func TestNewInterceptorNilPolicies(t *testing.T) {
+	if _, err := NewInterceptor(nil, nil); !errors.Is(err, ErrNilPolicies) {
+		t.Errorf("NewInterceptor() error = %v, want %v", err, ErrNilPolicies)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	const (
+		staffProcedure  = "/test.Service/Staff"
+		scopedProcedure = "/test.Service/Scoped"
+		openProcedure   = "/test.Service/Open"
+	)
+	interceptor, err := NewInterceptor(
+		map[string]Policy{
+			staffProcedure:  StaffPolicy,
+			scopedProcedure: {Roles: []string{RoleAdmin}, Scopes: []string{"webhooks:manage"}},
+		},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor() error = %v", err)
+	}
+
+	for _, test := range []struct {
+		name      string
+		procedure string
+		ctx       context.Context
+		wantErr   error
+	}{
+		{"procedure without policy", openProcedure, context.Background(), nil},
+		{"missing token claims", staffProcedure, context.Background(), ConnErrNilTokenClaims},
+		{"staff role", staffProcedure, contextWithClaims(map[string]any{RoleClaim: RoleModerator}), nil},
+		{"missing role", staffProcedure, contextWithClaims(map[string]any{RoleClaim: "user"}), ConnErrMissingRole},
+		{
+			"role and scope",
+			scopedProcedure,
+			contextWithClaims(map[string]any{RolesClaim: []any{RoleAdmin}, ScopeClaim: "webhooks:manage"}),
+			nil,
+		},
+		{
+			"missing scope",
+			scopedProcedure,
+			contextWithClaims(map[string]any{RoleClaim: RoleAdmin, ScopeClaim: "movies:read"}),
+			ConnErrMissingScope,
+		},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				// The unary and the streaming calls are checked alike
+				if err := interceptor.authorize(test.ctx, test.procedure); !errors.Is(err, test.wantErr) {
+					t.Errorf("authorize() error = %v, want %v", err, test.wantErr)
+				}
+
+				called := false
+				handler := interceptor.WrapStreamingHandler(
+					func(context.Context, connect.StreamingHandlerConn) error {
+						called = true
+						return nil
+					},
+				)
+				err := handler(test.ctx, streamingHandlerConn{procedure: test.procedure})
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("WrapStreamingHandler() error = %v, want %v", err, test.wantErr)
+				}
+				if called != (test.wantErr == nil) {
+					t.Errorf("WrapStreamingHandler() called the handler = %t, want %t", called, test.wantErr == nil)
+				}
+			},
+		)
+	}
+}
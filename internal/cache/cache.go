@@ -0,0 +1,213 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// Cache is the Redis cache for the gRPC responses
+	Cache struct {
+		client *redis.Client
+		logger *slog.Logger
+	}
+)
+
+// NewCache creates a new cache
+//
+// Parameters:
+//
+// - client: the Redis client
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Cache: the cache
+// - error: if there was an error creating the cache
+func NewCache(client *redis.Client, logger *slog.Logger) (*Cache, error) {
+	// Check if the Redis client is nil
+	if client == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	// Create the logger for the cache
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "cache"),
+		)
+	}
+
+	return &Cache{
+		client: client,
+		logger: logger,
+	}, nil
+}
+
+// Get gets a cached message. Cache errors are logged and treated as a cache miss, so the caller can always fall back
+// to the source of the message
+//
+// Parameters:
+//
+// - ctx: the context
+// - key: the cache key
+// - message: the message to unmarshal the cached value into
+//
+// Returns:
+//
+// - bool: true if the message was found in the cache, false otherwise
+func (c *Cache) Get(ctx context.Context, key string, message proto.Message) bool {
+	if c == nil {
+		panic(ErrNilCache)
+	}
+
+	// Get the cached value
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) && c.logger != nil {
+			c.logger.Warn(
+				"Could not get cached value",
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+		}
+		return false
+	}
+
+	// Unmarshal the cached value
+	if unmarshalErr := proto.Unmarshal(value, message); unmarshalErr != nil {
+		if c.logger != nil {
+			c.logger.Warn(
+				"Could not unmarshal cached value",
+				slog.String("key", key),
+				slog.String("error", unmarshalErr.Error()),
+			)
+		}
+		return false
+	}
+	return true
+}
+
+// Set caches a message. Cache errors are logged and ignored
+//
+// Parameters:
+//
+// - ctx: the context
+// - key: the cache key
+// - message: the message to cache
+// - ttl: the time to live of the cached message
+func (c *Cache) Set(ctx context.Context, key string, message proto.Message, ttl time.Duration) {
+	if c == nil {
+		panic(ErrNilCache)
+	}
+
+	// Marshal the message
+	value, err := proto.Marshal(message)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Warn(
+				"Could not marshal value to cache",
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+		}
+		return
+	}
+
+	// Cache the value
+	if setErr := c.client.Set(ctx, key, value, ttl).Err(); setErr != nil && c.logger != nil {
+		c.logger.Warn(
+			"Could not cache value",
+			slog.String("key", key),
+			slog.String("error", setErr.Error()),
+		)
+	}
+}
+
+// DeleteMatching deletes every cached value whose key matches the given pattern, scanning the keys in batches so
+// Redis is never blocked
+//
+// Parameters:
+//
+// - ctx: the context
+// - pattern: the Redis glob-style key pattern
+//
+// Returns:
+//
+// - int64: the number of deleted keys
+// - error: if there was an error deleting the keys
+func (c *Cache) DeleteMatching(ctx context.Context, pattern string) (int64, error) {
+	if c == nil {
+		panic(ErrNilCache)
+	}
+
+	var deleted int64
+	iter := c.client.Scan(ctx, 0, pattern, ScanBatchSize).Iterator()
+	keys := make([]string, 0, ScanBatchSize)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) < ScanBatchSize {
+			continue
+		}
+		count, err := c.client.Unlink(ctx, keys...).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+		keys = keys[:0]
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	if len(keys) > 0 {
+		count, err := c.client.Unlink(ctx, keys...).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// Invalidate deletes every cached value whose key matches the given pattern. Cache errors are logged and ignored,
+// so the stale values expire with their time to live
+//
+// Parameters:
+//
+// - ctx: the context
+// - pattern: the Redis glob-style key pattern
+func (c *Cache) Invalidate(ctx context.Context, pattern string) {
+	if c == nil {
+		panic(ErrNilCache)
+	}
+
+	if _, err := c.DeleteMatching(ctx, pattern); err != nil && c.logger != nil {
+		c.logger.Warn(
+			"Could not invalidate cached values",
+			slog.String("pattern", pattern),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// Size gets the number of keys of the Redis database
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - int64: the number of keys
+// - error: if there was an error getting the number of keys
+func (c *Cache) Size(ctx context.Context) (int64, error) {
+	if c == nil {
+		panic(ErrNilCache)
+	}
+
+	return c.client.DBSize(ctx).Result()
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvMovieListsTTL is the environment variable for the time to live of the cached movie lists
+	EnvMovieListsTTL = "CACHE_MOVIE_LISTS_TTL"
+
+	// EnvMoviesTTL is the environment variable for the time to live of the cached movies
+	EnvMoviesTTL = "CACHE_MOVIES_TTL"
+
+	// EnvFeedTTL is the environment variable for the time to live of the cached activity feed pages
+	EnvFeedTTL = "CACHE_FEED_TTL"
+)
+
+const (
+	// MoviesKeyPrefix is the prefix of every cached movie and movie list key
+	MoviesKeyPrefix = "movies:"
+
+	// FeedKeyPrefix is the prefix of every cached activity feed page key
+	FeedKeyPrefix = "feed:"
+
+	// MovieListKey is the cache key format for a movie list by list name, language, region and page
+	MovieListKey = "movies:list:%s:%s:%s:%d"
+
+	// MovieListsPattern is the cache key pattern of every movie list
+	MovieListsPattern = "movies:list:*"
+
+	// SimpleMovieKey is the cache key format for a simple movie by movie ID and language
+	SimpleMovieKey = "movies:movie:%d:simple:%s"
+
+	// MovieKeyPattern is the cache key pattern format of every cached value of a movie by movie ID
+	MovieKeyPattern = "movies:movie:%d:*"
+
+	// FeedKey is the cache key format for an activity feed page by user ID, language, page size and cursor
+	FeedKey = "feed:%s:%s:%d:%s"
+
+	// FeedPattern is the cache key pattern format of every cached activity feed page of a user by user ID
+	FeedPattern = "feed:%s:*"
+)
+
+const (
+	// ScanBatchSize is the number of keys scanned and deleted per batch when deleting the keys matching a pattern
+	ScanBatchSize = 500
+)
+
+const (
+	// NowPlayingMovieList is the cache list name of the now playing movies
+	NowPlayingMovieList = "now_playing"
+
+	// PopularMovieList is the cache list name of the popular movies
+	PopularMovieList = "popular"
+
+	// TopRatedMovieList is the cache list name of the top rated movies
+	TopRatedMovieList = "top_rated"
+
+	// UpcomingMovieList is the cache list name of the upcoming movies
+	UpcomingMovieList = "upcoming"
+
+	// TrendingMovieList is the cache list name format of the trending movies by time window
+	TrendingMovieList = "trending_%s"
+)
+
+var (
+	// MovieListsTTL is the time to live of the cached movie lists
+	MovieListsTTL time.Duration
+
+	// MoviesTTL is the time to live of the cached movies
+	MoviesTTL time.Duration
+
+	// FeedTTL is the time to live of the cached activity feed pages
+	FeedTTL time.Duration
+)
+
+// Load loads the cache constants
+func Load() {
+	// Get the time to live of the cached movie lists from the environment variable
+	if err := internalloader.Loader.LoadDurationVariable(
+		EnvMovieListsTTL,
+		&MovieListsTTL,
+	); err != nil {
+		panic(err)
+	}
+
+	// Get the time to live of the cached movies from the environment variable
+	if err := internalloader.Loader.LoadDurationVariable(
+		EnvMoviesTTL,
+		&MoviesTTL,
+	); err != nil {
+		panic(err)
+	}
+
+	// Get the time to live of the cached activity feed pages from the environment variable
+	if err := internalloader.Loader.LoadDurationVariable(
+		EnvFeedTTL,
+		&FeedTTL,
+	); err != nil {
+		panic(err)
+	}
+}
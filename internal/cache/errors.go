@@ -0,0 +1,10 @@
+package cache
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilCache       = errors.New("cache is nil")
+	ErrNilRedisClient = errors.New("redis client is nil")
+)
@@ -0,0 +1,99 @@
+package community
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvRefreshInterval is the environment variable for the community leaderboard refresh interval
+	EnvRefreshInterval = "COMMUNITY_TOP_MOVIES_REFRESH_INTERVAL"
+
+	// EnvHalfLife is the environment variable for the half-life of a review's weight in the community leaderboard
+	EnvHalfLife = "COMMUNITY_TOP_MOVIES_HALF_LIFE"
+
+	// EnvMinReviews is the environment variable for the minimum number of reviews a movie needs within the time
+	// window to enter the community leaderboard
+	EnvMinReviews = "COMMUNITY_TOP_MOVIES_MIN_REVIEWS"
+)
+
+const (
+	// LeaderboardSize is the maximum number of movies kept in each community leaderboard
+	LeaderboardSize = 100
+
+	// PageSize is the number of movies returned per community leaderboard page
+	PageSize = 20
+
+	// DayWindow is the duration of the day community leaderboard time window
+	DayWindow = 24 * time.Hour
+
+	// WeekWindow is the duration of the week community leaderboard time window
+	WeekWindow = 7 * 24 * time.Hour
+)
+
+const (
+	// CurrentVersionKey is the Redis key format of the current leaderboard version of a time window
+	CurrentVersionKey = "community:top:%s:current"
+
+	// TopMoviesKey is the Redis sorted set key format of a leaderboard version of a time window
+	TopMoviesKey = "community:top:%s:%s"
+
+	// TopMoviesByGenreKey is the Redis sorted set key format of a leaderboard version of a time window for a genre
+	TopMoviesByGenreKey = "community:top:%s:%s:genre:%d"
+
+	// MoviesKey is the Redis hash key format of the leaderboard entries of a leaderboard version of a time window
+	MoviesKey = "community:top:%s:%s:movies"
+
+	// VersionKeysKey is the Redis set key format of the keys of a leaderboard version of a time window, so they can be
+	// expired once a newer version replaces it
+	VersionKeysKey = "community:top:%s:%s:keys"
+
+	// RefreshLeaseKey is the Redis key of the lease held while refreshing the leaderboards, so the replicas and
+	// moviesctl do not refresh them at the same time
+	RefreshLeaseKey = "community:top:refresh:lease"
+)
+
+const (
+	// RefreshLeaseTTL is how long the refresh lease is held at most, in case its holder stops before releasing it
+	RefreshLeaseTTL = 10 * time.Minute
+
+	// RefreshLeaseTokenLength is the number of random bytes of the token of a refresh lease
+	RefreshLeaseTokenLength = 16
+)
+
+var (
+	// RefreshInterval is the community leaderboard refresh interval
+	RefreshInterval time.Duration
+
+	// HalfLife is the half-life of a review's weight in the community leaderboard
+	HalfLife time.Duration
+
+	// MinReviews is the minimum number of reviews a movie needs within the time window to enter the community
+	// leaderboard
+	MinReviews int
+)
+
+// Load loads the community leaderboard constants
+func Load() {
+	// Get the refresh interval and the half-life from the environment variables
+	for env, dest := range map[string]*time.Duration{
+		EnvRefreshInterval: &RefreshInterval,
+		EnvHalfLife:        &HalfLife,
+	} {
+		if err := internalloader.Loader.LoadDurationVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the minimum number of reviews from the environment variable
+	if err := internalloader.Loader.LoadIntVariable(
+		EnvMinReviews,
+		&MinReviews,
+	); err != nil {
+		panic(err)
+	}
+}
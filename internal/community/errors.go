@@ -0,0 +1,11 @@
+package community
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilLeaderboard = errors.New("community leaderboard is nil")
+	ErrNilRedisClient = errors.New("redis client is nil")
+	ErrRefreshRunning = errors.New("community leaderboard is being refreshed by another process")
+)
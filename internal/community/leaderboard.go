@@ -0,0 +1,418 @@
+package community
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+)
+
+type (
+	// Leaderboard is the community leaderboard of the movies our users are reviewing and rating highly, computed in
+	// the background and stored in Redis sorted sets
+	Leaderboard struct {
+		pool        *pgxpool.Pool
+		redisClient *redis.Client
+		tmdbClient  *internaltmdb.Client
+		logger      *slog.Logger
+	}
+
+	// scoredMovie is a movie scored from the recent user reviews
+	scoredMovie struct {
+		movieID       int32
+		reviewsCount  int32
+		averageRating float64
+		score         float64
+	}
+)
+
+var (
+	// releaseLeaseScript deletes a lease only if it is still held with the given token, so a lease that expired and
+	// was taken by another process is not released
+	releaseLeaseScript = redis.NewScript(
+		`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`,
+	)
+)
+
+// NewLeaderboard creates a new community leaderboard
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - redisClient: the Redis client
+// - tmdbClient: the TMDB API client
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Leaderboard: the community leaderboard
+// - error: if there was an error creating the community leaderboard
+func NewLeaderboard(
+	pool *pgxpool.Pool,
+	redisClient *redis.Client,
+	tmdbClient *internaltmdb.Client,
+	logger *slog.Logger,
+) (*Leaderboard, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the Redis client is nil
+	if redisClient == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	// Check if the TMDB API client is nil
+	if tmdbClient == nil {
+		return nil, gotmdbapi.ErrNilClient
+	}
+
+	// Create the logger for the leaderboard
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "community_leaderboard"),
+		)
+	}
+
+	return &Leaderboard{
+		pool:        pool,
+		redisClient: redisClient,
+		tmdbClient:  tmdbClient,
+		logger:      logger,
+	}, nil
+}
+
+// Run refreshes the community leaderboard right away and then on every refresh interval, until the context is done
+//
+// Parameters:
+//
+// - ctx: the context
+func (l *Leaderboard) Run(ctx context.Context) {
+	if l == nil {
+		panic(ErrNilLeaderboard)
+	}
+
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		// Skip the refresh if another replica is running it
+		if err := l.Refresh(ctx); err != nil && !errors.Is(err, ErrRefreshRunning) && l.logger != nil {
+			l.logger.Error(
+				"Could not refresh community leaderboard",
+				slog.String("error", err.Error()),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh recomputes the community leaderboard of every time window, holding a Redis lease so only one process
+// refreshes them at a time
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - error: ErrRefreshRunning if another process holds the lease, or if there was an error refreshing the community
+// leaderboard
+func (l *Leaderboard) Refresh(ctx context.Context) (err error) {
+	if l == nil {
+		panic(ErrNilLeaderboard)
+	}
+
+	// Take the refresh lease
+	tokenBytes := make([]byte, RefreshLeaseTokenLength)
+	if _, err = rand.Read(tokenBytes); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(tokenBytes)
+	acquired, err := l.redisClient.SetNX(ctx, RefreshLeaseKey, token, RefreshLeaseTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrRefreshRunning
+	}
+	defer func() {
+		if releaseErr := releaseLeaseScript.Run(
+			context.WithoutCancel(ctx),
+			l.redisClient,
+			[]string{RefreshLeaseKey},
+			token,
+		).Err(); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+
+	// Share the fetched movies between the time windows
+	movies := make(map[int32]*v1.SimpleMovie)
+
+	now := time.Now()
+	version := strconv.FormatInt(now.Unix(), 10)
+	for timeWindow, window := range map[string]time.Duration{
+		internaltmdb.TrendingTimeWindowDay:  DayWindow,
+		internaltmdb.TrendingTimeWindowWeek: WeekWindow,
+	} {
+		if err := l.refreshWindow(ctx, timeWindow, window, now, version, movies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshWindow recomputes the community leaderboard of a time window under a new version and then points the time
+// window to it, so readers never see a partially written leaderboard. The current version never expires, so the
+// leaderboard is still served while the refreshes fail, and the replaced version is expired once readers moved on
+//
+// Parameters:
+//
+// - ctx: the context
+// - timeWindow: the time window name
+// - window: the time window duration
+// - now: the time the refresh started at
+// - version: the new leaderboard version
+// - movies: the movies already fetched from TMDB, by ID
+//
+// Returns:
+//
+// - error: if there was an error refreshing the community leaderboard of the time window
+func (l *Leaderboard) refreshWindow(
+	ctx context.Context,
+	timeWindow string,
+	window time.Duration,
+	now time.Time,
+	version string,
+	movies map[int32]*v1.SimpleMovie,
+) error {
+	// Score the recently reviewed movies
+	scoredMovies, err := l.scoreMovies(ctx, now.Add(-window), now)
+	if err != nil {
+		return err
+	}
+
+	// Get the version to replace
+	currentVersionKey := fmt.Sprintf(CurrentVersionKey, timeWindow)
+	previousVersion, err := l.redisClient.Get(ctx, currentVersionKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	// Write the new version of the leaderboard
+	pipe := l.redisClient.TxPipeline()
+	topMoviesKey := fmt.Sprintf(TopMoviesKey, timeWindow, version)
+	moviesKey := fmt.Sprintf(MoviesKey, timeWindow, version)
+	genreKeys := make(map[string]struct{})
+	for _, scored := range scoredMovies {
+		// Get the movie from TMDB, if it was not fetched yet
+		movie, ok := movies[scored.movieID]
+		if !ok {
+			apiResponse, _, getErr := l.tmdbClient.GetMovieDetails(ctx, scored.movieID, "")
+			if getErr != nil {
+				if l.logger != nil {
+					l.logger.Warn(
+						"Could not get community leaderboard movie details",
+						slog.Int("movie_id", int(scored.movieID)),
+						slog.String("error", getErr.Error()),
+					)
+				}
+				continue
+			}
+			movie = internaltmdb.MapMovieDetailsToSimpleMovie(apiResponse)
+			movies[scored.movieID] = movie
+		}
+
+		// Marshal the leaderboard entry
+		entry, marshalErr := proto.Marshal(
+			&v1.CommunityTopMovie{
+				Movie:         movie,
+				Score:         scored.score,
+				ReviewsCount:  scored.reviewsCount,
+				AverageRating: scored.averageRating,
+			},
+		)
+		if marshalErr != nil {
+			return marshalErr
+		}
+
+		// Add the movie to the leaderboard and to the leaderboard of each of its genres
+		member := redis.Z{Score: scored.score, Member: scored.movieID}
+		pipe.ZAdd(ctx, topMoviesKey, member)
+		for _, genreID := range movie.GetGenreIds() {
+			genreKey := fmt.Sprintf(TopMoviesByGenreKey, timeWindow, version, genreID)
+			pipe.ZAdd(ctx, genreKey, member)
+			genreKeys[genreKey] = struct{}{}
+		}
+		pipe.HSet(ctx, moviesKey, scored.movieID, entry)
+	}
+	versionKeys := []any{topMoviesKey, moviesKey}
+	for genreKey := range genreKeys {
+		versionKeys = append(versionKeys, genreKey)
+	}
+	pipe.SAdd(ctx, fmt.Sprintf(VersionKeysKey, timeWindow, version), versionKeys...)
+
+	// Point the time window to the new version
+	pipe.Set(ctx, currentVersionKey, version, 0)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if previousVersion == "" || previousVersion == version {
+		return nil
+	}
+
+	// Keep the replaced version around long enough for in-flight readers
+	previousVersionKeysKey := fmt.Sprintf(VersionKeysKey, timeWindow, previousVersion)
+	previousKeys, err := l.redisClient.SMembers(ctx, previousVersionKeysKey).Result()
+	if err != nil {
+		return err
+	}
+	ttl := 2 * RefreshInterval
+	pipe = l.redisClient.Pipeline()
+	for _, key := range append(previousKeys, previousVersionKeysKey) {
+		pipe.Expire(ctx, key, ttl)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// scoreMovies scores the movies reviewed within the given time window with a time-decayed sum of their ratings
+//
+// Parameters:
+//
+// - ctx: the context
+// - since: the start of the time window
+// - now: the end of the time window
+//
+// Returns:
+//
+// - []scoredMovie: the scored movies, sorted from the highest to the lowest score
+// - error: if there was an error scoring the movies
+func (l *Leaderboard) scoreMovies(ctx context.Context, since, now time.Time) ([]scoredMovie, error) {
+	// A review's weight halves every half-life
+	decayConstant := HalfLife.Seconds() / math.Ln2
+
+	rows, err := l.pool.Query(
+		ctx,
+		internalpostgres.ScoreRecentUserReviewsQuery,
+		since,
+		now,
+		decayConstant,
+		MinReviews,
+		LeaderboardSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scoredMovies := make([]scoredMovie, 0, LeaderboardSize)
+	for rows.Next() {
+		var scored scoredMovie
+		if scanErr := rows.Scan(
+			&scored.movieID,
+			&scored.reviewsCount,
+			&scored.averageRating,
+			&scored.score,
+		); scanErr != nil {
+			return nil, scanErr
+		}
+		scoredMovies = append(scoredMovies, scored)
+	}
+	return scoredMovies, rows.Err()
+}
+
+// Top gets a page of the current community leaderboard of a time window
+//
+// Parameters:
+//
+// - ctx: the context
+// - timeWindow: the time window name
+// - genreID: the genre ID to filter by (optional)
+// - page: the page number, starting at 1
+//
+// Returns:
+//
+// - []*v1.CommunityTopMovie: the leaderboard entries of the page, from the highest to the lowest score
+// - int64: the total number of leaderboard entries
+// - error: if there was an error getting the community leaderboard
+func (l *Leaderboard) Top(
+	ctx context.Context,
+	timeWindow string,
+	genreID *int32,
+	page int32,
+) ([]*v1.CommunityTopMovie, int64, error) {
+	if l == nil {
+		panic(ErrNilLeaderboard)
+	}
+
+	// Get the current version of the leaderboard
+	version, err := l.redisClient.Get(ctx, fmt.Sprintf(CurrentVersionKey, timeWindow)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return []*v1.CommunityTopMovie{}, 0, nil
+		}
+		return nil, 0, err
+	}
+
+	// Get the sorted set to read from
+	topMoviesKey := fmt.Sprintf(TopMoviesKey, timeWindow, version)
+	if genreID != nil {
+		topMoviesKey = fmt.Sprintf(TopMoviesByGenreKey, timeWindow, version, *genreID)
+	}
+
+	// Get the total number of entries and the movie IDs of the page
+	page = max(page, 1)
+	start := int64((page - 1) * PageSize)
+	pipe := l.redisClient.Pipeline()
+	totalCmd := pipe.ZCard(ctx, topMoviesKey)
+	movieIDsCmd := pipe.ZRevRange(ctx, topMoviesKey, start, start+PageSize-1)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, 0, err
+	}
+	movieIDs := movieIDsCmd.Val()
+	if len(movieIDs) == 0 {
+		return []*v1.CommunityTopMovie{}, totalCmd.Val(), nil
+	}
+
+	// Get the leaderboard entries of the page
+	entries, err := l.redisClient.HMGet(ctx, fmt.Sprintf(MoviesKey, timeWindow, version), movieIDs...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	topMovies := make([]*v1.CommunityTopMovie, 0, len(entries))
+	for _, entry := range entries {
+		rawEntry, ok := entry.(string)
+		if !ok {
+			continue
+		}
+		topMovie := &v1.CommunityTopMovie{}
+		if unmarshalErr := proto.Unmarshal([]byte(rawEntry), topMovie); unmarshalErr != nil {
+			return nil, 0, unmarshalErr
+		}
+		topMovies = append(topMovies, topMovie)
+	}
+	return topMovies, totalCmd.Val(), nil
+}
@@ -0,0 +1,202 @@
+package connect
+
+import (
+	"context"
+	"log/slog"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
+
+	internalservice "github.com/ralvarezdev/connect-movies/internal/service"
+)
+
+type (
+	// AdminServer is the gRPC admin server
+	AdminServer struct {
+		logger  *slog.Logger
+		service *internalservice.Service
+		v1connect.UnimplementedAdminServiceHandler
+	}
+)
+
+// NewAdminServer creates a new gRPC admin server
+//
+// Parameters:
+//
+//   - service: the service for the server
+//   - logger: the logger
+//
+// Returns:
+//
+//   - *AdminServer: the gRPC admin server
+//   - error: if there was an error creating the server
+func NewAdminServer(service *internalservice.Service, logger *slog.Logger) (*AdminServer, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, internalservice.ErrNilService
+	}
+
+	// Create the logger for the gRPC admin server
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "grpc_admin_server"),
+		)
+	}
+
+	return &AdminServer{
+		service: service,
+		logger:  logger,
+	}, nil
+}
+
+func (s AdminServer) DeleteAnyUserMovieReview(
+	ctx context.Context,
+	request *v1.DeleteAnyUserMovieReviewRequest,
+) (*v1.DeleteAnyUserMovieReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to delete the user movie review
+	response, err := s.service.DeleteAnyUserMovieReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete any user movie review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) PurgeMovieCache(
+	ctx context.Context,
+	request *v1.PurgeMovieCacheRequest,
+) (*v1.PurgeMovieCacheResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to purge the movie cache
+	response, err := s.service.PurgeMovieCache(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to purge movie cache", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) GetSystemStats(
+	ctx context.Context,
+	request *v1.GetSystemStatsRequest,
+) (*v1.GetSystemStatsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the system stats
+	response, err := s.service.GetSystemStats(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get system stats", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) ListAdminAuditLog(
+	ctx context.Context,
+	request *v1.ListAdminAuditLogRequest,
+) (*v1.ListAdminAuditLogResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the admin audit log
+	response, err := s.service.ListAdminAuditLog(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list admin audit log", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) CreateWebhookSubscription(
+	ctx context.Context,
+	request *v1.CreateWebhookSubscriptionRequest,
+) (*v1.CreateWebhookSubscriptionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to create the webhook subscription
+	response, err := s.service.CreateWebhookSubscription(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to create webhook subscription", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) ListWebhookSubscriptions(
+	ctx context.Context,
+	request *v1.ListWebhookSubscriptionsRequest,
+) (*v1.ListWebhookSubscriptionsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the webhook subscriptions
+	response, err := s.service.ListWebhookSubscriptions(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list webhook subscriptions", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) DeleteWebhookSubscription(
+	ctx context.Context,
+	request *v1.DeleteWebhookSubscriptionRequest,
+) (*v1.DeleteWebhookSubscriptionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to delete the webhook subscription
+	response, err := s.service.DeleteWebhookSubscription(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete webhook subscription", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s AdminServer) ListWebhookDeliveries(
+	ctx context.Context,
+	request *v1.ListWebhookDeliveriesRequest,
+) (*v1.ListWebhookDeliveriesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the webhook deliveries
+	response, err := s.service.ListWebhookDeliveries(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list webhook deliveries", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
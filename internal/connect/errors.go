@@ -5,5 +5,6 @@ import (
 )
 
 var (
-	ErrInDevelopment = errors.New("rpc method in development")
+	ErrInDevelopment    = errors.New("rpc method in development")
+	ErrNilInterceptions = errors.New("interceptions are nil")
 )
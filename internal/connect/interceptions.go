@@ -0,0 +1,31 @@
+package connect
+
+import (
+	gojwttoken "github.com/ralvarezdev/go-jwt/token"
+	protomovies "github.com/ralvarezdev/proto-movies/gen/go"
+	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
+)
+
+var (
+	// PublicProcedures are the procedures that can be called without an access token
+	PublicProcedures = []string{
+		v1connect.MoviesServiceGetPublicUserMovieListProcedure,
+	}
+)
+
+// NewInterceptions creates the interceptions of the auth interceptor, copying the generated interceptions and
+// overriding the public procedures so they are not intercepted
+//
+// Returns:
+//
+//   - map[string]*gojwttoken.Token: the interceptions by procedure
+func NewInterceptions() map[string]*gojwttoken.Token {
+	interceptions := make(map[string]*gojwttoken.Token, len(protomovies.Interceptions))
+	for procedure, interception := range protomovies.Interceptions {
+		interceptions[procedure] = interception
+	}
+	for _, procedure := range PublicProcedures {
+		interceptions[procedure] = nil
+	}
+	return interceptions
+}
@@ -0,0 +1,69 @@
+package connect
+
+import (
+	"context"
+	"log/slog"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
+
+	internalservice "github.com/ralvarezdev/connect-movies/internal/service"
+)
+
+type (
+	// InternalServer is the gRPC server of the procedures called by the other internal services
+	InternalServer struct {
+		logger  *slog.Logger
+		service *internalservice.Service
+		v1connect.UnimplementedInternalServiceHandler
+	}
+)
+
+// NewInternalServer creates a new gRPC internal server
+//
+// Parameters:
+//
+//   - service: the service for the server
+//   - logger: the logger
+//
+// Returns:
+//
+//   - *InternalServer: the gRPC internal server
+//   - error: if there was an error creating the server
+func NewInternalServer(service *internalservice.Service, logger *slog.Logger) (*InternalServer, error) {
+	// Check if the service is nil
+	if service == nil {
+		return nil, internalservice.ErrNilService
+	}
+
+	// Create the logger for the gRPC internal server
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "grpc_internal_server"),
+		)
+	}
+
+	return &InternalServer{
+		service: service,
+		logger:  logger,
+	}, nil
+}
+
+func (s InternalServer) DeleteUserData(
+	ctx context.Context,
+	request *v1.DeleteUserDataRequest,
+) (*v1.DeleteUserDataResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to erase the data of the deleted user
+	response, err := s.service.DeleteUserData(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete user data", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	"connectrpc.com/connect"
 	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
 	"github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1/v1connect"
 
@@ -198,6 +199,82 @@ func (s Server) GetMovieDetails(
 	return response, nil
 }
 
+func (s Server) FindMovieByExternalId(
+	ctx context.Context,
+	request *v1.FindMovieByExternalIdRequest,
+) (*v1.FindMovieByExternalIdResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to find the movie by its external ID
+	response, err := s.service.FindMovieByExternalId(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error finding movie by external ID", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetMovieWatchProviders(
+	ctx context.Context,
+	request *v1.GetMovieWatchProvidersRequest,
+) (*v1.GetMovieWatchProvidersResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get movie watch providers
+	response, err := s.service.GetMovieWatchProviders(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting movie watch providers", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListWatchProviderRegions(
+	ctx context.Context,
+	request *v1.ListWatchProviderRegionsRequest,
+) (*v1.ListWatchProviderRegionsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list watch provider regions
+	response, err := s.service.ListWatchProviderRegions(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error listing watch provider regions", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetMovieVideos(
+	ctx context.Context,
+	request *v1.GetMovieVideosRequest,
+) (*v1.GetMovieVideosResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get movie videos
+	response, err := s.service.GetMovieVideos(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting movie videos", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
 func (s Server) GetMovieReviews(
 	ctx context.Context,
 	request *v1.GetMovieReviewsRequest,
@@ -330,3 +407,858 @@ func (s Server) GetUserMovieReview(
 	}
 	return response, nil
 }
+
+func (s Server) SearchKeywords(
+	ctx context.Context,
+	request *v1.SearchKeywordsRequest,
+) (*v1.SearchKeywordsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to search keywords
+	response, err := s.service.SearchKeywords(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error searching keywords", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) SearchCompanies(
+	ctx context.Context,
+	request *v1.SearchCompaniesRequest,
+) (*v1.SearchCompaniesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to search companies
+	response, err := s.service.SearchCompanies(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error searching companies", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetCollection(
+	ctx context.Context,
+	request *v1.GetCollectionRequest,
+) (*v1.GetCollectionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get collection
+	response, err := s.service.GetCollection(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting collection", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetCompanyDetails(
+	ctx context.Context,
+	request *v1.GetCompanyDetailsRequest,
+) (*v1.GetCompanyDetailsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get company details
+	response, err := s.service.GetCompanyDetails(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting company details", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetMovieCertifications(
+	ctx context.Context,
+	request *v1.GetMovieCertificationsRequest,
+) (*v1.GetMovieCertificationsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get movie certifications
+	response, err := s.service.GetMovieCertifications(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting movie certifications", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetMovieReleaseDates(
+	ctx context.Context,
+	request *v1.GetMovieReleaseDatesRequest,
+) (*v1.GetMovieReleaseDatesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get movie release dates
+	response, err := s.service.GetMovieReleaseDates(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting movie release dates", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetTrendingMovies(
+	ctx context.Context,
+	request *v1.GetTrendingMoviesRequest,
+) (*v1.GetTrendingMoviesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get trending movies
+	response, err := s.service.GetTrendingMovies(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting trending movies", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetCommunityTopMovies(
+	ctx context.Context,
+	request *v1.GetCommunityTopMoviesRequest,
+) (*v1.GetCommunityTopMoviesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get community top movies
+	response, err := s.service.GetCommunityTopMovies(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error getting community top movies", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) LikeUserMovieReview(
+	ctx context.Context,
+	request *v1.LikeUserMovieReviewRequest,
+) (*v1.LikeUserMovieReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to like user movie review
+	response, err := s.service.LikeUserMovieReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error liking user movie review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) UnlikeUserMovieReview(
+	ctx context.Context,
+	request *v1.UnlikeUserMovieReviewRequest,
+) (*v1.UnlikeUserMovieReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to unlike user movie review
+	response, err := s.service.UnlikeUserMovieReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error unliking user movie review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) MarkUserMovieReviewHelpful(
+	ctx context.Context,
+	request *v1.MarkUserMovieReviewHelpfulRequest,
+) (*v1.MarkUserMovieReviewHelpfulResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to mark user movie review as helpful
+	response, err := s.service.MarkUserMovieReviewHelpful(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error marking user movie review as helpful", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) UnmarkUserMovieReviewHelpful(
+	ctx context.Context,
+	request *v1.UnmarkUserMovieReviewHelpfulRequest,
+) (*v1.UnmarkUserMovieReviewHelpfulResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to unmark user movie review as helpful
+	response, err := s.service.UnmarkUserMovieReviewHelpful(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Error unmarking user movie review as helpful", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) CreateUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.CreateUserMovieReviewCommentRequest,
+) (*v1.CreateUserMovieReviewCommentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to create the user movie review comment
+	response, err := s.service.CreateUserMovieReviewComment(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to create user movie review comment", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) UpdateUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.UpdateUserMovieReviewCommentRequest,
+) (*v1.UpdateUserMovieReviewCommentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to update the user movie review comment
+	response, err := s.service.UpdateUserMovieReviewComment(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to update user movie review comment", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) DeleteUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.DeleteUserMovieReviewCommentRequest,
+) (*v1.DeleteUserMovieReviewCommentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to delete the user movie review comment
+	response, err := s.service.DeleteUserMovieReviewComment(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete user movie review comment", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserMovieReviewComments(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewCommentsRequest,
+) (*v1.ListUserMovieReviewCommentsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the user movie review comments
+	response, err := s.service.ListUserMovieReviewComments(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list user movie review comments", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListModerationQueue(
+	ctx context.Context,
+	request *v1.ListModerationQueueRequest,
+) (*v1.ListModerationQueueResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the moderation queue
+	response, err := s.service.ListModerationQueue(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list moderation queue", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ApproveUserMovieReview(
+	ctx context.Context,
+	request *v1.ApproveUserMovieReviewRequest,
+) (*v1.ApproveUserMovieReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to approve the user movie review
+	response, err := s.service.ApproveUserMovieReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to approve user movie review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) RejectUserMovieReview(
+	ctx context.Context,
+	request *v1.RejectUserMovieReviewRequest,
+) (*v1.RejectUserMovieReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to reject the user movie review
+	response, err := s.service.RejectUserMovieReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to reject user movie review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserMovieReviewModerationAudit(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewModerationAuditRequest,
+) (*v1.ListUserMovieReviewModerationAuditResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the user movie review moderation audit
+	response, err := s.service.ListUserMovieReviewModerationAudit(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list user movie review moderation audit", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ReportReview(
+	ctx context.Context,
+	request *v1.ReportReviewRequest,
+) (*v1.ReportReviewResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to report the review
+	response, err := s.service.ReportReview(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to report review", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ReportUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.ReportUserMovieReviewCommentRequest,
+) (*v1.ReportUserMovieReviewCommentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to report the user movie review comment
+	response, err := s.service.ReportUserMovieReviewComment(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to report user movie review comment", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListContentReports(
+	ctx context.Context,
+	request *v1.ListContentReportsRequest,
+) (*v1.ListContentReportsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the content reports
+	response, err := s.service.ListContentReports(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list content reports", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ResolveContentReport(
+	ctx context.Context,
+	request *v1.ResolveContentReportRequest,
+) (*v1.ResolveContentReportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to resolve the content report
+	response, err := s.service.ResolveContentReport(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to resolve content report", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserMovieReviewRevisions(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewRevisionsRequest,
+) (*v1.ListUserMovieReviewRevisionsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the user movie review revisions
+	response, err := s.service.ListUserMovieReviewRevisions(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list user movie review revisions", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) CreateUserMovieList(
+	ctx context.Context,
+	request *v1.CreateUserMovieListRequest,
+) (*v1.CreateUserMovieListResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to create the user movie list
+	response, err := s.service.CreateUserMovieList(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to create user movie list", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) UpdateUserMovieList(
+	ctx context.Context,
+	request *v1.UpdateUserMovieListRequest,
+) (*v1.UpdateUserMovieListResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to update the user movie list
+	response, err := s.service.UpdateUserMovieList(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to update user movie list", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) DeleteUserMovieList(
+	ctx context.Context,
+	request *v1.DeleteUserMovieListRequest,
+) (*v1.DeleteUserMovieListResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to delete the user movie list
+	response, err := s.service.DeleteUserMovieList(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete user movie list", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserMovieLists(
+	ctx context.Context,
+	request *v1.ListUserMovieListsRequest,
+) (*v1.ListUserMovieListsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the user movie lists
+	response, err := s.service.ListUserMovieLists(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list user movie lists", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetUserMovieList(
+	ctx context.Context,
+	request *v1.GetUserMovieListRequest,
+) (*v1.GetUserMovieListResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the user movie list
+	response, err := s.service.GetUserMovieList(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get user movie list", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetPublicUserMovieList(
+	ctx context.Context,
+	request *v1.GetPublicUserMovieListRequest,
+) (*v1.GetPublicUserMovieListResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the public user movie list
+	response, err := s.service.GetPublicUserMovieList(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get public user movie list", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) AddUserMovieListItem(
+	ctx context.Context,
+	request *v1.AddUserMovieListItemRequest,
+) (*v1.AddUserMovieListItemResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to add the user movie list item
+	response, err := s.service.AddUserMovieListItem(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to add user movie list item", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) RemoveUserMovieListItem(
+	ctx context.Context,
+	request *v1.RemoveUserMovieListItemRequest,
+) (*v1.RemoveUserMovieListItemResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to remove the user movie list item
+	response, err := s.service.RemoveUserMovieListItem(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to remove user movie list item", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ReorderUserMovieListItems(
+	ctx context.Context,
+	request *v1.ReorderUserMovieListItemsRequest,
+) (*v1.ReorderUserMovieListItemsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to reorder the user movie list items
+	response, err := s.service.ReorderUserMovieListItems(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to reorder user movie list items", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) FollowUser(
+	ctx context.Context,
+	request *v1.FollowUserRequest,
+) (*v1.FollowUserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to follow the user
+	response, err := s.service.FollowUser(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to follow user", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) UnfollowUser(
+	ctx context.Context,
+	request *v1.UnfollowUserRequest,
+) (*v1.UnfollowUserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to unfollow the user
+	response, err := s.service.UnfollowUser(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to unfollow user", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserFollowers(
+	ctx context.Context,
+	request *v1.ListUserFollowersRequest,
+) (*v1.ListUserFollowersResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the user followers
+	response, err := s.service.ListUserFollowers(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list user followers", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ListUserFollowing(
+	ctx context.Context,
+	request *v1.ListUserFollowingRequest,
+) (*v1.ListUserFollowingResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to list the followed users
+	response, err := s.service.ListUserFollowing(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to list followed users", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetActivityFeed(
+	ctx context.Context,
+	request *v1.GetActivityFeedRequest,
+) (*v1.GetActivityFeedResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the activity feed
+	response, err := s.service.GetActivityFeed(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get activity feed", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ExportMyData(
+	ctx context.Context,
+	request *v1.ExportMyDataRequest,
+) (*v1.ExportMyDataResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to request the data export
+	response, err := s.service.ExportMyData(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to request data export", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetMyDataExport(
+	ctx context.Context,
+	request *v1.GetMyDataExportRequest,
+) (*v1.GetMyDataExportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the data export
+	response, err := s.service.GetMyDataExport(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get data export", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) DeleteMyAccountData(
+	ctx context.Context,
+	request *v1.DeleteMyAccountDataRequest,
+) (*v1.DeleteMyAccountDataResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to erase the account data
+	response, err := s.service.DeleteMyAccountData(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to delete account data", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ImportUserMovieReviews(
+	ctx context.Context,
+	stream *connect.ClientStream[v1.ImportUserMovieReviewsRequest],
+) (*v1.ImportUserMovieReviewsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to import the user movie reviews
+	response, err := s.service.ImportUserMovieReviews(ctx, stream)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to import user movie reviews", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) GetUserReviewImport(
+	ctx context.Context,
+	request *v1.GetUserReviewImportRequest,
+) (*v1.GetUserReviewImportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Call the service to get the review import
+	response, err := s.service.GetUserReviewImport(ctx, request)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to get review import", slog.String("error", err.Error()))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) ExportUserMovieData(
+	ctx context.Context,
+	request *v1.ExportUserMovieDataRequest,
+	stream *connect.ServerStream[v1.ExportUserMovieDataResponse],
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Call the service to export the user movie data
+	if err := s.service.ExportUserMovieData(ctx, request, stream); err != nil {
+		if s.logger != nil {
+			s.logger.Error("Failed to export user movie data", slog.String("error", err.Error()))
+		}
+		return err
+	}
+	return nil
+}
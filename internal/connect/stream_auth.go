@@ -0,0 +1,142 @@
+package connect
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	goconnect "github.com/ralvarezdev/go-connect"
+	goconnectauth "github.com/ralvarezdev/go-connect/server/interceptor/auth"
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+	gojwttoken "github.com/ralvarezdev/go-jwt/token"
+	gojwtvalidator "github.com/ralvarezdev/go-jwt/token/validator"
+)
+
+type (
+	// StreamAuthInterceptor is the authentication interceptor of the streaming procedures, which the unary auth
+	// interceptor does not intercept. It validates the access token of the stream headers and sets its claims to the
+	// context, without refreshing expired tokens
+	StreamAuthInterceptor struct {
+		validator     gojwtvalidator.Validator
+		interceptions map[string]*gojwttoken.Token
+		logger        *slog.Logger
+	}
+)
+
+// NewStreamAuthInterceptor creates a new stream authentication interceptor
+//
+// Parameters:
+//
+//   - validator: the JWT validator
+//   - interceptions: the interceptions by procedure
+//   - logger: the logger (optional)
+//
+// Returns:
+//
+//   - *StreamAuthInterceptor: the stream authentication interceptor
+//   - error: if there was an error creating the stream authentication interceptor
+func NewStreamAuthInterceptor(
+	validator gojwtvalidator.Validator,
+	interceptions map[string]*gojwttoken.Token,
+	logger *slog.Logger,
+) (*StreamAuthInterceptor, error) {
+	// Check if either the validator or the interceptions are nil
+	if validator == nil {
+		return nil, gojwtvalidator.ErrNilValidator
+	}
+	if interceptions == nil {
+		return nil, ErrNilInterceptions
+	}
+
+	// Create the logger for the interceptor
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "stream_auth_interceptor"),
+		)
+	}
+
+	return &StreamAuthInterceptor{
+		validator:     validator,
+		interceptions: interceptions,
+		logger:        logger,
+	}, nil
+}
+
+// WrapUnary passes the unary calls through, as they are intercepted by the unary auth interceptor
+//
+// Parameters:
+//
+//   - next: the next unary function
+//
+// Returns:
+//
+//   - connect.UnaryFunc: the next unary function
+func (i StreamAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+// WrapStreamingClient passes the outgoing streams through
+//
+// Parameters:
+//
+//   - next: the next streaming client function
+//
+// Returns:
+//
+//   - connect.StreamingClientFunc: the next streaming client function
+func (i StreamAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler authenticates the incoming streams of the intercepted procedures
+//
+// Parameters:
+//
+//   - next: the next streaming handler function
+//
+// Returns:
+//
+//   - connect.StreamingHandlerFunc: the streaming handler function
+func (i StreamAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		// Check if the procedure should be intercepted, rejecting the unknown procedures for security reasons
+		procedure := conn.Spec().Procedure
+		interception, ok := i.interceptions[procedure]
+		if !ok {
+			if i.logger != nil {
+				i.logger.Error(
+					"Interception not found for procedure. Cancelling call for security reasons.",
+					slog.String("procedure", procedure),
+				)
+			}
+			return connect.NewError(connect.CodeInternal, goconnect.ErrInternalServerError)
+		}
+		if interception == nil {
+			return next(ctx, conn)
+		}
+
+		// Get the cookie name and custom header based on the interception type
+		cookieName := goconnect.AccessTokenCookieName
+		if *interception == gojwttoken.RefreshToken {
+			cookieName = goconnect.RefreshTokenCookieName
+		}
+		customHeader := goconnect.RefreshTokenKey
+
+		// Extract the token from the stream headers
+		token, err := goconnectauth.FindAuthorizationToken(conn.RequestHeader(), &customHeader, &cookieName)
+		if err != nil {
+			return connect.NewError(connect.CodeUnauthenticated, goconnectauth.ErrUnauthenticated)
+		}
+
+		// Validate the token and get the validated claims
+		claims, err := i.validator.ValidateClaims(ctx, token, *interception)
+		if err != nil {
+			return connect.NewError(connect.CodeUnauthenticated, err)
+		}
+
+		// Set the raw token and token claims to the context
+		ctx = gojwtgrpc.SetCtxToken(ctx, token)
+		ctx = gojwtgrpc.SetCtxTokenClaims(ctx, claims)
+		return next(ctx, conn)
+	}
+}
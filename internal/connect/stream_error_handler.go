@@ -0,0 +1,127 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"connectrpc.com/connect"
+	goconnect "github.com/ralvarezdev/go-connect"
+	goflags "github.com/ralvarezdev/go-flags"
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+)
+
+type (
+	// StreamErrorHandlerInterceptor is the error handler interceptor of the streaming procedures, which the unary
+	// error handler interceptor does not intercept. It recovers the panics of the stream handlers, like the service
+	// layer ones on unexpected errors, and returns them as internal errors
+	StreamErrorHandlerInterceptor struct {
+		modeFlag *goflagsmode.Flag
+		logger   *slog.Logger
+	}
+)
+
+// NewStreamErrorHandlerInterceptor creates a new stream error handler interceptor
+//
+// Parameters:
+//
+//   - modeFlag: the mode flag, to hide the panic details in production
+//   - logger: the logger (optional)
+//
+// Returns:
+//
+//   - *StreamErrorHandlerInterceptor: the stream error handler interceptor
+//   - error: if there was an error creating the stream error handler interceptor
+func NewStreamErrorHandlerInterceptor(
+	modeFlag *goflagsmode.Flag,
+	logger *slog.Logger,
+) (*StreamErrorHandlerInterceptor, error) {
+	// Check if the mode flag is nil
+	if modeFlag == nil {
+		return nil, goflags.ErrNilFlag
+	}
+
+	// Create the logger for the interceptor
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "stream_error_handler_interceptor"),
+		)
+	}
+
+	return &StreamErrorHandlerInterceptor{
+		modeFlag: modeFlag,
+		logger:   logger,
+	}, nil
+}
+
+// WrapUnary passes the unary calls through, as they are intercepted by the unary error handler interceptor
+//
+// Parameters:
+//
+//   - next: the next unary function
+//
+// Returns:
+//
+//   - connect.UnaryFunc: the next unary function
+func (i StreamErrorHandlerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+// WrapStreamingClient passes the outgoing streams through
+//
+// Parameters:
+//
+//   - next: the next streaming client function
+//
+// Returns:
+//
+//   - connect.StreamingClientFunc: the next streaming client function
+func (i StreamErrorHandlerInterceptor) WrapStreamingClient(
+	next connect.StreamingClientFunc,
+) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler recovers the panics of the incoming streams
+//
+// Parameters:
+//
+//   - next: the next streaming handler function
+//
+// Returns:
+//
+//   - connect.StreamingHandlerFunc: the streaming handler function
+func (i StreamErrorHandlerInterceptor) WrapStreamingHandler(
+	next connect.StreamingHandlerFunc,
+) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			stack := debug.Stack()
+			if i.logger != nil {
+				i.logger.Error(
+					"Panic recovered",
+					slog.String("procedure", conn.Spec().Procedure),
+					slog.Any("error", r),
+					slog.String("stack_trace", string(stack)),
+				)
+			}
+
+			// Hide the panic details in production
+			if i.modeFlag.IsProd() {
+				err = connect.NewError(connect.CodeInternal, goconnect.ErrInternalServerError)
+				return
+			}
+			err = connect.NewError(
+				connect.CodeInternal,
+				fmt.Errorf("panic: %v\nstack trace:\n%s", r, string(stack)),
+			)
+		}()
+		return next(ctx, conn)
+	}
+}
@@ -20,6 +20,36 @@ const (
 	EnvMaxIdleConnections = "POSTGRES_MAX_IDLE_CONNECTIONS"
 )
 
+const (
+	// UserMovieListsUniqueUserName is the unique constraint of the user movie list names of each user
+	UserMovieListsUniqueUserName = "user_movie_lists_unique_user_name"
+
+	// UserReviewImportsActiveUserKey is the unique index of the imports in progress of each user
+	UserReviewImportsActiveUserKey = "user_review_imports_active_user_key"
+)
+
+const (
+	// UserReviewImportRowsTable is the table of the rows of the review imports
+	UserReviewImportRowsTable = "user_review_import_rows"
+)
+
+var (
+	// UserReviewImportRowsColumns are the columns copied when inserting the rows of a review import
+	UserReviewImportRowsColumns = []string{
+		"import_id",
+		"row_number",
+		"title",
+		"year",
+		"imdb_id",
+		"rating",
+		"review_text",
+		"tags",
+		"watched_on",
+		"status",
+		"reason",
+	}
+)
+
 var (
 	// DSN is the DSN for the Postgres database
 	DSN string
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS user_reviews_movie_likes_idx;
+
+DROP INDEX IF EXISTS user_reviews_movie_helpful_idx;
+
+DROP TABLE IF EXISTS user_review_votes;
+
+ALTER TABLE user_reviews
+    DROP COLUMN IF EXISTS helpful_count,
+    DROP COLUMN IF EXISTS likes_count;
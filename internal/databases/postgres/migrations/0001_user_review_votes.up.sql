@@ -0,0 +1,25 @@
+-- Denormalized vote counts of the user reviews
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS likes_count   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS helpful_count INTEGER NOT NULL DEFAULT 0;
+
+-- Votes cast by users on other users' reviews
+CREATE TABLE IF NOT EXISTS user_review_votes
+(
+    voter_user_id  BIGINT      NOT NULL,
+    review_user_id BIGINT      NOT NULL,
+    movie_id       INTEGER     NOT NULL,
+    vote_type      TEXT        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_review_votes_pkey PRIMARY KEY (voter_user_id, review_user_id, movie_id, vote_type),
+    CONSTRAINT user_review_votes_vote_type_check CHECK (vote_type IN ('like', 'helpful')),
+    CONSTRAINT user_review_votes_not_own_review_check CHECK (voter_user_id <> review_user_id),
+    CONSTRAINT user_review_votes_review_fkey FOREIGN KEY (review_user_id, movie_id)
+        REFERENCES user_reviews (user_id, movie_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_review_votes_review_idx ON user_review_votes (review_user_id, movie_id);
+
+CREATE INDEX IF NOT EXISTS user_reviews_movie_helpful_idx ON user_reviews (movie_id, helpful_count DESC);
+
+CREATE INDEX IF NOT EXISTS user_reviews_movie_likes_idx ON user_reviews (movie_id, likes_count DESC);
@@ -0,0 +1,30 @@
+-- Usernames of the users, copied from Redis when they comment or review, and used as a fallback when they are no
+-- longer cached there
+CREATE TABLE IF NOT EXISTS users
+(
+    id       BIGINT PRIMARY KEY,
+    username TEXT NOT NULL
+);
+
+-- Comments on user reviews, with one level of replies. The author is cleared when the author's data is erased, so
+-- the replies of the other users keep their thread
+CREATE TABLE IF NOT EXISTS user_review_comments
+(
+    id             BIGSERIAL PRIMARY KEY,
+    review_user_id BIGINT      NOT NULL,
+    movie_id       INTEGER     NOT NULL,
+    user_id        BIGINT,
+    parent_id      BIGINT REFERENCES user_review_comments (id) ON DELETE CASCADE,
+    content        TEXT        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    CONSTRAINT user_review_comments_review_fkey FOREIGN KEY (review_user_id, movie_id)
+        REFERENCES user_reviews (user_id, movie_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_review_comments_review_idx
+    ON user_review_comments (review_user_id, movie_id, parent_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS user_review_comments_user_idx ON user_review_comments (user_id);
+
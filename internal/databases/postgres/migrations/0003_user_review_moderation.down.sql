@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS user_review_moderation_audit;
+
+DROP INDEX IF EXISTS user_reviews_pending_idx;
+
+ALTER TABLE user_reviews
+    DROP CONSTRAINT IF EXISTS user_reviews_moderation_status_check,
+    DROP COLUMN IF EXISTS moderation_reasons,
+    DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,30 @@
+-- Moderation state of the user reviews. Flagged reviews stay pending until an admin approves or rejects them
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS moderation_status  TEXT   NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS moderation_reasons TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE user_reviews
+    ADD CONSTRAINT user_reviews_moderation_status_check
+        CHECK (moderation_status IN ('published', 'pending', 'rejected'));
+
+CREATE INDEX IF NOT EXISTS user_reviews_pending_idx
+    ON user_reviews (COALESCE(updated_at, created_at))
+    WHERE moderation_status = 'pending';
+
+-- Audit trail of the moderation decisions. It has no foreign key so it outlives the deleted reviews
+CREATE TABLE IF NOT EXISTS user_review_moderation_audit
+(
+    id             BIGSERIAL PRIMARY KEY,
+    review_user_id BIGINT      NOT NULL,
+    movie_id       INTEGER     NOT NULL,
+    action         TEXT        NOT NULL,
+    actor_user_id  BIGINT,
+    reasons        TEXT[]      NOT NULL DEFAULT '{}',
+    note           TEXT,
+    review_text    TEXT,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_review_moderation_audit_action_check CHECK (action IN ('flagged', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS user_review_moderation_audit_review_idx
+    ON user_review_moderation_audit (review_user_id, movie_id, created_at);
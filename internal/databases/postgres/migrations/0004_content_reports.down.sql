@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS content_reports;
+
+ALTER TABLE user_review_comments
+    DROP COLUMN IF EXISTS hidden_at;
+
+ALTER TABLE user_reviews
+    DROP COLUMN IF EXISTS reports_held_at;
@@ -0,0 +1,42 @@
+-- Comments hidden automatically after being reported, pending an admin decision
+ALTER TABLE user_review_comments
+    ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+
+-- Time a user review was held as pending after being reported, so dismissing the reports only publishes again the
+-- reviews the reports hid, and not the ones flagged by the moderation rules
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS reports_held_at TIMESTAMPTZ;
+
+-- Abuse reports on user reviews and on their comments. A report on a comment has a comment ID
+CREATE TABLE IF NOT EXISTS content_reports
+(
+    id               BIGSERIAL PRIMARY KEY,
+    reporter_user_id BIGINT      NOT NULL,
+    review_user_id   BIGINT      NOT NULL,
+    movie_id         INTEGER     NOT NULL,
+    comment_id       BIGINT REFERENCES user_review_comments (id) ON DELETE CASCADE,
+    reason           TEXT        NOT NULL,
+    details          TEXT,
+    status           TEXT        NOT NULL DEFAULT 'open',
+    resolved_by      BIGINT,
+    resolved_at      TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT content_reports_reason_check
+        CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'spoilers', 'sexual_content', 'other')),
+    CONSTRAINT content_reports_status_check CHECK (status IN ('open', 'dismissed', 'upheld')),
+    CONSTRAINT content_reports_review_fkey FOREIGN KEY (review_user_id, movie_id)
+        REFERENCES user_reviews (user_id, movie_id) ON DELETE CASCADE
+);
+
+-- Each user can report a review or a comment only once
+CREATE UNIQUE INDEX IF NOT EXISTS content_reports_review_reporter_key
+    ON content_reports (reporter_user_id, review_user_id, movie_id)
+    WHERE comment_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS content_reports_comment_reporter_key
+    ON content_reports (reporter_user_id, comment_id)
+    WHERE comment_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS content_reports_open_idx
+    ON content_reports (created_at)
+    WHERE status = 'open';
@@ -0,0 +1,14 @@
+-- Audit log of the actions taken by admins and moderators
+CREATE TABLE IF NOT EXISTS admin_audit_log
+(
+    id            BIGSERIAL PRIMARY KEY,
+    actor_user_id BIGINT      NOT NULL,
+    action        TEXT        NOT NULL,
+    target        TEXT        NOT NULL,
+    details       JSONB       NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_created_at_idx ON admin_audit_log (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_actor_idx ON admin_audit_log (actor_user_id, created_at DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE user_reviews
+    DROP COLUMN IF EXISTS version;
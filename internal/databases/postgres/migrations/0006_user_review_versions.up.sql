@@ -0,0 +1,3 @@
+-- Version of the user reviews, increased on every edit so concurrent edits can be detected
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,23 @@
+-- Previous versions of the user reviews, written every time a review is edited. Revisions outlive the reviews removed
+-- by the staff, so those removals can still be reviewed: the revisions of a review deleted by the staff are archived
+-- instead of deleted, while the authors' own deletions and the user erasures delete them explicitly
+CREATE TABLE IF NOT EXISTS user_review_revisions
+(
+    id                BIGSERIAL PRIMARY KEY,
+    review_user_id    BIGINT      NOT NULL,
+    movie_id          INTEGER     NOT NULL,
+    version           BIGINT      NOT NULL,
+    rating            INTEGER     NOT NULL,
+    review_text       TEXT,
+    contains_spoilers BOOLEAN     NOT NULL DEFAULT FALSE,
+    spoiler_spans     JSONB       NOT NULL DEFAULT '[]',
+    tags              TEXT[]      NOT NULL DEFAULT '{}',
+    watched_on        DATE,
+    written_at        TIMESTAMPTZ NOT NULL,
+    replaced_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    archived_at       TIMESTAMPTZ
+);
+
+-- A review written again after being deleted by the staff starts its versions from scratch
+CREATE UNIQUE INDEX IF NOT EXISTS user_review_revisions_unique_version
+    ON user_review_revisions (review_user_id, movie_id, version) WHERE archived_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS user_reviews_tags_idx;
+
+ALTER TABLE user_reviews
+    DROP COLUMN IF EXISTS watched_on,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS spoiler_spans,
+    DROP COLUMN IF EXISTS contains_spoilers;
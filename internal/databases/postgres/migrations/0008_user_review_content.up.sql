@@ -0,0 +1,9 @@
+-- Spoiler marking, tags and the watched on date of the user reviews. The spoiler spans are the [start, end) rune
+-- offsets of the inline spoilers in the review text
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS contains_spoilers BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS spoiler_spans     JSONB   NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS tags              TEXT[]  NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS watched_on        DATE;
+
+CREATE INDEX IF NOT EXISTS user_reviews_tags_idx ON user_reviews USING GIN (tags);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_movie_list_items;
+
+DROP TABLE IF EXISTS user_movie_lists;
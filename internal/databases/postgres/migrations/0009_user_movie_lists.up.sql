@@ -0,0 +1,25 @@
+-- Named movie lists curated by the users, private unless shared publicly
+CREATE TABLE IF NOT EXISTS user_movie_lists
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT      NOT NULL,
+    name        TEXT        NOT NULL,
+    description TEXT        NOT NULL DEFAULT '',
+    is_public   BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_movie_lists_unique_user_name UNIQUE (user_id, name)
+);
+
+-- Movies of the user movie lists. Positions are zero-based and kept contiguous by the service
+CREATE TABLE IF NOT EXISTS user_movie_list_items
+(
+    list_id  BIGINT      NOT NULL REFERENCES user_movie_lists (id) ON DELETE CASCADE,
+    movie_id INTEGER     NOT NULL,
+    position INTEGER     NOT NULL,
+    note     TEXT        NOT NULL DEFAULT '',
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, movie_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_movie_list_items_position_idx ON user_movie_list_items (list_id, position);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS user_movie_list_items_activity_idx;
+
+DROP INDEX IF EXISTS user_reviews_activity_idx;
+
+ALTER TABLE user_movie_list_items
+    DROP COLUMN IF EXISTS activity_id;
+
+ALTER TABLE user_reviews
+    DROP COLUMN IF EXISTS activity_id;
+
+DROP SEQUENCE IF EXISTS activity_id_seq;
+
+DROP TABLE IF EXISTS user_follows;
@@ -0,0 +1,26 @@
+-- Follow graph between the users
+CREATE TABLE IF NOT EXISTS user_follows
+(
+    follower_user_id BIGINT      NOT NULL,
+    followed_user_id BIGINT      NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_user_id, followed_user_id),
+    CONSTRAINT user_follows_not_self_check CHECK (follower_user_id <> followed_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_follows_followed_idx ON user_follows (followed_user_id, created_at);
+
+-- Activity IDs shared by the user reviews and the user movie list items, so the activity feed built from both tables
+-- has a unique tiebreaker for its cursor pagination
+CREATE SEQUENCE IF NOT EXISTS activity_id_seq;
+
+ALTER TABLE user_reviews
+    ADD COLUMN IF NOT EXISTS activity_id BIGINT NOT NULL DEFAULT nextval('activity_id_seq');
+
+ALTER TABLE user_movie_list_items
+    ADD COLUMN IF NOT EXISTS activity_id BIGINT NOT NULL DEFAULT nextval('activity_id_seq');
+
+CREATE INDEX IF NOT EXISTS user_reviews_activity_idx ON user_reviews (user_id, created_at, activity_id);
+
+CREATE INDEX IF NOT EXISTS user_movie_list_items_activity_idx
+    ON user_movie_list_items (list_id, added_at, activity_id);
@@ -0,0 +1,19 @@
+-- Transactional outbox of the domain events, written in the same transaction as the change they describe and
+-- published to Redis Streams by the outbox relay
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id               BIGSERIAL PRIMARY KEY,
+    event_type       TEXT        NOT NULL,
+    aggregate_id     TEXT        NOT NULL,
+    payload          JSONB       NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts         INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error       TEXT,
+    published_at     TIMESTAMPTZ,
+    dead_lettered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx
+    ON outbox_events (next_attempt_at, id)
+    WHERE published_at IS NULL AND dead_lettered_at IS NULL;
@@ -0,0 +1,27 @@
+-- Data exports requested by the users, built in the background as a ZIP bundle kept until it expires
+CREATE TABLE IF NOT EXISTS user_data_exports
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT      NOT NULL,
+    status       TEXT        NOT NULL DEFAULT 'pending',
+    bundle       BYTEA,
+    error        TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at   TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    CONSTRAINT user_data_exports_status_check CHECK (status IN ('pending', 'running', 'succeeded', 'failed'))
+);
+
+-- Each user can have only one export in progress
+CREATE UNIQUE INDEX IF NOT EXISTS user_data_exports_active_user_key
+    ON user_data_exports (user_id)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX IF NOT EXISTS user_data_exports_pending_idx
+    ON user_data_exports (created_at)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX IF NOT EXISTS user_data_exports_expires_at_idx
+    ON user_data_exports (expires_at)
+    WHERE expires_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_review_import_rows;
+
+DROP TABLE IF EXISTS user_review_imports;
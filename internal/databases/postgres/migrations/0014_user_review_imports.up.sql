@@ -0,0 +1,51 @@
+-- Imports of the ratings exported from other services, processed in the background row by row. The rows that could
+-- not be processed yet stay pending, so an import is retried until every row is imported, skipped or unmatched
+CREATE TABLE IF NOT EXISTS user_review_imports
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT      NOT NULL,
+    source       TEXT        NOT NULL,
+    status       TEXT        NOT NULL DEFAULT 'pending',
+    total_rows   INTEGER     NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT user_review_imports_source_check CHECK (source IN ('letterboxd', 'imdb')),
+    CONSTRAINT user_review_imports_status_check CHECK (status IN ('pending', 'running', 'succeeded'))
+);
+
+-- Each user can have only one import in progress
+CREATE UNIQUE INDEX IF NOT EXISTS user_review_imports_active_user_key
+    ON user_review_imports (user_id)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX IF NOT EXISTS user_review_imports_pending_idx
+    ON user_review_imports (created_at)
+    WHERE status IN ('pending', 'running');
+
+CREATE INDEX IF NOT EXISTS user_review_imports_user_idx ON user_review_imports (user_id, created_at DESC);
+
+-- Rows of the imported files, with the TMDB movie they were matched to and the reason they were not imported
+CREATE TABLE IF NOT EXISTS user_review_import_rows
+(
+    import_id   BIGINT  NOT NULL REFERENCES user_review_imports (id) ON DELETE CASCADE,
+    row_number  INTEGER NOT NULL,
+    title       TEXT    NOT NULL DEFAULT '',
+    year        INTEGER,
+    imdb_id     TEXT,
+    rating      INTEGER,
+    review_text TEXT    NOT NULL DEFAULT '',
+    tags        TEXT[]  NOT NULL DEFAULT '{}',
+    watched_on  DATE,
+    status      TEXT    NOT NULL DEFAULT 'pending',
+    movie_id    INTEGER,
+    reason      TEXT,
+    PRIMARY KEY (import_id, row_number),
+    CONSTRAINT user_review_import_rows_status_check
+        CHECK (status IN ('pending', 'imported', 'skipped', 'unmatched'))
+);
+
+CREATE INDEX IF NOT EXISTS user_review_import_rows_pending_idx
+    ON user_review_import_rows (import_id, row_number)
+    WHERE status = 'pending';
@@ -0,0 +1,13 @@
+-- Mappings from the IDs used by other services to the TMDB movie IDs, so the repeated lookups skip TMDB
+CREATE TABLE IF NOT EXISTS movie_external_ids
+(
+    source      TEXT        NOT NULL,
+    external_id TEXT        NOT NULL,
+    movie_id    INTEGER     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source, external_id),
+    CONSTRAINT movie_external_ids_source_check CHECK (source IN ('imdb_id', 'wikidata_id'))
+);
+
+CREATE INDEX IF NOT EXISTS movie_external_ids_movie_idx ON movie_external_ids (movie_id);
@@ -0,0 +1,13 @@
+package migrations
+
+const (
+	// AdvisoryLockKey is the key of the Postgres advisory lock held while migrating the schema, so replicas starting
+	// at the same time do not apply the same migrations concurrently
+	AdvisoryLockKey int64 = 0x6d6f766965730001
+
+	// UpSuffix is the file name suffix of the migrations applied when upgrading the schema
+	UpSuffix = "up"
+
+	// DownSuffix is the file name suffix of the migrations applied when reverting the schema
+	DownSuffix = "down"
+)
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+)
+
+const (
+	ErrInvalidMigrationFileName = "invalid migration file name: %s"
+	ErrMissingMigrationFile     = "missing %s file of migration %04d_%s"
+	ErrDuplicatedMigration      = "duplicated migration version: %04d"
+	ErrUnknownAppliedMigration  = "cannot revert migration %04d_%s, it is not embedded in this binary"
+	ErrSchemaBehind             = "schema is behind: %d pending migrations, starting at version %04d"
+)
+
+var (
+	ErrNilMigrator       = errors.New("migrator is nil")
+	ErrBaseSchemaMissing = errors.New("base schema not found, apply the sqlmovies schema before the migrations")
+)
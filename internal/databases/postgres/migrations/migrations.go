@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+type (
+	// Migration is a versioned schema migration embedded in the binary
+	Migration struct {
+		Version int
+		Name    string
+		Up      string
+		Down    string
+	}
+)
+
+var (
+	// files are the SQL files of the migrations
+	//
+	//go:embed *.sql
+	files embed.FS
+
+	// fileNameRegexp matches the migration file names, like 0001_user_review_votes.up.sql
+	fileNameRegexp = regexp.MustCompile(`^(\d{4})_(\w+)\.(up|down)\.sql$`)
+)
+
+// List lists the embedded migrations, from the oldest to the newest version
+//
+// Returns:
+//
+// - []Migration: the embedded migrations
+// - error: if a file name is invalid, or a migration is duplicated or misses its up or down file
+func List() ([]Migration, error) {
+	return listMigrations(files)
+}
+
+// listMigrations lists the migrations of the SQL files at the root of a file system, from the oldest to the newest
+// version
+//
+// Parameters:
+//
+// - fileSystem: the file system of the SQL files
+//
+// Returns:
+//
+// - []Migration: the migrations
+// - error: if a file name is invalid, or a migration is duplicated or misses its up or down file
+func listMigrations(fileSystem fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fileSystem, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsByVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf(ErrInvalidMigrationFileName, entry.Name())
+		}
+		version, _ := strconv.Atoi(matches[1])
+		name, suffix := matches[2], matches[3]
+
+		content, readErr := fs.ReadFile(fileSystem, entry.Name())
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		// Check the up and down files share the version and the name
+		migration, ok := migrationsByVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			migrationsByVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf(ErrDuplicatedMigration, version)
+		}
+
+		if suffix == UpSuffix {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(migrationsByVersion))
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf(ErrMissingMigrationFile, UpSuffix, migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf(ErrMissingMigrationFile, DownSuffix, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(
+		migrations, func(i, j int) bool {
+			return migrations[i].Version < migrations[j].Version
+		},
+	)
+	return migrations, nil
+}
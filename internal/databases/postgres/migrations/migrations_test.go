@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+// migrationFiles creates a file system with the given SQL files, each containing its own name
+//
+// Parameters:
+//
+// - names: the file names
+//
+// Returns:
+//
+// - fstest.MapFS: the file system
+func migrationFiles(names ...string) fstest.MapFS {
+	fileSystem := make(fstest.MapFS, len(names))
+	for _, name := range names {
+		fileSystem[name] = &fstest.MapFile{Data: []byte(name)}
+	}
+	return fileSystem
+}
+
+func TestListMigrations(t *testing.T) {
+	migrations, err := listMigrations(
+		migrationFiles(
+			"0010_user_follows.down.sql",
+			"0010_user_follows.up.sql",
+			"0002_user_review_comments.up.sql",
+			"0002_user_review_comments.down.sql",
+			"0001_user_review_votes.up.sql",
+			"0001_user_review_votes.down.sql",
+		),
+	)
+	if err != nil {
+		t.Fatalf("listMigrations() error = %v", err)
+	}
+
+	// The migrations are sorted by version, and pair the up and down files
+	want := []Migration{
+		{1, "user_review_votes", "0001_user_review_votes.up.sql", "0001_user_review_votes.down.sql"},
+		{2, "user_review_comments", "0002_user_review_comments.up.sql", "0002_user_review_comments.down.sql"},
+		{10, "user_follows", "0010_user_follows.up.sql", "0010_user_follows.down.sql"},
+	}
+	if len(migrations) != len(want) {
+		t.Fatalf("listMigrations() = %d migrations, want %d", len(migrations), len(want))
+	}
+	for i, migration := range migrations {
+		if migration != want[i] {
+			t.Errorf("migration %d = %+v, want %+v", i, migration, want[i])
+		}
+	}
+}
+
+func TestListMigrationsErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		files   []string
+		wantErr string
+	}{
+		{
+			"missing version digits",
+			[]string{"001_votes.up.sql"},
+			fmt.Sprintf(ErrInvalidMigrationFileName, "001_votes.up.sql"),
+		},
+		{
+			"missing name",
+			[]string{"0001.up.sql"},
+			fmt.Sprintf(ErrInvalidMigrationFileName, "0001.up.sql"),
+		},
+		{
+			"unknown direction",
+			[]string{"0001_votes.sideways.sql"},
+			fmt.Sprintf(ErrInvalidMigrationFileName, "0001_votes.sideways.sql"),
+		},
+		{
+			"not a SQL file",
+			[]string{"0001_votes.up.txt"},
+			fmt.Sprintf(ErrInvalidMigrationFileName, "0001_votes.up.txt"),
+		},
+		{
+			"name with dashes",
+			[]string{"0001_user-votes.up.sql"},
+			fmt.Sprintf(ErrInvalidMigrationFileName, "0001_user-votes.up.sql"),
+		},
+		{
+			"duplicated version",
+			[]string{"0001_comments.up.sql", "0001_votes.up.sql"},
+			fmt.Sprintf(ErrDuplicatedMigration, 1),
+		},
+		{
+			"missing down file",
+			[]string{"0001_votes.up.sql"},
+			fmt.Sprintf(ErrMissingMigrationFile, DownSuffix, 1, "votes"),
+		},
+		{
+			"missing up file",
+			[]string{"0001_votes.down.sql"},
+			fmt.Sprintf(ErrMissingMigrationFile, UpSuffix, 1, "votes"),
+		},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				_, err := listMigrations(migrationFiles(test.files...))
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("listMigrations() error = %v, want %q", err, test.wantErr)
+				}
+			},
+		)
+	}
+}
+
+func TestListEmbeddedMigrations(t *testing.T) {
+	migrations, err := List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	// The embedded migrations are numbered without gaps from the first version
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Fatalf("migration %d has version %04d, want %04d", i, migration.Version, i+1)
+		}
+	}
+}
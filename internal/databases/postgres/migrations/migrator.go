@@ -0,0 +1,392 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// Migrator applies and reverts the embedded migrations. Every migration runs in its own transaction together with
+	// its schema_migrations record, while a Postgres advisory lock keeps the other replicas waiting
+	Migrator struct {
+		pool       *pgxpool.Pool
+		migrations []Migration
+		logger     *slog.Logger
+	}
+
+	// MigrationStatus is the status of a schema migration
+	MigrationStatus struct {
+		Version   int
+		Name      string
+		AppliedAt sql.NullTime
+		Embedded  bool
+	}
+)
+
+// NewMigrator creates a new migrator of the embedded migrations
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Migrator: the migrator
+// - error: if there was an error creating the migrator or reading the embedded migrations
+func NewMigrator(pool *pgxpool.Pool, logger *slog.Logger) (*Migrator, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Read the embedded migrations
+	migrations, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the logger for the migrator
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "migrator"),
+		)
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// withLock runs a function on a connection holding the advisory lock of the migrations, creating the
+// schema_migrations table if it does not exist
+//
+// Parameters:
+//
+// - ctx: the context
+// - fn: the function to run
+//
+// Returns:
+//
+// - error: if there was an error taking the lock or running the function
+func (m *Migrator) withLock(ctx context.Context, fn func(ctx context.Context, conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	// Wait for the other replicas to finish migrating
+	if _, err = conn.Exec(ctx, internalpostgres.LockMigrationsQuery, AdvisoryLockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// Close the connection if the lock could not be released, since session locks are only released with it
+		if _, unlockErr := conn.Exec(
+			context.WithoutCancel(ctx),
+			internalpostgres.UnlockMigrationsQuery,
+			AdvisoryLockKey,
+		); unlockErr != nil {
+			if m.logger != nil {
+				m.logger.Error(
+					"Could not release the migrations lock",
+					slog.String("error", unlockErr.Error()),
+				)
+			}
+			// nolint:errcheck
+			conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, internalpostgres.CreateSchemaMigrationsTableQuery); err != nil {
+		return err
+	}
+	return fn(ctx, conn)
+}
+
+// listApplied lists the applied migrations, from the oldest to the newest version
+//
+// Parameters:
+//
+// - ctx: the context
+// - conn: the connection
+//
+// Returns:
+//
+// - []MigrationStatus: the applied migrations
+// - error: if there was an error listing the applied migrations
+func (m *Migrator) listApplied(ctx context.Context, conn *pgxpool.Conn) ([]MigrationStatus, error) {
+	rows, err := conn.Query(ctx, internalpostgres.ListSchemaMigrationsQuery)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (MigrationStatus, error) {
+			var status MigrationStatus
+			scanErr := row.Scan(&status.Version, &status.Name, &status.AppliedAt)
+			return status, scanErr
+		},
+	)
+}
+
+// Up applies the pending migrations, from the oldest to the newest version
+//
+// Parameters:
+//
+// - ctx: the context
+// - steps: the maximum number of migrations to apply, 0 to apply all of them
+//
+// Returns:
+//
+// - []Migration: the applied migrations
+// - error: if there was an error applying the migrations
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	if m == nil {
+		panic(ErrNilMigrator)
+	}
+
+	var appliedMigrations []Migration
+	err := m.withLock(
+		ctx,
+		func(ctx context.Context, conn *pgxpool.Conn) error {
+			// Check the base schema exists, since the migrations extend it
+			var baseSchemaFound bool
+			if err := conn.QueryRow(ctx, internalpostgres.ExistsBaseSchemaQuery).Scan(&baseSchemaFound); err != nil {
+				return err
+			}
+			if !baseSchemaFound {
+				return ErrBaseSchemaMissing
+			}
+
+			applied, err := m.listApplied(ctx, conn)
+			if err != nil {
+				return err
+			}
+			appliedVersions := make(map[int]bool, len(applied))
+			for _, status := range applied {
+				appliedVersions[status.Version] = true
+			}
+
+			for _, migration := range m.migrations {
+				if appliedVersions[migration.Version] {
+					continue
+				}
+				if steps > 0 && len(appliedMigrations) == steps {
+					break
+				}
+
+				if err = m.run(ctx, conn, migration, UpSuffix); err != nil {
+					return err
+				}
+				appliedMigrations = append(appliedMigrations, migration)
+			}
+			return nil
+		},
+	)
+	return appliedMigrations, err
+}
+
+// Down reverts the applied migrations, from the newest to the oldest version
+//
+// Parameters:
+//
+// - ctx: the context
+// - steps: the number of migrations to revert
+//
+// Returns:
+//
+// - []Migration: the reverted migrations
+// - error: if there was an error reverting the migrations, or an applied migration is not embedded in this binary
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if m == nil {
+		panic(ErrNilMigrator)
+	}
+
+	migrationsByVersion := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrationsByVersion[migration.Version] = migration
+	}
+
+	var revertedMigrations []Migration
+	err := m.withLock(
+		ctx,
+		func(ctx context.Context, conn *pgxpool.Conn) error {
+			applied, err := m.listApplied(ctx, conn)
+			if err != nil {
+				return err
+			}
+
+			for i := len(applied) - 1; i >= 0 && len(revertedMigrations) < steps; i-- {
+				migration, ok := migrationsByVersion[applied[i].Version]
+				if !ok {
+					return fmt.Errorf(ErrUnknownAppliedMigration, applied[i].Version, applied[i].Name)
+				}
+
+				if err = m.run(ctx, conn, migration, DownSuffix); err != nil {
+					return err
+				}
+				revertedMigrations = append(revertedMigrations, migration)
+			}
+			return nil
+		},
+	)
+	return revertedMigrations, err
+}
+
+// run applies or reverts a migration in a transaction, recording it in the schema_migrations table
+//
+// Parameters:
+//
+// - ctx: the context
+// - conn: the connection holding the advisory lock
+// - migration: the migration
+// - direction: UpSuffix to apply the migration, DownSuffix to revert it
+//
+// Returns:
+//
+// - error: if there was an error running the migration
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, migration Migration, direction string) error {
+	if m.logger != nil {
+		m.logger.Info(
+			"Running migration",
+			slog.Int("version", migration.Version),
+			slog.String("name", migration.Name),
+			slog.String("direction", direction),
+		)
+	}
+
+	return pgx.BeginFunc(
+		ctx,
+		conn,
+		func(tx pgx.Tx) error {
+			if direction == UpSuffix {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, internalpostgres.InsertSchemaMigrationQuery, migration.Version, migration.Name)
+				return err
+			}
+
+			if _, err := tx.Exec(ctx, migration.Down); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, internalpostgres.DeleteSchemaMigrationQuery, migration.Version)
+			return err
+		},
+	)
+}
+
+// Status gets the status of the embedded migrations and of the applied migrations that are not embedded in this
+// binary, from the oldest to the newest version
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - []MigrationStatus: the migration statuses
+// - error: if there was an error reading the applied migrations
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if m == nil {
+		panic(ErrNilMigrator)
+	}
+
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	// Read the applied migrations without creating the schema_migrations table
+	var tableFound bool
+	if err = conn.QueryRow(ctx, internalpostgres.ExistsSchemaMigrationsTableQuery).Scan(&tableFound); err != nil {
+		return nil, err
+	}
+	var applied []MigrationStatus
+	if tableFound {
+		if applied, err = m.listApplied(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	return mergeStatuses(m.migrations, applied), nil
+}
+
+// mergeStatuses merges the embedded and the applied migrations by version, from the oldest to the newest version
+//
+// Parameters:
+//
+// - migrations: the embedded migrations, from the oldest to the newest version
+// - applied: the applied migrations, from the oldest to the newest version
+//
+// Returns:
+//
+// - []MigrationStatus: the migration statuses
+func mergeStatuses(migrations []Migration, applied []MigrationStatus) []MigrationStatus {
+	statuses := make([]MigrationStatus, 0, len(migrations)+len(applied))
+	i := 0
+	for _, migration := range migrations {
+		for i < len(applied) && applied[i].Version < migration.Version {
+			statuses = append(statuses, applied[i])
+			i++
+		}
+
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, Embedded: true}
+		if i < len(applied) && applied[i].Version == migration.Version {
+			status.AppliedAt = applied[i].AppliedAt
+			i++
+		}
+		statuses = append(statuses, status)
+	}
+	return append(statuses, applied[i:]...)
+}
+
+// CheckCurrent checks every embedded migration is applied, so the server does not serve requests against a schema
+// that is behind the binary. Applied migrations that are not embedded, left by a newer binary, are only logged
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - error: if there are pending migrations or there was an error reading the applied migrations
+func (m *Migrator) CheckCurrent(ctx context.Context) error {
+	if m == nil {
+		panic(ErrNilMigrator)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []MigrationStatus
+	for _, status := range statuses {
+		if !status.AppliedAt.Valid {
+			pending = append(pending, status)
+		} else if !status.Embedded && m.logger != nil {
+			m.logger.Warn(
+				"Schema has a migration this binary does not know",
+				slog.Int("version", status.Version),
+				slog.String("name", status.Name),
+			)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf(ErrSchemaBehind, len(pending), pending[0].Version)
+	}
+	return nil
+}
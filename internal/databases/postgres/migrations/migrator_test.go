@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeStatuses(t *testing.T) {
+	appliedAt := sql.NullTime{Time: time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC), Valid: true}
+	migrations := []Migration{
+		{Version: 2, Name: "comments"},
+		{Version: 3, Name: "moderation"},
+		{Version: 5, Name: "audit_log"},
+	}
+
+	for _, test := range []struct {
+		name    string
+		applied []MigrationStatus
+		want    []MigrationStatus
+	}{
+		{
+			"nothing applied",
+			nil,
+			[]MigrationStatus{
+				{Version: 2, Name: "comments", Embedded: true},
+				{Version: 3, Name: "moderation", Embedded: true},
+				{Version: 5, Name: "audit_log", Embedded: true},
+			},
+		},
+		{
+			"partially applied",
+			[]MigrationStatus{{Version: 2, Name: "comments", AppliedAt: appliedAt}},
+			[]MigrationStatus{
+				{Version: 2, Name: "comments", AppliedAt: appliedAt, Embedded: true},
+				{Version: 3, Name: "moderation", Embedded: true},
+				{Version: 5, Name: "audit_log", Embedded: true},
+			},
+		},
+		{
+			"applied migrations not embedded",
+			[]MigrationStatus{
+				{Version: 1, Name: "votes", AppliedAt: appliedAt},
+				{Version: 2, Name: "comments", AppliedAt: appliedAt},
+				{Version: 4, Name: "reports", AppliedAt: appliedAt},
+				{Version: 5, Name: "audit_log", AppliedAt: appliedAt},
+				{Version: 6, Name: "versions", AppliedAt: appliedAt},
+			},
+			[]MigrationStatus{
+				{Version: 1, Name: "votes", AppliedAt: appliedAt},
+				{Version: 2, Name: "comments", AppliedAt: appliedAt, Embedded: true},
+				{Version: 3, Name: "moderation", Embedded: true},
+				{Version: 4, Name: "reports", AppliedAt: appliedAt},
+				{Version: 5, Name: "audit_log", AppliedAt: appliedAt, Embedded: true},
+				{Version: 6, Name: "versions", AppliedAt: appliedAt},
+			},
+		},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if got := mergeStatuses(migrations, test.applied); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("mergeStatuses() = %+v, want %+v", got, test.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,1141 @@
+package postgres
+
+const (
+	// CountRecentUserReviewsByMovieQuery counts the published user reviews created since a given time for each of
+	// the given movies
+	CountRecentUserReviewsByMovieQuery = `
+SELECT movie_id, COUNT(*)
+FROM user_reviews
+WHERE movie_id = ANY($1) AND created_at >= $2 AND moderation_status = 'published'
+GROUP BY movie_id
+`
+)
+
+const (
+	// ScoreRecentUserReviewsQuery scores the movies reviewed since a given time with a time-decayed sum of their
+	// published ratings, keeping the movies with a minimum number of reviews
+	ScoreRecentUserReviewsQuery = `
+SELECT
+	movie_id,
+	COUNT(*) AS reviews_count,
+	AVG(rating)::FLOAT8 AS average_rating,
+	SUM(rating * EXP(-EXTRACT(EPOCH FROM ($2::TIMESTAMPTZ - created_at)) / $3::FLOAT8))::FLOAT8 AS score
+FROM user_reviews
+WHERE created_at >= $1 AND moderation_status = 'published'
+GROUP BY movie_id
+HAVING COUNT(*) >= $4
+ORDER BY score DESC
+LIMIT $5
+`
+)
+
+const (
+	// UserReviewExistsQuery checks if the published user review of a movie exists
+	UserReviewExistsQuery = `
+SELECT EXISTS (SELECT 1 FROM user_reviews WHERE user_id = $1 AND movie_id = $2 AND moderation_status = 'published')
+`
+
+	// GetUserReviewVersionQuery gets the version of a user review
+	GetUserReviewVersionQuery = `
+SELECT version FROM user_reviews WHERE user_id = $1 AND movie_id = $2
+`
+
+	// GetUserReviewDetailsQuery gets the version, the spoiler marking, the tags and the watched on date of a user
+	// review
+	GetUserReviewDetailsQuery = `
+SELECT version, contains_spoilers, spoiler_spans, tags, watched_on
+FROM user_reviews
+WHERE user_id = $1 AND movie_id = $2
+`
+
+	// SetUserReviewDetailsQuery sets the spoiler marking, the tags and the watched on date of a user review
+	SetUserReviewDetailsQuery = `
+UPDATE user_reviews
+SET contains_spoilers = $3, spoiler_spans = $4, tags = $5, watched_on = $6
+WHERE user_id = $1 AND movie_id = $2
+`
+
+	// LockUserReviewVersionQuery gets the version of a user review, locking the review until the transaction ends
+	LockUserReviewVersionQuery = `
+SELECT version FROM user_reviews WHERE user_id = $1 AND movie_id = $2 FOR UPDATE
+`
+
+	// IncrementUserReviewVersionQuery increments the version of a user review, returning the new version
+	IncrementUserReviewVersionQuery = `
+UPDATE user_reviews SET version = version + 1 WHERE user_id = $1 AND movie_id = $2 RETURNING version
+`
+
+	// InsertUserReviewRevisionQuery copies the current version of a user review, with its details, into its revisions
+	InsertUserReviewRevisionQuery = `
+INSERT INTO user_review_revisions (
+	review_user_id, movie_id, version, rating, review_text, written_at, contains_spoilers, spoiler_spans, tags,
+	watched_on
+)
+SELECT user_id, movie_id, version, rating, review_text, COALESCE(updated_at, created_at), contains_spoilers,
+	spoiler_spans, tags, watched_on
+FROM user_reviews
+WHERE user_id = $1 AND movie_id = $2
+`
+
+	// ArchiveUserReviewRevisionsQuery archives the revisions of a user review about to be deleted by the staff, along
+	// with its current version, so they outlive the review
+	ArchiveUserReviewRevisionsQuery = `
+WITH archived AS (
+	UPDATE user_review_revisions
+	SET archived_at = NOW()
+	WHERE review_user_id = $1 AND movie_id = $2 AND archived_at IS NULL
+)
+INSERT INTO user_review_revisions (
+	review_user_id, movie_id, version, rating, review_text, written_at, contains_spoilers, spoiler_spans, tags,
+	watched_on, archived_at
+)
+SELECT user_id, movie_id, version, rating, review_text, COALESCE(updated_at, created_at), contains_spoilers,
+	spoiler_spans, tags, watched_on, NOW()
+FROM user_reviews
+WHERE user_id = $1 AND movie_id = $2
+`
+
+	// DeleteUserReviewRevisionsQuery deletes the revisions of a user review, keeping the ones archived by the staff
+	DeleteUserReviewRevisionsQuery = `
+DELETE FROM user_review_revisions
+WHERE review_user_id = $1 AND movie_id = $2 AND archived_at IS NULL
+`
+
+	// ListUserReviewRevisionsQuery lists the previous versions of a user review with their details, from the newest to
+	// the oldest one. The revisions archived by the staff are only listed if requested
+	ListUserReviewRevisionsQuery = `
+SELECT version, rating, review_text, written_at, replaced_at, contains_spoilers, spoiler_spans, tags, watched_on
+FROM user_review_revisions
+WHERE review_user_id = $1 AND movie_id = $2 AND (archived_at IS NULL OR $3::BOOLEAN)
+ORDER BY replaced_at DESC, id DESC
+`
+
+	// InsertUserReviewVoteQuery inserts a vote on a user review, doing nothing if the vote was already cast
+	InsertUserReviewVoteQuery = `
+INSERT INTO user_review_votes (voter_user_id, review_user_id, movie_id, vote_type)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING
+`
+
+	// DeleteUserReviewVoteQuery deletes a vote on a user review
+	DeleteUserReviewVoteQuery = `
+DELETE FROM user_review_votes
+WHERE voter_user_id = $1 AND review_user_id = $2 AND movie_id = $3 AND vote_type = $4
+`
+
+	// UpdateUserReviewLikesCountQuery adds the given delta to the likes count of a user review
+	UpdateUserReviewLikesCountQuery = `
+UPDATE user_reviews SET likes_count = likes_count + $3 WHERE user_id = $1 AND movie_id = $2
+`
+
+	// UpdateUserReviewHelpfulCountQuery adds the given delta to the helpful count of a user review
+	UpdateUserReviewHelpfulCountQuery = `
+UPDATE user_reviews SET helpful_count = helpful_count + $3 WHERE user_id = $1 AND movie_id = $2
+`
+)
+
+const (
+	// ListMovieUserReviewsQuery lists a page of the published user reviews of a movie with the total number of
+	// listed user reviews of the movie, skipping the reviews flagged as spoilers if requested. It must be formatted
+	// with one of the user reviews order by clauses
+	ListMovieUserReviewsQuery = `
+SELECT
+	user_id::TEXT,
+	rating,
+	review_text,
+	likes_count,
+	helpful_count,
+	created_at,
+	updated_at,
+	contains_spoilers,
+	spoiler_spans,
+	tags,
+	watched_on,
+	COUNT(*) OVER ()
+FROM user_reviews
+WHERE movie_id = $1 AND moderation_status = 'published' AND NOT ($4 AND contains_spoilers)
+ORDER BY %s
+LIMIT $2 OFFSET $3
+`
+
+	// UserReviewsOrderByNewest orders the user reviews from the most to the least recent
+	UserReviewsOrderByNewest = "created_at DESC"
+
+	// UserReviewsOrderByMostHelpful orders the user reviews from the most to the least helpful
+	UserReviewsOrderByMostHelpful = "helpful_count DESC, created_at DESC"
+
+	// UserReviewsOrderByMostLiked orders the user reviews from the most to the least liked
+	UserReviewsOrderByMostLiked = "likes_count DESC, created_at DESC"
+)
+
+const (
+	// GetUserReviewCommentParentQuery gets the parent ID of a comment on a user review and whether it was deleted or
+	// hidden
+	GetUserReviewCommentParentQuery = `
+SELECT parent_id, deleted_at IS NOT NULL OR hidden_at IS NOT NULL
+FROM user_review_comments
+WHERE id = $1 AND review_user_id = $2 AND movie_id = $3
+`
+
+	// InsertUserReviewCommentQuery inserts a comment on a user review
+	InsertUserReviewCommentQuery = `
+INSERT INTO user_review_comments (review_user_id, movie_id, user_id, parent_id, content)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at
+`
+
+	// UpdateUserReviewCommentQuery updates the content of a visible comment written by the given user
+	UpdateUserReviewCommentQuery = `
+UPDATE user_review_comments
+SET content = $3, updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND hidden_at IS NULL
+RETURNING parent_id, created_at, updated_at
+`
+
+	// SoftDeleteUserReviewCommentQuery soft deletes a comment written by the given user, keeping its replies visible
+	SoftDeleteUserReviewCommentQuery = `
+UPDATE user_review_comments
+SET deleted_at = NOW()
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+`
+
+	// ListUserReviewCommentsQuery lists a page of the comments on a user review with the same parent, from the oldest
+	// to the newest, after the given cursor. Deleted and hidden comments are only listed, as deleted, while they have
+	// visible replies
+	ListUserReviewCommentsQuery = `
+SELECT
+	c.id,
+	c.user_id::TEXT,
+	c.content,
+	c.created_at,
+	c.updated_at,
+	c.deleted_at IS NOT NULL OR c.hidden_at IS NOT NULL,
+	(
+		SELECT COUNT(*)
+		FROM user_review_comments r
+		WHERE r.parent_id = c.id AND r.deleted_at IS NULL AND r.hidden_at IS NULL
+	)
+FROM user_review_comments c
+WHERE c.review_user_id = $1
+	AND c.movie_id = $2
+	AND c.parent_id IS NOT DISTINCT FROM $3::BIGINT
+	AND ($4::TIMESTAMPTZ IS NULL OR (c.created_at, c.id) > ($4::TIMESTAMPTZ, $5::BIGINT))
+	AND (
+		(c.deleted_at IS NULL AND c.hidden_at IS NULL)
+		OR EXISTS (
+			SELECT 1
+			FROM user_review_comments r
+			WHERE r.parent_id = c.id AND r.deleted_at IS NULL AND r.hidden_at IS NULL
+		)
+	)
+ORDER BY c.created_at, c.id
+LIMIT $6
+`
+)
+
+const (
+	// ListUsernamesQuery lists the usernames of the given users
+	ListUsernamesQuery = `
+SELECT id::TEXT, username FROM users WHERE id = ANY($1::BIGINT[])
+`
+
+	// UpsertUsernameQuery stores the username of a user, replacing the previous one if it changed
+	UpsertUsernameQuery = `
+INSERT INTO users (id, username)
+VALUES ($1, $2)
+ON CONFLICT (id) DO UPDATE
+SET username = EXCLUDED.username
+WHERE users.username <> EXCLUDED.username
+`
+)
+
+const (
+	// SetUserReviewModerationQuery sets the moderation status and reasons of a user review that was not rejected,
+	// returning the resulting ones. A review held by its reports stays pending and keeps its reasons, adding the new
+	// ones, and if the rules flag it the hold is cleared so dismissing the reports does not publish it
+	SetUserReviewModerationQuery = `
+UPDATE user_reviews
+SET
+	moderation_status = CASE WHEN reports_held_at IS NULL THEN $3::TEXT ELSE moderation_status END,
+	moderation_reasons = CASE WHEN reports_held_at IS NULL THEN $4::TEXT[] ELSE moderation_reasons || $4::TEXT[] END,
+	reports_held_at = CASE WHEN $3::TEXT = 'pending' THEN NULL ELSE reports_held_at END
+WHERE user_id = $1 AND movie_id = $2 AND moderation_status <> 'rejected'
+RETURNING moderation_status, moderation_reasons
+`
+
+	// ResolvePendingUserReviewQuery sets the moderation status of a pending user review, returning its text and the
+	// reasons it was flagged for
+	ResolvePendingUserReviewQuery = `
+UPDATE user_reviews
+SET moderation_status = $3, reports_held_at = NULL
+WHERE user_id = $1 AND movie_id = $2 AND moderation_status = 'pending'
+RETURNING review_text, moderation_reasons
+`
+
+	// ListPendingUserReviewsQuery lists a page of the pending user reviews, from the oldest to the newest, with the
+	// total number of pending user reviews
+	ListPendingUserReviewsQuery = `
+SELECT
+	user_id::TEXT,
+	movie_id,
+	rating,
+	review_text,
+	moderation_reasons,
+	created_at,
+	updated_at,
+	COUNT(*) OVER ()
+FROM user_reviews
+WHERE moderation_status = 'pending'
+ORDER BY COALESCE(updated_at, created_at)
+LIMIT $1 OFFSET $2
+`
+
+	// InsertUserReviewModerationAuditQuery inserts an entry of the moderation audit trail of a user review
+	InsertUserReviewModerationAuditQuery = `
+INSERT INTO user_review_moderation_audit (review_user_id, movie_id, action, actor_user_id, reasons, note, review_text)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+	// ListUserReviewModerationAuditQuery lists the moderation audit trail of a user review, from the oldest to the
+	// newest entry
+	ListUserReviewModerationAuditQuery = `
+SELECT id, action, actor_user_id::TEXT, reasons, note, review_text, created_at
+FROM user_review_moderation_audit
+WHERE review_user_id = $1 AND movie_id = $2
+ORDER BY created_at, id
+`
+)
+
+const (
+	// LockPublishedUserReviewQuery locks a published user review until the transaction ends, so its reports are
+	// inserted and counted one at a time
+	LockPublishedUserReviewQuery = `
+SELECT 1 FROM user_reviews WHERE user_id = $1 AND movie_id = $2 AND moderation_status = 'published' FOR UPDATE
+`
+
+	// InsertUserReviewReportQuery inserts a report on a user review, doing nothing if the user already reported it
+	InsertUserReviewReportQuery = `
+INSERT INTO content_reports (reporter_user_id, review_user_id, movie_id, reason, details)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (reporter_user_id, review_user_id, movie_id) WHERE comment_id IS NULL DO NOTHING
+`
+
+	// CountOpenUserReviewReportsQuery counts the distinct users with an open report on a user review
+	CountOpenUserReviewReportsQuery = `
+SELECT COUNT(DISTINCT reporter_user_id)
+FROM content_reports
+WHERE review_user_id = $1 AND movie_id = $2 AND comment_id IS NULL AND status = 'open'
+`
+
+	// HideReportedUserReviewQuery holds a published user review as pending, adding the given reason to its moderation
+	// reasons and returning its text
+	HideReportedUserReviewQuery = `
+UPDATE user_reviews
+SET moderation_status = 'pending', moderation_reasons = array_append(moderation_reasons, $3), reports_held_at = NOW()
+WHERE user_id = $1 AND movie_id = $2 AND moderation_status = 'published'
+RETURNING review_text
+`
+
+	// ReleaseReportedUserReviewQuery publishes again a user review held as pending by its reports, returning its text
+	// and moderation reasons
+	ReleaseReportedUserReviewQuery = `
+UPDATE user_reviews
+SET moderation_status = 'published', reports_held_at = NULL
+WHERE user_id = $1 AND movie_id = $2 AND moderation_status = 'pending' AND reports_held_at IS NOT NULL
+RETURNING review_text, moderation_reasons
+`
+
+	// RejectUserReviewQuery rejects a user review that was not rejected yet, returning its text and moderation reasons
+	RejectUserReviewQuery = `
+UPDATE user_reviews
+SET moderation_status = 'rejected', reports_held_at = NULL
+WHERE user_id = $1 AND movie_id = $2 AND moderation_status <> 'rejected'
+RETURNING review_text, moderation_reasons
+`
+
+	// GetVisibleUserReviewCommentQuery gets the user review and the author of a visible comment, locking the comment
+	// until the transaction ends so its reports are inserted and counted one at a time
+	GetVisibleUserReviewCommentQuery = `
+SELECT review_user_id::TEXT, movie_id, user_id::TEXT
+FROM user_review_comments
+WHERE id = $1 AND deleted_at IS NULL AND hidden_at IS NULL
+FOR UPDATE
+`
+
+	// InsertUserReviewCommentReportQuery inserts a report on a comment of a user review, doing nothing if the user
+	// already reported it
+	InsertUserReviewCommentReportQuery = `
+INSERT INTO content_reports (reporter_user_id, review_user_id, movie_id, comment_id, reason, details)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (reporter_user_id, comment_id) WHERE comment_id IS NOT NULL DO NOTHING
+`
+
+	// CountOpenUserReviewCommentReportsQuery counts the distinct users with an open report on a comment
+	CountOpenUserReviewCommentReportsQuery = `
+SELECT COUNT(DISTINCT reporter_user_id) FROM content_reports WHERE comment_id = $1 AND status = 'open'
+`
+
+	// HideUserReviewCommentQuery hides a comment of a user review
+	HideUserReviewCommentQuery = `
+UPDATE user_review_comments SET hidden_at = NOW() WHERE id = $1 AND hidden_at IS NULL
+`
+
+	// UnhideUserReviewCommentQuery makes a hidden comment of a user review visible again
+	UnhideUserReviewCommentQuery = `
+UPDATE user_review_comments SET hidden_at = NULL WHERE id = $1
+`
+
+	// RemoveUserReviewCommentQuery soft deletes a comment of a user review, regardless of its author
+	RemoveUserReviewCommentQuery = `
+UPDATE user_review_comments SET deleted_at = COALESCE(deleted_at, NOW()) WHERE id = $1
+`
+
+	// ListOpenContentReportsQuery lists a page of the open reports, from the oldest to the newest, with the total
+	// number of open reports
+	ListOpenContentReportsQuery = `
+SELECT
+	id,
+	reporter_user_id::TEXT,
+	review_user_id::TEXT,
+	movie_id,
+	comment_id,
+	reason,
+	details,
+	created_at,
+	COUNT(*) OVER ()
+FROM content_reports
+WHERE status = 'open'
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2
+`
+
+	// GetOpenContentReportTargetQuery gets the reported user review and comment of an open report
+	GetOpenContentReportTargetQuery = `
+SELECT review_user_id::TEXT, movie_id, comment_id FROM content_reports WHERE id = $1 AND status = 'open'
+`
+
+	// ResolveUserReviewReportsQuery resolves every open report on a user review
+	ResolveUserReviewReportsQuery = `
+UPDATE content_reports
+SET status = $3, resolved_by = $4, resolved_at = NOW()
+WHERE review_user_id = $1 AND movie_id = $2 AND comment_id IS NULL AND status = 'open'
+`
+
+	// ResolveUserReviewCommentReportsQuery resolves every open report on a comment of a user review
+	ResolveUserReviewCommentReportsQuery = `
+UPDATE content_reports
+SET status = $2, resolved_by = $3, resolved_at = NOW()
+WHERE comment_id = $1 AND status = 'open'
+`
+)
+
+const (
+	// InsertAdminAuditLogQuery inserts an entry of the admin audit log
+	InsertAdminAuditLogQuery = `
+INSERT INTO admin_audit_log (actor_user_id, action, target, details) VALUES ($1, $2, $3, $4)
+`
+
+	// ListAdminAuditLogQuery lists a page of the admin audit log, from the newest to the oldest entry, optionally
+	// filtered by actor, after the given cursor
+	ListAdminAuditLogQuery = `
+SELECT id, actor_user_id::TEXT, action, target, details::TEXT, created_at
+FROM admin_audit_log
+WHERE ($1::BIGINT IS NULL OR actor_user_id = $1::BIGINT)
+	AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) < ($2::TIMESTAMPTZ, $3::BIGINT))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+)
+
+const (
+	// CountUserReviewsByModerationStatusQuery counts the user reviews of each moderation status
+	CountUserReviewsByModerationStatusQuery = `
+SELECT moderation_status, COUNT(*) FROM user_reviews GROUP BY moderation_status
+`
+
+	// GetSystemStatsQuery gets the counts of the community content
+	GetSystemStatsQuery = `
+SELECT
+	(SELECT COUNT(*) FROM user_review_comments WHERE deleted_at IS NULL),
+	(SELECT COUNT(*) FROM user_review_votes),
+	(SELECT COUNT(*) FROM content_reports WHERE status = 'open')
+`
+)
+
+const (
+	// InsertUserMovieListQuery inserts a user movie list
+	InsertUserMovieListQuery = `
+INSERT INTO user_movie_lists (user_id, name, description, is_public)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at
+`
+
+	// UpdateUserMovieListQuery updates the name, description and visibility of a user movie list, returning its
+	// timestamps and number of items
+	UpdateUserMovieListQuery = `
+UPDATE user_movie_lists
+SET name = $3, description = $4, is_public = $5, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING created_at, updated_at, (SELECT COUNT(*) FROM user_movie_list_items WHERE list_id = $1)
+`
+
+	// DeleteUserMovieListQuery deletes a user movie list with its items
+	DeleteUserMovieListQuery = `
+DELETE FROM user_movie_lists WHERE id = $1 AND user_id = $2
+`
+
+	// ListUserMovieListsQuery lists the movie lists of a user with their number of items, from the newest to the
+	// oldest list
+	ListUserMovieListsQuery = `
+SELECT l.id, l.name, l.description, l.is_public, l.created_at, l.updated_at, COUNT(i.movie_id)
+FROM user_movie_lists l
+LEFT JOIN user_movie_list_items i ON i.list_id = l.id
+WHERE l.user_id = $1
+GROUP BY l.id
+ORDER BY l.created_at DESC, l.id DESC
+`
+
+	// GetUserMovieListQuery gets a user movie list with its number of items
+	GetUserMovieListQuery = `
+SELECT
+	user_id::TEXT,
+	name,
+	description,
+	is_public,
+	created_at,
+	updated_at,
+	(SELECT COUNT(*) FROM user_movie_list_items WHERE list_id = $1)
+FROM user_movie_lists
+WHERE id = $1
+`
+
+	// TouchUserMovieListQuery sets the update time of a user movie list, locking it until the transaction ends so
+	// the positions of its items are changed one transaction at a time
+	TouchUserMovieListQuery = `
+UPDATE user_movie_lists SET updated_at = NOW() WHERE id = $1 AND user_id = $2
+`
+
+	// CountUserMovieListItemsQuery counts the items of a user movie list
+	CountUserMovieListItemsQuery = `
+SELECT COUNT(*) FROM user_movie_list_items WHERE list_id = $1
+`
+
+	// InsertUserMovieListItemQuery appends a movie to a user movie list, doing nothing if the movie is already in it
+	InsertUserMovieListItemQuery = `
+INSERT INTO user_movie_list_items (list_id, movie_id, position, note)
+SELECT $1, $2, COUNT(*), $3 FROM user_movie_list_items WHERE list_id = $1
+ON CONFLICT DO NOTHING
+`
+
+	// DeleteUserMovieListItemQuery deletes a movie from a user movie list, returning its position
+	DeleteUserMovieListItemQuery = `
+DELETE FROM user_movie_list_items WHERE list_id = $1 AND movie_id = $2 RETURNING position
+`
+
+	// ShiftUserMovieListItemsQuery moves up the items of a user movie list after the given position, to fill the gap
+	// left by a deleted item
+	ShiftUserMovieListItemsQuery = `
+UPDATE user_movie_list_items SET position = position - 1 WHERE list_id = $1 AND position > $2
+`
+
+	// ListUserMovieListItemMovieIDsQuery lists the movie IDs of the items of a user movie list
+	ListUserMovieListItemMovieIDsQuery = `
+SELECT movie_id FROM user_movie_list_items WHERE list_id = $1
+`
+
+	// ReorderUserMovieListItemsQuery sets the positions of the items of a user movie list to the order of the given
+	// movie IDs
+	ReorderUserMovieListItemsQuery = `
+UPDATE user_movie_list_items i
+SET position = o.position - 1
+FROM UNNEST($2::INTEGER[]) WITH ORDINALITY AS o(movie_id, position)
+WHERE i.list_id = $1 AND i.movie_id = o.movie_id
+`
+
+	// ListUserMovieListItemsQuery lists a page of the items of a user movie list by position
+	ListUserMovieListItemsQuery = `
+SELECT movie_id, position, note, added_at
+FROM user_movie_list_items
+WHERE list_id = $1
+ORDER BY position
+LIMIT $2 OFFSET $3
+`
+)
+
+const (
+	// InsertUserFollowQuery makes a user follow another user, doing nothing if the user already follows them
+	InsertUserFollowQuery = `
+INSERT INTO user_follows (follower_user_id, followed_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+
+	// DeleteUserFollowQuery makes a user unfollow another user
+	DeleteUserFollowQuery = `
+DELETE FROM user_follows WHERE follower_user_id = $1 AND followed_user_id = $2
+`
+
+	// ListUserFollowersQuery lists a page of the followers of a user, from the newest to the oldest follower, after
+	// the given cursor
+	ListUserFollowersQuery = `
+SELECT follower_user_id, created_at
+FROM user_follows
+WHERE followed_user_id = $1
+	AND ($2::TIMESTAMPTZ IS NULL OR (created_at, follower_user_id) < ($2::TIMESTAMPTZ, $3::BIGINT))
+ORDER BY created_at DESC, follower_user_id DESC
+LIMIT $4
+`
+
+	// ListUserFollowingQuery lists a page of the users followed by a user, from the newest to the oldest follow,
+	// after the given cursor
+	ListUserFollowingQuery = `
+SELECT followed_user_id, created_at
+FROM user_follows
+WHERE follower_user_id = $1
+	AND ($2::TIMESTAMPTZ IS NULL OR (created_at, followed_user_id) < ($2::TIMESTAMPTZ, $3::BIGINT))
+ORDER BY created_at DESC, followed_user_id DESC
+LIMIT $4
+`
+
+	// ListActivityFeedQuery lists a page of the activity of the users followed by a user, from the newest to the
+	// oldest activity, after the given cursor. The activity is their published reviews, which are diary entries when
+	// they have a watched on date, and the movies they added to their public lists
+	ListActivityFeedQuery = `
+WITH followed AS (SELECT followed_user_id AS user_id FROM user_follows WHERE follower_user_id = $1)
+SELECT kind, user_id::TEXT, movie_id, list_id, list_name, rating, watched_on, occurred_at, activity_id
+FROM (
+	SELECT
+		CASE WHEN r.watched_on IS NULL THEN 'review' ELSE 'diary_entry' END AS kind,
+		r.user_id,
+		r.movie_id,
+		NULL::BIGINT AS list_id,
+		NULL::TEXT AS list_name,
+		r.rating,
+		r.watched_on,
+		r.created_at AS occurred_at,
+		r.activity_id
+	FROM user_reviews r
+	JOIN followed f ON f.user_id = r.user_id
+	WHERE r.moderation_status = 'published'
+	UNION ALL
+	SELECT 'list_item', l.user_id, i.movie_id, l.id, l.name, NULL, NULL, i.added_at, i.activity_id
+	FROM user_movie_list_items i
+	JOIN user_movie_lists l ON l.id = i.list_id
+	JOIN followed f ON f.user_id = l.user_id
+	WHERE l.is_public
+) activity
+WHERE $2::TIMESTAMPTZ IS NULL OR (occurred_at, activity_id) < ($2::TIMESTAMPTZ, $3::BIGINT)
+ORDER BY occurred_at DESC, activity_id DESC
+LIMIT $4
+`
+)
+
+const (
+	// InsertOutboxEventQuery inserts a domain event into the outbox
+	InsertOutboxEventQuery = `
+INSERT INTO outbox_events (event_type, aggregate_id, payload) VALUES ($1, $2, $3)
+`
+
+	// ListPendingOutboxEventsQuery lists a batch of the outbox events due to be published, locking them so concurrent
+	// relays skip them
+	ListPendingOutboxEventsQuery = `
+SELECT id, event_type, aggregate_id, payload::TEXT, created_at, attempts
+FROM outbox_events
+WHERE published_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= NOW()
+ORDER BY next_attempt_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+	// MarkOutboxEventPublishedQuery marks an outbox event as published
+	MarkOutboxEventPublishedQuery = `
+UPDATE outbox_events SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1
+`
+
+	// MarkOutboxEventFailedQuery records a failed publishing attempt of an outbox event, scheduling its next attempt
+	// after the given number of seconds
+	MarkOutboxEventFailedQuery = `
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + make_interval(secs => $3)
+WHERE id = $1
+`
+
+	// MarkOutboxEventDeadLetteredQuery marks an outbox event as moved to the dead-letter stream
+	MarkOutboxEventDeadLetteredQuery = `
+UPDATE outbox_events SET dead_lettered_at = NOW(), attempts = attempts + 1, last_error = $2 WHERE id = $1
+`
+)
+
+const (
+	// InsertWebhookSubscriptionQuery inserts a webhook subscription
+	InsertWebhookSubscriptionQuery = `
+INSERT INTO webhook_subscriptions (url, secret, event_types, description, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at
+`
+
+	// ListWebhookSubscriptionsQuery lists the webhook subscriptions, from the newest to the oldest one
+	ListWebhookSubscriptionsQuery = `
+SELECT id, url, event_types, description, created_by::TEXT, created_at
+FROM webhook_subscriptions
+ORDER BY created_at DESC, id DESC
+`
+
+	// ExistsWebhookSubscriptionQuery checks if a webhook subscription exists
+	ExistsWebhookSubscriptionQuery = `
+SELECT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE id = $1)
+`
+
+	// DeleteWebhookSubscriptionQuery deletes a webhook subscription with its deliveries
+	DeleteWebhookSubscriptionQuery = `
+DELETE FROM webhook_subscriptions WHERE id = $1
+`
+
+	// InsertWebhookDeliveriesQuery inserts a delivery of an event for every webhook subscription that matches its
+	// type, doing nothing for the subscriptions the event was already queued for
+	InsertWebhookDeliveriesQuery = `
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, body)
+SELECT id, $1, $2, $3
+FROM webhook_subscriptions
+WHERE cardinality(event_types) = 0 OR $2 = ANY (event_types)
+ON CONFLICT (subscription_id, event_id) DO NOTHING
+`
+
+	// ClaimWebhookDeliveriesQuery claims a batch of the webhook deliveries due to be attempted, postponing their
+	// next attempt by the given number of seconds so other dispatchers skip them while they are being delivered
+	ClaimWebhookDeliveriesQuery = `
+UPDATE webhook_deliveries d
+SET next_attempt_at = NOW() + make_interval(secs => $2)
+FROM webhook_subscriptions s
+WHERE s.id = d.subscription_id
+	AND d.id IN (
+		SELECT id
+		FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at, id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+RETURNING d.id, d.event_id, d.event_type, d.body, d.attempts, s.url, s.secret
+`
+
+	// MarkWebhookDeliverySucceededQuery records the successful attempt of a webhook delivery
+	MarkWebhookDeliverySucceededQuery = `
+UPDATE webhook_deliveries
+SET status = 'succeeded',
+	attempts = attempts + 1,
+	last_status_code = $2,
+	last_error = NULL,
+	last_attempt_at = NOW(),
+	delivered_at = NOW()
+WHERE id = $1
+`
+
+	// MarkWebhookDeliveryFailedQuery records a failed attempt of a webhook delivery, scheduling its next attempt after
+	// the given number of seconds
+	MarkWebhookDeliveryFailedQuery = `
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+	last_status_code = $2,
+	last_error = $3,
+	last_attempt_at = NOW(),
+	next_attempt_at = NOW() + make_interval(secs => $4)
+WHERE id = $1
+`
+
+	// MarkWebhookDeliveryExhaustedQuery records the last failed attempt of a webhook delivery, giving up on it
+	MarkWebhookDeliveryExhaustedQuery = `
+UPDATE webhook_deliveries
+SET status = 'failed',
+	attempts = attempts + 1,
+	last_status_code = $2,
+	last_error = $3,
+	last_attempt_at = NOW()
+WHERE id = $1
+`
+
+	// ListWebhookDeliveriesQuery lists a page of the deliveries of a webhook subscription, from the newest to the
+	// oldest delivery, after the given cursor
+	ListWebhookDeliveriesQuery = `
+SELECT
+	id,
+	event_id,
+	event_type,
+	status,
+	attempts,
+	last_status_code,
+	last_error,
+	created_at,
+	last_attempt_at,
+	delivered_at
+FROM webhook_deliveries
+WHERE subscription_id = $1
+	AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) < ($2::TIMESTAMPTZ, $3::BIGINT))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+)
+
+const (
+	// LockMigrationsQuery takes the session advisory lock of the schema migrations, waiting for other replicas that
+	// hold it
+	LockMigrationsQuery = `
+SELECT pg_advisory_lock($1)
+`
+
+	// UnlockMigrationsQuery releases the session advisory lock of the schema migrations
+	UnlockMigrationsQuery = `
+SELECT pg_advisory_unlock($1)
+`
+
+	// CreateSchemaMigrationsTableQuery creates the table of the applied schema migrations
+	CreateSchemaMigrationsTableQuery = `
+CREATE TABLE IF NOT EXISTS schema_migrations
+(
+	version    INTEGER PRIMARY KEY,
+	name       TEXT        NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)
+`
+
+	// ExistsSchemaMigrationsTableQuery checks if the table of the applied schema migrations exists
+	ExistsSchemaMigrationsTableQuery = `
+SELECT to_regclass('schema_migrations') IS NOT NULL
+`
+
+	// ExistsBaseSchemaQuery checks if the base schema managed by the sqlmovies package exists, since the migrations
+	// extend its tables
+	ExistsBaseSchemaQuery = `
+SELECT to_regclass('user_reviews') IS NOT NULL
+`
+
+	// ListSchemaMigrationsQuery lists the applied schema migrations, from the oldest to the newest version
+	ListSchemaMigrationsQuery = `
+SELECT version, name, applied_at
+FROM schema_migrations
+ORDER BY version
+`
+
+	// InsertSchemaMigrationQuery records an applied schema migration
+	InsertSchemaMigrationQuery = `
+INSERT INTO schema_migrations (version, name) VALUES ($1, $2)
+`
+
+	// DeleteSchemaMigrationQuery removes a reverted schema migration
+	DeleteSchemaMigrationQuery = `
+DELETE FROM schema_migrations WHERE version = $1
+`
+)
+
+const (
+	// ListUserReviewsForExportQuery lists every review of a user, whatever its moderation status, from the oldest to
+	// the newest
+	ListUserReviewsForExportQuery = `
+SELECT
+	movie_id,
+	rating,
+	review_text,
+	contains_spoilers,
+	tags,
+	watched_on,
+	moderation_status,
+	version,
+	created_at,
+	updated_at
+FROM user_reviews
+WHERE user_id = $1
+ORDER BY created_at, movie_id
+`
+
+	// ListUserFollowerIDsQuery lists the IDs of the followers of a user
+	ListUserFollowerIDsQuery = `
+SELECT follower_user_id::TEXT FROM user_follows WHERE followed_user_id = $1
+`
+
+	// SubtractUserVotesFromCountsQuery subtracts the votes cast by a user from the vote counts of the voted reviews,
+	// before the votes are deleted
+	SubtractUserVotesFromCountsQuery = `
+UPDATE user_reviews r
+SET likes_count = r.likes_count - v.likes_count,
+	helpful_count = r.helpful_count - v.helpful_count
+FROM (
+	SELECT
+		review_user_id,
+		movie_id,
+		COUNT(*) FILTER (WHERE vote_type = 'like') AS likes_count,
+		COUNT(*) FILTER (WHERE vote_type = 'helpful') AS helpful_count
+	FROM user_review_votes
+	WHERE voter_user_id = $1
+	GROUP BY review_user_id, movie_id
+) v
+WHERE r.user_id = v.review_user_id AND r.movie_id = v.movie_id
+`
+
+	// DeleteUserVotesQuery deletes the votes cast by a user
+	DeleteUserVotesQuery = `
+DELETE FROM user_review_votes WHERE voter_user_id = $1
+`
+
+	// AnonymizeUserCommentsQuery clears the author and the content of the comments written by a user and soft deletes
+	// them, keeping the replies of the other users in their threads
+	AnonymizeUserCommentsQuery = `
+UPDATE user_review_comments
+SET user_id = NULL, content = '', deleted_at = COALESCE(deleted_at, NOW())
+WHERE user_id = $1
+`
+
+	// DeleteUserContentReportsQuery deletes the reports filed by a user
+	DeleteUserContentReportsQuery = `
+DELETE FROM content_reports WHERE reporter_user_id = $1
+`
+
+	// AnonymizeUserModerationAuditQuery clears the review texts kept by the moderation audit trail of a user's
+	// reviews, keeping the moderation decisions
+	AnonymizeUserModerationAuditQuery = `
+UPDATE user_review_moderation_audit SET review_text = NULL WHERE review_user_id = $1 AND review_text IS NOT NULL
+`
+
+	// DeleteUserReviewsQuery deletes every review of a user with their votes, comments and reports
+	DeleteUserReviewsQuery = `
+DELETE FROM user_reviews WHERE user_id = $1 RETURNING movie_id
+`
+
+	// DeleteUserMovieListsQuery deletes every movie list of a user with their items
+	DeleteUserMovieListsQuery = `
+DELETE FROM user_movie_lists WHERE user_id = $1
+`
+
+	// DeleteUserFollowsQuery deletes the follows from and to a user
+	DeleteUserFollowsQuery = `
+DELETE FROM user_follows WHERE follower_user_id = $1 OR followed_user_id = $1
+`
+
+	// DeleteUserQuery deletes the username of a user
+	DeleteUserQuery = `
+DELETE FROM users WHERE id = $1
+`
+
+	// DeleteUserDataExportsQuery deletes the data exports of a user with their bundles
+	DeleteUserDataExportsQuery = `
+DELETE FROM user_data_exports WHERE user_id = $1
+`
+
+	// DeleteUserReviewImportsQuery deletes the review imports of a user with their rows
+	DeleteUserReviewImportsQuery = `
+DELETE FROM user_review_imports WHERE user_id = $1
+`
+
+	// DeleteUserRevisionsQuery deletes every revision of the reviews of a user, including the archived ones
+	DeleteUserRevisionsQuery = `
+DELETE FROM user_review_revisions WHERE review_user_id = $1
+`
+)
+
+const (
+	// ListUserMovieListsForExportQuery lists every movie list of a user with its items by position, from the oldest
+	// to the newest list
+	ListUserMovieListsForExportQuery = `
+SELECT
+	l.name,
+	l.description,
+	l.is_public,
+	l.created_at,
+	l.updated_at,
+	COALESCE(
+		json_agg(
+			json_build_object('movie_id', i.movie_id, 'note', i.note, 'added_at', i.added_at)
+			ORDER BY i.position
+		) FILTER (WHERE i.movie_id IS NOT NULL),
+		'[]'
+	)
+FROM user_movie_lists l
+LEFT JOIN user_movie_list_items i ON i.list_id = l.id
+WHERE l.user_id = $1
+GROUP BY l.id
+ORDER BY l.created_at, l.id
+`
+
+	// ListUserCommentsForExportQuery lists every comment written by a user, deleted ones included, from the oldest
+	// to the newest
+	ListUserCommentsForExportQuery = `
+SELECT id, review_user_id::TEXT, movie_id, parent_id, content, created_at, updated_at, deleted_at
+FROM user_review_comments
+WHERE user_id = $1
+ORDER BY created_at, id
+`
+
+	// ListUserVotesForExportQuery lists every vote cast by a user, from the oldest to the newest
+	ListUserVotesForExportQuery = `
+SELECT review_user_id::TEXT, movie_id, vote_type, created_at
+FROM user_review_votes
+WHERE voter_user_id = $1
+ORDER BY created_at, review_user_id, movie_id, vote_type
+`
+
+	// ListUserFollowingForExportQuery lists every user followed by a user, from the oldest to the newest follow
+	ListUserFollowingForExportQuery = `
+SELECT followed_user_id::TEXT, created_at
+FROM user_follows
+WHERE follower_user_id = $1
+ORDER BY created_at, followed_user_id
+`
+)
+
+const (
+	// InsertUserDataExportQuery requests a data export for a user, returning the export in progress instead if the
+	// user already has one
+	InsertUserDataExportQuery = `
+WITH inserted AS (
+	INSERT INTO user_data_exports (user_id)
+	VALUES ($1)
+	ON CONFLICT (user_id) WHERE status IN ('pending', 'running') DO NOTHING
+	RETURNING id, status, created_at
+)
+SELECT id, status, created_at FROM inserted
+UNION ALL
+SELECT id, status, created_at FROM user_data_exports WHERE user_id = $1 AND status IN ('pending', 'running')
+LIMIT 1
+`
+
+	// ClaimUserDataExportsQuery marks a batch of the pending data exports as running, skipping the ones locked by
+	// other exporters. Running exports started more than the given number of seconds ago are claimed again, as their
+	// exporter is assumed to have stopped
+	ClaimUserDataExportsQuery = `
+UPDATE user_data_exports
+SET status = 'running', started_at = NOW()
+WHERE id IN (
+	SELECT id
+	FROM user_data_exports
+	WHERE status = 'pending' OR (status = 'running' AND started_at < NOW() - make_interval(secs => $2))
+	ORDER BY created_at, id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id::TEXT
+`
+
+	// CompleteUserDataExportQuery stores the bundle of a data export, keeping it for the given number of seconds
+	CompleteUserDataExportQuery = `
+UPDATE user_data_exports
+SET status = 'succeeded',
+	bundle = $2,
+	error = NULL,
+	completed_at = NOW(),
+	expires_at = NOW() + make_interval(secs => $3)
+WHERE id = $1 AND status = 'running'
+`
+
+	// FailUserDataExportQuery records the error of a failed data export, keeping it for the given number of seconds
+	FailUserDataExportQuery = `
+UPDATE user_data_exports
+SET status = 'failed', error = $2, completed_at = NOW(), expires_at = NOW() + make_interval(secs => $3)
+WHERE id = $1 AND status = 'running'
+`
+
+	// GetUserDataExportQuery gets a data export of a user with its bundle, unless it expired
+	GetUserDataExportQuery = `
+SELECT status, error, created_at, completed_at, expires_at, bundle
+FROM user_data_exports
+WHERE id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > NOW())
+`
+
+	// DeleteExpiredUserDataExportsQuery deletes the data exports whose bundles expired
+	DeleteExpiredUserDataExportsQuery = `
+DELETE FROM user_data_exports WHERE expires_at <= NOW()
+`
+)
+
+const (
+	// InsertUserReviewImportQuery inserts a review import of a user
+	InsertUserReviewImportQuery = `
+INSERT INTO user_review_imports (user_id, source, total_rows)
+VALUES ($1, $2, $3)
+RETURNING id, created_at
+`
+
+	// GetUserReviewImportQuery gets a review import of a user with the number of its rows by status
+	GetUserReviewImportQuery = `
+SELECT
+	i.source,
+	i.status,
+	i.total_rows,
+	COUNT(r.row_number) FILTER (WHERE r.status <> 'pending'),
+	COUNT(r.row_number) FILTER (WHERE r.status = 'imported'),
+	COUNT(r.row_number) FILTER (WHERE r.status = 'skipped'),
+	COUNT(r.row_number) FILTER (WHERE r.status = 'unmatched'),
+	i.created_at,
+	i.completed_at
+FROM user_review_imports i
+LEFT JOIN user_review_import_rows r ON r.import_id = i.id
+WHERE i.id = $1 AND i.user_id = $2
+GROUP BY i.id
+`
+
+	// ListUnimportedUserReviewImportRowsQuery lists the rows of a review import that were skipped or did not match a
+	// TMDB movie, by row number
+	ListUnimportedUserReviewImportRowsQuery = `
+SELECT row_number, title, year, imdb_id, status, reason
+FROM user_review_import_rows
+WHERE import_id = $1 AND status IN ('skipped', 'unmatched')
+ORDER BY row_number
+`
+
+	// ClaimUserReviewImportQuery marks the oldest pending review import as running, skipping the ones locked by other
+	// importers. Running imports not updated for more than the given number of seconds are claimed again, as their
+	// importer is assumed to have stopped
+	ClaimUserReviewImportQuery = `
+UPDATE user_review_imports
+SET status = 'running', started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+WHERE id = (
+	SELECT id
+	FROM user_review_imports
+	WHERE status = 'pending' OR (status = 'running' AND updated_at < NOW() - make_interval(secs => $1))
+	ORDER BY created_at, id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id::TEXT
+`
+
+	// ListPendingUserReviewImportRowsQuery lists a batch of the pending rows of a review import, by row number
+	ListPendingUserReviewImportRowsQuery = `
+SELECT row_number, title, year, imdb_id, rating, review_text, tags, watched_on
+FROM user_review_import_rows
+WHERE import_id = $1 AND status = 'pending'
+ORDER BY row_number
+LIMIT $2
+`
+
+	// SetUserReviewImportRowStatusQuery records the outcome of a row of a review import
+	SetUserReviewImportRowStatusQuery = `
+UPDATE user_review_import_rows
+SET status = $3, movie_id = $4, reason = NULLIF($5, '')
+WHERE import_id = $1 AND row_number = $2
+`
+
+	// TouchUserReviewImportQuery sets the update time of a running review import, so other importers do not claim it
+	TouchUserReviewImportQuery = `
+UPDATE user_review_imports SET updated_at = NOW() WHERE id = $1 AND status = 'running'
+`
+
+	// CompleteUserReviewImportQuery marks a running review import as succeeded
+	CompleteUserReviewImportQuery = `
+UPDATE user_review_imports
+SET status = 'succeeded', updated_at = NOW(), completed_at = NOW()
+WHERE id = $1 AND status = 'running'
+`
+)
+
+const (
+	// GetMovieIDByExternalIDQuery gets the TMDB movie ID mapped to an external ID of a source
+	GetMovieIDByExternalIDQuery = `
+SELECT movie_id FROM movie_external_ids WHERE source = $1 AND external_id = $2
+`
+
+	// UpsertMovieExternalIDQuery maps an external ID of a source to a TMDB movie ID, replacing the previous mapping
+	UpsertMovieExternalIDQuery = `
+INSERT INTO movie_external_ids (source, external_id, movie_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (source, external_id) DO UPDATE
+SET movie_id = EXCLUDED.movie_id, updated_at = NOW()
+WHERE movie_external_ids.movie_id <> EXCLUDED.movie_id
+`
+)
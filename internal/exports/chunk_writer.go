@@ -0,0 +1,87 @@
+package exports
+
+type (
+	// SendChunkFn sends a chunk of an export. The chunk must not be retained after the function returns
+	SendChunkFn func(chunk []byte) error
+
+	// ChunkWriter buffers the encoded records of an export and sends them in chunks of ChunkSize bytes
+	ChunkWriter struct {
+		send   SendChunkFn
+		buffer []byte
+	}
+)
+
+// NewChunkWriter creates a new chunk writer
+//
+// Parameters:
+//
+// - send: the function to send each chunk
+//
+// Returns:
+//
+// - *ChunkWriter: the chunk writer
+// - error: if the send function is nil
+func NewChunkWriter(send SendChunkFn) (*ChunkWriter, error) {
+	// Check if the send function is nil
+	if send == nil {
+		return nil, ErrNilSendChunkFn
+	}
+
+	return &ChunkWriter{
+		send:   send,
+		buffer: make([]byte, 0, ChunkSize),
+	}, nil
+}
+
+// Write buffers the given bytes, sending the full chunks
+//
+// Parameters:
+//
+// - p: the bytes to write
+//
+// Returns:
+//
+// - int: the number of bytes written
+// - error: if a chunk could not be sent
+func (w *ChunkWriter) Write(p []byte) (int, error) {
+	if w == nil {
+		panic(ErrNilChunkWriter)
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// Fill the buffer up to the chunk size
+		size := min(len(p), ChunkSize-len(w.buffer))
+		w.buffer = append(w.buffer, p[:size]...)
+		p = p[size:]
+		written += size
+
+		// Send the buffer once it is full
+		if len(w.buffer) == ChunkSize {
+			if err := w.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush sends the buffered bytes, if any
+//
+// Returns:
+//
+// - error: if the chunk could not be sent
+func (w *ChunkWriter) Flush() error {
+	if w == nil {
+		panic(ErrNilChunkWriter)
+	}
+
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	if err := w.send(w.buffer); err != nil {
+		return err
+	}
+	w.buffer = w.buffer[:0]
+	return nil
+}
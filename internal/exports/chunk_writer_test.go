@@ -0,0 +1,120 @@
+package exports
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// chunkRecorder records the chunks sent by a chunk writer
+type chunkRecorder struct {
+	chunks [][]byte
+	err    error
+}
+
+// send records a copy of the chunk, as the chunk writer reuses its buffer
+//
+// Parameters:
+//
+// - chunk: the sent chunk
+//
+// Returns:
+//
+// - error: the error set on the recorder
+func (r *chunkRecorder) send(chunk []byte) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.chunks = append(r.chunks, bytes.Clone(chunk))
+	return nil
+}
+
+func TestNewChunkWriterNilSend(t *testing.T) {
+	if _, err := NewChunkWriter(nil); !errors.Is(err, ErrNilSendChunkFn) {
+		t.Errorf("NewChunkWriter() error = %v, want %v", err, ErrNilSendChunkFn)
+	}
+}
+
+func TestChunkWriter(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		writes      []int
+		wantChunks  []int
+		wantFlushed []int
+	}{
+		{"empty", nil, nil, nil},
+		{"partial chunk", []int{10}, nil, []int{10}},
+		{"exact chunk", []int{ChunkSize}, []int{ChunkSize}, []int{ChunkSize}},
+		{"several writes", []int{ChunkSize - 1, 2, 3}, []int{ChunkSize}, []int{ChunkSize, 4}},
+		{"large write", []int{2*ChunkSize + 5}, []int{ChunkSize, ChunkSize}, []int{ChunkSize, ChunkSize, 5}},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				var recorder chunkRecorder
+				writer, err := NewChunkWriter(recorder.send)
+				if err != nil {
+					t.Fatalf("NewChunkWriter() error = %v", err)
+				}
+
+				var written []byte
+				for i, size := range test.writes {
+					p := bytes.Repeat([]byte{byte('a' + i)}, size)
+					n, writeErr := writer.Write(p)
+					if writeErr != nil {
+						t.Fatalf("Write() error = %v", writeErr)
+					}
+					if n != size {
+						t.Fatalf("Write() = %d, want %d", n, size)
+					}
+					written = append(written, p...)
+				}
+				assertChunkSizes(t, recorder.chunks, test.wantChunks)
+
+				if err = writer.Flush(); err != nil {
+					t.Fatalf("Flush() error = %v", err)
+				}
+				assertChunkSizes(t, recorder.chunks, test.wantFlushed)
+				if got := bytes.Join(recorder.chunks, nil); !bytes.Equal(got, written) {
+					t.Error("sent chunks do not match the written bytes")
+				}
+			},
+		)
+	}
+}
+
+func TestChunkWriterSendError(t *testing.T) {
+	sendErr := errors.New("stream closed")
+	recorder := chunkRecorder{err: sendErr}
+	writer, err := NewChunkWriter(recorder.send)
+	if err != nil {
+		t.Fatalf("NewChunkWriter() error = %v", err)
+	}
+
+	n, err := writer.Write(make([]byte, ChunkSize+1))
+	if !errors.Is(err, sendErr) {
+		t.Errorf("Write() error = %v, want %v", err, sendErr)
+	}
+	if n != ChunkSize {
+		t.Errorf("Write() = %d, want %d", n, ChunkSize)
+	}
+}
+
+// assertChunkSizes checks the sizes of the sent chunks
+//
+// Parameters:
+//
+// - t: the test
+// - chunks: the sent chunks
+// - want: the expected sizes
+func assertChunkSizes(t *testing.T, chunks [][]byte, want []int) {
+	t.Helper()
+
+	if len(chunks) != len(want) {
+		t.Fatalf("sent %d chunks, want %d", len(chunks), len(want))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) != want[i] {
+			t.Errorf("chunk %d has %d bytes, want %d", i, len(chunk), want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package exports
+
+const (
+	// FormatCSV is the format of the CSV exports, with the columns of the Letterboxd import format
+	FormatCSV = "csv"
+
+	// FormatJSON is the format of the JSON exports, as an array of records
+	FormatJSON = "json"
+)
+
+const (
+	// ContentReviews is the content of the exports of the user reviews
+	ContentReviews = "reviews"
+
+	// ContentWatchlist is the content of the exports of the user watchlist
+	ContentWatchlist = "watchlist"
+
+	// ContentLists is the content of the exports of the user movie lists, except the watchlist
+	ContentLists = "lists"
+)
+
+const (
+	// WatchlistName is the name reserved for the user watchlist, the movie list exported as the watchlist. The lists
+	// store it with this casing, whatever the casing the user named them with
+	WatchlistName = "Watchlist"
+
+	// ChunkSize is the number of bytes sent per streamed chunk
+	ChunkSize = 32 << 10
+
+	// EnrichBatchSize is the number of records whose movies are fetched at once
+	EnrichBatchSize = 50
+
+	// FileNameFormat is the format of the export file names, from the content and the format
+	FileNameFormat = "%s.%s"
+
+	// LetterboxdRatingScale is the factor between the review ratings and the Letterboxd half-star ratings
+	LetterboxdRatingScale = 2
+)
+
+var (
+	// ContentTypes are the MIME types of the export formats
+	ContentTypes = map[string]string{
+		FormatCSV:  "text/csv; charset=utf-8",
+		FormatJSON: "application/json",
+	}
+
+	// ReviewColumns are the CSV columns of the reviews exports, as read by the Letterboxd importer
+	ReviewColumns = []string{"tmdbID", "Title", "Year", "Rating", "Rating10", "WatchedDate", "Tags", "Review"}
+
+	// WatchlistColumns are the CSV columns of the watchlist exports, as read by the Letterboxd importer
+	WatchlistColumns = []string{"tmdbID", "Title", "Year"}
+
+	// ListColumns are the CSV columns of the movie lists exports, as read by the Letterboxd list importer with the
+	// name of the list of each item first
+	ListColumns = []string{"List", "Position", "tmdbID", "Title", "Year", "Description"}
+)
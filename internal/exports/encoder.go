@@ -0,0 +1,220 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Movie is the exported movie of a record, enriched with its TMDB title and release year
+	Movie struct {
+		ID    int32  `json:"tmdb_id"`
+		Title string `json:"title,omitempty"`
+		Year  *int32 `json:"year,omitempty"`
+	}
+
+	// Record is an exported record, encoded as a CSV row or as a JSON object
+	Record interface {
+		CSVRow() []string
+	}
+
+	// ReviewRecord is an exported user review
+	ReviewRecord struct {
+		Movie
+		Rating           int32     `json:"rating"`
+		Review           string    `json:"review,omitempty"`
+		ContainsSpoilers bool      `json:"contains_spoilers"`
+		Tags             []string  `json:"tags"`
+		WatchedOn        *string   `json:"watched_on,omitempty"`
+		CreatedAt        time.Time `json:"created_at"`
+	}
+
+	// WatchlistRecord is an exported movie of the user watchlist
+	WatchlistRecord struct {
+		Movie
+		AddedAt time.Time `json:"added_at"`
+	}
+
+	// ListRecord is an exported movie of a user movie list
+	ListRecord struct {
+		List     string `json:"list"`
+		Position int32  `json:"position"`
+		Movie
+		Note    string    `json:"note,omitempty"`
+		AddedAt time.Time `json:"added_at"`
+	}
+
+	// Encoder encodes the records of an export
+	Encoder interface {
+		Encode(record Record) error
+		Close() error
+	}
+
+	// csvEncoder encodes the records as the rows of a CSV file, after its header
+	csvEncoder struct {
+		writer *csv.Writer
+	}
+
+	// jsonEncoder encodes the records as the objects of a JSON array
+	jsonEncoder struct {
+		writer  io.Writer
+		encoded int
+	}
+)
+
+// csvYear formats the release year of a movie for a CSV row
+//
+// Returns:
+//
+// - string: the release year, or an empty string if it is unknown
+func (m Movie) csvYear() string {
+	if m.Year == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*m.Year))
+}
+
+// CSVRow gets the CSV row of the review, in the order of the ReviewColumns. The rating is also written as Letterboxd
+// half stars
+//
+// Returns:
+//
+// - []string: the CSV row
+func (r ReviewRecord) CSVRow() []string {
+	var watchedOn string
+	if r.WatchedOn != nil {
+		watchedOn = *r.WatchedOn
+	}
+	return []string{
+		strconv.Itoa(int(r.ID)),
+		r.Title,
+		r.csvYear(),
+		strconv.FormatFloat(float64(r.Rating)/LetterboxdRatingScale, 'f', -1, 64),
+		strconv.Itoa(int(r.Rating)),
+		watchedOn,
+		strings.Join(r.Tags, ", "),
+		r.Review,
+	}
+}
+
+// CSVRow gets the CSV row of the watchlist movie, in the order of the WatchlistColumns
+//
+// Returns:
+//
+// - []string: the CSV row
+func (r WatchlistRecord) CSVRow() []string {
+	return []string{
+		strconv.Itoa(int(r.ID)),
+		r.Title,
+		r.csvYear(),
+	}
+}
+
+// CSVRow gets the CSV row of the list movie, in the order of the ListColumns
+//
+// Returns:
+//
+// - []string: the CSV row
+func (r ListRecord) CSVRow() []string {
+	return []string{
+		r.List,
+		strconv.Itoa(int(r.Position)),
+		strconv.Itoa(int(r.ID)),
+		r.Title,
+		r.csvYear(),
+		r.Note,
+	}
+}
+
+// NewEncoder creates a new encoder of the records of an export
+//
+// Parameters:
+//
+// - format: the export format
+// - writer: the writer of the encoded records
+// - columns: the CSV columns, written as the header of the CSV exports
+//
+// Returns:
+//
+// - Encoder: the encoder
+// - error: if the format is unknown or the CSV header could not be written
+func NewEncoder(format string, writer io.Writer, columns []string) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{writer: csvWriter}, nil
+	case FormatJSON:
+		if _, err := io.WriteString(writer, "["); err != nil {
+			return nil, err
+		}
+		return &jsonEncoder{writer: writer}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Encode encodes a record as a CSV row
+//
+// Parameters:
+//
+// - record: the record to encode
+//
+// Returns:
+//
+// - error: if the row could not be written
+func (e *csvEncoder) Encode(record Record) error {
+	return e.writer.Write(record.CSVRow())
+}
+
+// Close flushes the CSV rows
+//
+// Returns:
+//
+// - error: if the rows could not be flushed
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// Encode encodes a record as an object of the JSON array
+//
+// Parameters:
+//
+// - record: the record to encode
+//
+// Returns:
+//
+// - error: if the record could not be marshaled or written
+func (e *jsonEncoder) Encode(record Record) error {
+	encodedRecord, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if e.encoded > 0 {
+		if _, err = io.WriteString(e.writer, ","); err != nil {
+			return err
+		}
+	}
+	if _, err = e.writer.Write(encodedRecord); err != nil {
+		return err
+	}
+	e.encoded++
+	return nil
+}
+
+// Close closes the JSON array
+//
+// Returns:
+//
+// - error: if the end of the array could not be written
+func (e *jsonEncoder) Close() error {
+	_, err := io.WriteString(e.writer, "]\n")
+	return err
+}
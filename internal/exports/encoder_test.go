@@ -0,0 +1,153 @@
+package exports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testRecords gets the records encoded by the tests
+//
+// Returns:
+//
+// - []Record: the records
+func testRecords() []Record {
+	year := int32(1999)
+	watchedOn := "2024-05-01"
+	addedAt := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+	return []Record{
+		ReviewRecord{
+			Movie:            Movie{ID: 603, Title: "The Matrix", Year: &year},
+			Rating:           9,
+			Review:           "Still holds up, \"really\"",
+			ContainsSpoilers: true,
+			Tags:             []string{"rewatch", "sci-fi"},
+			WatchedOn:        &watchedOn,
+			CreatedAt:        addedAt,
+		},
+		WatchlistRecord{Movie: Movie{ID: 550}, AddedAt: addedAt},
+		ListRecord{List: "Favorites", Position: 1, Movie: Movie{ID: 13, Title: "Forrest Gump"}, AddedAt: addedAt},
+	}
+}
+
+func TestRecordCSVRows(t *testing.T) {
+	records := testRecords()
+	for i, want := range [][]string{
+		{"603", "The Matrix", "1999", "4.5", "9", "2024-05-01", "rewatch, sci-fi", "Still holds up, \"really\""},
+		{"550", "", ""},
+		{"Favorites", "1", "13", "Forrest Gump", "", ""},
+	} {
+		if got := records[i].CSVRow(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%T.CSVRow() = %q, want %q", records[i], got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		record  Record
+		columns []string
+	}{
+		{records[0], ReviewColumns},
+		{records[1], WatchlistColumns},
+		{records[2], ListColumns},
+	} {
+		if len(test.record.CSVRow()) != len(test.columns) {
+			t.Errorf("%T.CSVRow() has %d values, want one per column", test.record, len(test.record.CSVRow()))
+		}
+	}
+}
+
+func TestCSVEncoder(t *testing.T) {
+	var buffer bytes.Buffer
+	encoder, err := NewEncoder(FormatCSV, &buffer, ReviewColumns)
+	if err != nil {
+		t.Fatalf("NewEncoder() error = %v", err)
+	}
+	record := testRecords()[0]
+	if err = encoder.Encode(record); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if err = encoder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatalf("encoded CSV is invalid: %v", err)
+	}
+	want := [][]string{ReviewColumns, record.CSVRow()}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("encoded CSV rows = %q, want %q", rows, want)
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		records []Record
+	}{
+		{"empty", nil},
+		{"single record", testRecords()[:1]},
+		{"several records", testRecords()},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				var buffer bytes.Buffer
+				encoder, err := NewEncoder(FormatJSON, &buffer, nil)
+				if err != nil {
+					t.Fatalf("NewEncoder() error = %v", err)
+				}
+				for _, record := range test.records {
+					if err = encoder.Encode(record); err != nil {
+						t.Fatalf("Encode() error = %v", err)
+					}
+				}
+				if err = encoder.Close(); err != nil {
+					t.Fatalf("Close() error = %v", err)
+				}
+
+				var objects []json.RawMessage
+				if err = json.Unmarshal(buffer.Bytes(), &objects); err != nil {
+					t.Fatalf("encoded JSON %q is invalid: %v", buffer.String(), err)
+				}
+				if len(objects) != len(test.records) {
+					t.Fatalf("encoded JSON has %d objects, want %d", len(objects), len(test.records))
+				}
+				for i, record := range test.records {
+					want, _ := json.Marshal(record)
+					if !bytes.Equal(objects[i], want) {
+						t.Errorf("object %d = %s, want %s", i, objects[i], want)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestJSONEncoderFields(t *testing.T) {
+	var buffer bytes.Buffer
+	encoder, err := NewEncoder(FormatJSON, &buffer, nil)
+	if err != nil {
+		t.Fatalf("NewEncoder() error = %v", err)
+	}
+	if err = encoder.Encode(testRecords()[1]); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if err = encoder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := `[{"tmdb_id":550,"added_at":"2024-05-02T10:00:00Z"}]` + "\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("encoded JSON = %s, want %s", got, want)
+	}
+}
+
+func TestNewEncoderUnknownFormat(t *testing.T) {
+	if _, err := NewEncoder("xml", &bytes.Buffer{}, nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("NewEncoder() error = %v, want %v", err, ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,11 @@
+package exports
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilChunkWriter = errors.New("export chunk writer is nil")
+	ErrNilSendChunkFn = errors.New("send chunk function is nil")
+	ErrUnknownFormat  = errors.New("unknown export format")
+)
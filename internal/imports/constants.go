@@ -0,0 +1,175 @@
+package imports
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvPollInterval is the environment variable for the interval between the review importer polls
+	EnvPollInterval = "REVIEW_IMPORT_POLL_INTERVAL"
+
+	// EnvBatchSize is the environment variable for the number of rows of a review import processed per batch
+	EnvBatchSize = "REVIEW_IMPORT_BATCH_SIZE"
+)
+
+const (
+	// ImportLease is the time a review import can stay running without progress before another importer claims it
+	// again
+	ImportLease = 5 * time.Minute
+
+	// MaxUploadSize is the maximum number of bytes of an uploaded export file
+	MaxUploadSize = 16 << 20
+
+	// MaxRows is the maximum number of rows of an uploaded export file
+	MaxRows = 20000
+
+	// MaxReasonLength is the maximum number of bytes of the reason kept for a row that was not imported
+	MaxReasonLength = 500
+)
+
+const (
+	// SourceLetterboxd is the source of the Letterboxd exports, such as ratings.csv, diary.csv or reviews.csv
+	SourceLetterboxd = "letterboxd"
+
+	// SourceIMDb is the source of the IMDb ratings exports
+	SourceIMDb = "imdb"
+)
+
+const (
+	// RowStatusPending is the status of the rows not processed yet
+	RowStatusPending = "pending"
+
+	// RowStatusImported is the status of the rows imported as a review
+	RowStatusImported = "imported"
+
+	// RowStatusSkipped is the status of the rows that were not imported because they are invalid or already reviewed
+	RowStatusSkipped = "skipped"
+
+	// RowStatusUnmatched is the status of the rows that did not match a TMDB movie
+	RowStatusUnmatched = "unmatched"
+)
+
+const (
+	// MinRating is the minimum rating of a review
+	MinRating = 1
+
+	// MaxRating is the maximum rating of a review
+	MaxRating = 10
+
+	// LetterboxdRatingScale is the factor that maps the Letterboxd half-star ratings, from 0.5 to 5, to the review
+	// ratings
+	LetterboxdRatingScale = 2
+
+	// WatchedOnLayout is the layout of the watched dates of the Letterboxd exports
+	WatchedOnLayout = "2006-01-02"
+
+	// TagsSeparator is the separator of the tags of the Letterboxd exports
+	TagsSeparator = ","
+)
+
+const (
+	// LetterboxdTitleColumn is the Letterboxd column of the movie title
+	LetterboxdTitleColumn = "name"
+
+	// LetterboxdYearColumn is the Letterboxd column of the movie release year
+	LetterboxdYearColumn = "year"
+
+	// LetterboxdRatingColumn is the Letterboxd column of the star rating
+	LetterboxdRatingColumn = "rating"
+
+	// LetterboxdWatchedDateColumn is the Letterboxd column of the watched date, only in the diary and reviews exports
+	LetterboxdWatchedDateColumn = "watched date"
+
+	// LetterboxdReviewColumn is the Letterboxd column of the review text, only in the reviews export
+	LetterboxdReviewColumn = "review"
+
+	// LetterboxdTagsColumn is the Letterboxd column of the tags, only in the diary and reviews exports
+	LetterboxdTagsColumn = "tags"
+
+	// IMDbIDColumn is the IMDb column of the IMDb ID
+	IMDbIDColumn = "const"
+
+	// IMDbRatingColumn is the IMDb column of the rating
+	IMDbRatingColumn = "your rating"
+
+	// IMDbTitleColumn is the IMDb column of the title
+	IMDbTitleColumn = "title"
+
+	// IMDbYearColumn is the IMDb column of the release year
+	IMDbYearColumn = "year"
+
+	// IMDbTitleTypeColumn is the IMDb column of the title type, such as movie or TV series
+	IMDbTitleTypeColumn = "title type"
+)
+
+const (
+	// ReasonMissingRating is the reason of the rows without a rating
+	ReasonMissingRating = "missing rating"
+
+	// ReasonInvalidRating is the reason of the rows with a rating that cannot be parsed or is out of range
+	ReasonInvalidRating = "invalid rating"
+
+	// ReasonInvalidYear is the reason of the rows with a release year that cannot be parsed
+	ReasonInvalidYear = "invalid year"
+
+	// ReasonInvalidWatchedDate is the reason of the rows with a watched date that cannot be parsed
+	ReasonInvalidWatchedDate = "invalid watched date"
+
+	// ReasonMissingTitle is the reason of the rows without a title or an IMDb ID to match
+	ReasonMissingTitle = "missing title"
+
+	// ReasonNotAMovie is the reason of the IMDb rows of other title types, such as TV series
+	ReasonNotAMovie = "not a movie"
+
+	// ReasonNoMatch is the reason of the rows that did not match a TMDB movie
+	ReasonNoMatch = "no TMDB movie matches the title and year"
+
+	// ReasonNoIMDbMatch is the reason of the rows whose IMDb ID and title did not match a TMDB movie
+	ReasonNoIMDbMatch = "no TMDB movie matches the IMDb ID"
+
+	// ReasonAlreadyReviewed is the reason of the rows of the movies already reviewed by the user
+	ReasonAlreadyReviewed = "movie already reviewed"
+
+	// ReasonRejected is the reason of the rows whose review was rejected by the database
+	ReasonRejected = "review rejected"
+)
+
+var (
+	// IMDbMovieTitleTypes are the IMDb title types imported as movies, in lower case
+	IMDbMovieTitleTypes = map[string]struct{}{
+		"movie":    {},
+		"tv movie": {},
+		"tvmovie":  {},
+		"short":    {},
+		"video":    {},
+	}
+)
+
+var (
+	// PollInterval is the interval between the review importer polls
+	PollInterval time.Duration
+
+	// BatchSize is the number of rows of a review import processed per batch
+	BatchSize int
+)
+
+// Load loads the review import constants
+func Load() {
+	// Get the batch size from the environment variable
+	if err := internalloader.Loader.LoadIntVariable(
+		EnvBatchSize,
+		&BatchSize,
+	); err != nil {
+		panic(err)
+	}
+
+	// Get the poll interval from the environment variable
+	if err := internalloader.Loader.LoadDurationVariable(
+		EnvPollInterval,
+		&PollInterval,
+	); err != nil {
+		panic(err)
+	}
+}
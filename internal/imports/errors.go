@@ -0,0 +1,17 @@
+package imports
+
+import (
+	"errors"
+)
+
+const (
+	ErrMissingColumn = "missing the %q column"
+	ErrTooManyRows   = "the file has more than %d rows"
+)
+
+var (
+	ErrNilImporter      = errors.New("review importer is nil")
+	ErrNilReviewCreator = errors.New("review creator is nil")
+	ErrUnknownSource    = errors.New("unknown review import source")
+	ErrEmptyFile        = errors.New("the file has no rows")
+)
@@ -0,0 +1,426 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+)
+
+type (
+	// ReviewCreator creates the reviews of the imported rows
+	ReviewCreator interface {
+		CreateImportedUserReview(ctx context.Context, userID string, movieID int32, row *Row) error
+	}
+
+	// Importer processes the review imports in the background. It claims the pending imports in Postgres, so several
+	// importers can run side by side, matches their rows to TMDB movies and creates the reviews. A row that cannot be
+	// processed because of a transient error stays pending, and its import is claimed again once its lease expires
+	Importer struct {
+		pool       *pgxpool.Pool
+		tmdbClient *internaltmdb.Client
+		creator    ReviewCreator
+		logger     *slog.Logger
+	}
+
+	// claimedImport is a review import claimed to be processed
+	claimedImport struct {
+		id     int64
+		userID string
+	}
+)
+
+// NewImporter creates a new review importer
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - tmdbClient: the TMDB API client
+// - creator: the review creator
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Importer: the review importer
+// - error: if there was an error creating the review importer
+func NewImporter(
+	pool *pgxpool.Pool,
+	tmdbClient *internaltmdb.Client,
+	creator ReviewCreator,
+	logger *slog.Logger,
+) (*Importer, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the TMDB API client is nil
+	if tmdbClient == nil {
+		return nil, gotmdbapi.ErrNilClient
+	}
+
+	// Check if the review creator is nil
+	if creator == nil {
+		return nil, ErrNilReviewCreator
+	}
+
+	// Create the logger for the importer
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "review_importer"),
+		)
+	}
+
+	return &Importer{
+		pool:       pool,
+		tmdbClient: tmdbClient,
+		creator:    creator,
+		logger:     logger,
+	}, nil
+}
+
+// Run processes the pending review imports on every poll interval until the context is done. A processed import is
+// followed right away by the next one, so a backlog is drained without waiting for the next poll
+//
+// Parameters:
+//
+// - ctx: the context
+func (i *Importer) Run(ctx context.Context) {
+	if i == nil {
+		panic(ErrNilImporter)
+	}
+
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := i.ImportNext(ctx)
+		if err != nil && i.logger != nil && ctx.Err() == nil {
+			i.logger.Error(
+				"Could not process the review import",
+				slog.String("error", err.Error()),
+			)
+		}
+		if err == nil && claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ImportNext claims the oldest pending review import and processes its rows in batches, until none is pending
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - bool: if a review import was claimed
+// - error: if there was an error claiming the import or processing its rows
+func (i *Importer) ImportNext(ctx context.Context) (bool, error) {
+	if i == nil {
+		panic(ErrNilImporter)
+	}
+
+	// Claim the oldest pending import
+	var reviewImport claimedImport
+	if err := i.pool.QueryRow(
+		ctx,
+		internalpostgres.ClaimUserReviewImportQuery,
+		ImportLease.Seconds(),
+	).Scan(&reviewImport.id, &reviewImport.userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for {
+		// List the next batch of pending rows
+		rows, err := i.pool.Query(
+			ctx,
+			internalpostgres.ListPendingUserReviewImportRowsQuery,
+			reviewImport.id,
+			BatchSize,
+		)
+		if err != nil {
+			return true, err
+		}
+		pendingRows, err := pgx.CollectRows(
+			rows,
+			func(row pgx.CollectableRow) (Row, error) {
+				pendingRow := Row{Status: RowStatusPending}
+				scanErr := row.Scan(
+					&pendingRow.Number,
+					&pendingRow.Title,
+					&pendingRow.Year,
+					&pendingRow.IMDbID,
+					&pendingRow.Rating,
+					&pendingRow.Review,
+					&pendingRow.Tags,
+					&pendingRow.WatchedOn,
+				)
+				return pendingRow, scanErr
+			},
+		)
+		if err != nil {
+			return true, err
+		}
+
+		// Complete the import once every row was processed
+		if len(pendingRows) == 0 {
+			if _, err = i.pool.Exec(ctx, internalpostgres.CompleteUserReviewImportQuery, reviewImport.id); err != nil {
+				return true, err
+			}
+			if i.logger != nil {
+				i.logger.Info(
+					"Processed review import",
+					slog.Int64("import_id", reviewImport.id),
+					slog.String("user_id", reviewImport.userID),
+				)
+			}
+			return true, nil
+		}
+
+		for index := range pendingRows {
+			if err = i.importRow(ctx, reviewImport, &pendingRows[index]); err != nil {
+				return true, err
+			}
+		}
+
+		// Keep the lease of the import
+		if _, err = i.pool.Exec(ctx, internalpostgres.TouchUserReviewImportQuery, reviewImport.id); err != nil {
+			return true, err
+		}
+	}
+}
+
+// importRow matches a pending row to a TMDB movie, creates its review and records the outcome
+//
+// Parameters:
+//
+// - ctx: the context
+// - reviewImport: the claimed review import
+// - row: the pending row
+//
+// Returns:
+//
+// - error: if there was a transient error matching the row, creating its review or recording the outcome
+func (i *Importer) importRow(ctx context.Context, reviewImport claimedImport, row *Row) error {
+	movieID, reason, err := i.match(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	status := RowStatusUnmatched
+	if movieID != nil {
+		status, reason, err = i.createReview(ctx, reviewImport.userID, *movieID, row)
+		if err != nil {
+			return err
+		}
+	}
+	if len(reason) > MaxReasonLength {
+		reason = reason[:MaxReasonLength]
+	}
+
+	_, err = i.pool.Exec(
+		ctx,
+		internalpostgres.SetUserReviewImportRowStatusQuery,
+		reviewImport.id,
+		row.Number,
+		status,
+		movieID,
+		reason,
+	)
+	return err
+}
+
+// match matches a row to a TMDB movie, by its IMDb ID if it has one and by its title and year otherwise
+//
+// Parameters:
+//
+// - ctx: the context
+// - row: the row to match
+//
+// Returns:
+//
+// - *int32: the ID of the matched TMDB movie, or nil if none matched
+// - string: the reason the row did not match
+// - error: if there was a transient error calling the TMDB API
+func (i *Importer) match(ctx context.Context, row *Row) (*int32, string, error) {
+	reason := ReasonNoMatch
+	if row.IMDbID != nil {
+		reason = ReasonNoIMDbMatch
+
+		// Find the movie by its IMDb ID
+		response, statusCode, err := i.tmdbClient.FindByExternalID(
+			ctx,
+			*row.IMDbID,
+			internaltmdb.ExternalSourceIMDb,
+			"",
+		)
+		if err != nil && !isRowStatusCode(statusCode) {
+			return nil, "", err
+		}
+		if err == nil && len(response.MovieResults) > 0 {
+			return &response.MovieResults[0].ID, "", nil
+		}
+
+		// Fall back to the title, if the export has it
+		if row.Title == "" {
+			return nil, reason, nil
+		}
+	}
+
+	// Search the movie by its title, released in the given year
+	var year int32
+	if row.Year != nil {
+		year = *row.Year
+	}
+	response, statusCode, err := i.tmdbClient.SearchMovies(ctx, row.Title, false, "", 0, 1, "", year)
+	if err != nil {
+		if isRowStatusCode(statusCode) {
+			return nil, reason, nil
+		}
+		return nil, "", err
+	}
+	if movieID, ok := matchMovie(response.Results, row.Title, row.Year); ok {
+		return &movieID, "", nil
+	}
+	return nil, reason, nil
+}
+
+// createReview creates the review of a matched row
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user that imports the row
+// - movieID: the ID of the matched TMDB movie
+// - row: the matched row
+//
+// Returns:
+//
+// - string: the status of the row
+// - string: the reason the row was skipped
+// - error: if there was a transient error creating the review
+func (i *Importer) createReview(ctx context.Context, userID string, movieID int32, row *Row) (string, string, error) {
+	err := i.creator.CreateImportedUserReview(ctx, userID, movieID, row)
+	if err == nil {
+		return RowStatusImported, "", nil
+	}
+
+	// Skip the rows rejected by the validations
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		switch connectErr.Code() {
+		case connect.CodeAlreadyExists:
+			return RowStatusSkipped, ReasonAlreadyReviewed, nil
+		case connect.CodeInvalidArgument:
+			return RowStatusSkipped, connectErr.Message(), nil
+		default:
+			return "", "", err
+		}
+	}
+
+	// Skip the rows rejected by the data exceptions and integrity constraints of the database
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return RowStatusSkipped, ReasonRejected, nil
+	}
+	return "", "", err
+}
+
+// isRowStatusCode checks if a TMDB API status code is caused by the row itself, so retrying it would fail again
+//
+// Parameters:
+//
+// - statusCode: the HTTP status code
+//
+// Returns:
+//
+// - bool: if the status code is a client error other than a rate limit
+func isRowStatusCode(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest &&
+		statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusTooManyRequests &&
+		statusCode != http.StatusUnauthorized
+}
+
+// normalizeTitle normalizes a movie title to be compared, keeping only its lower case letters and digits
+//
+// Parameters:
+//
+// - title: the movie title
+//
+// Returns:
+//
+// - string: the normalized title
+func normalizeTitle(title string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		},
+		title,
+	)
+}
+
+// matchMovie matches a title and year to one of the TMDB search results. The results whose title or original title
+// is the given title are matched, preferring the one released in the given year
+//
+// Parameters:
+//
+// - results: the TMDB search results, sorted by relevance
+// - title: the movie title
+// - year: the release year (optional)
+//
+// Returns:
+//
+// - int32: the ID of the matched TMDB movie
+// - bool: if a movie matched
+func matchMovie(results []gotmdbapi.SimpleMovie, title string, year *int32) (int32, bool) {
+	normalizedTitle := normalizeTitle(title)
+	if normalizedTitle == "" {
+		return 0, false
+	}
+
+	var (
+		matchedID int32
+		matched   bool
+	)
+	for _, result := range results {
+		if normalizeTitle(result.Title) != normalizedTitle && normalizeTitle(result.OriginalTitle) != normalizedTitle {
+			continue
+		}
+		if year == nil || strings.HasPrefix(result.ReleaseDate, strconv.Itoa(int(*year))) {
+			return result.ID, true
+		}
+		if !matched {
+			matchedID, matched = result.ID, true
+		}
+	}
+	return matchedID, matched
+}
@@ -0,0 +1,46 @@
+package imports
+
+import (
+	"testing"
+
+	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+)
+
+func TestMatchMovie(t *testing.T) {
+	results := []gotmdbapi.SimpleMovie{
+		{ID: 1, Title: "Heat", ReleaseDate: "1986-03-14"},
+		{ID: 2, Title: "Heat", ReleaseDate: "1995-12-15"},
+		{ID: 3, Title: "Amélie", OriginalTitle: "Le Fabuleux Destin d'Amélie Poulain", ReleaseDate: "2001-04-25"},
+		{ID: 4, Title: "Heat Wave", ReleaseDate: "1995-01-01"},
+	}
+
+	for _, test := range []struct {
+		name      string
+		title     string
+		year      *int32
+		wantID    int32
+		wantMatch bool
+	}{
+		{"title and year", "Heat", int32Pointer(1995), 2, true},
+		{"title without year", "Heat", nil, 1, true},
+		{"title with another year", "Heat", int32Pointer(2020), 1, true},
+		{"case and punctuation", "  HEAT! ", int32Pointer(1995), 2, true},
+		{"original title", "le fabuleux destin d’Amélie Poulain", nil, 3, true},
+		{"prefix of a title", "Hea", nil, 0, false},
+		{"unknown title", "Ronin", nil, 0, false},
+		{"title without letters", "!!!", nil, 0, false},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				id, matched := matchMovie(results, test.title, test.year)
+				if id != test.wantID || matched != test.wantMatch {
+					t.Errorf("matchMovie() = %d, %t, want %d, %t", id, matched, test.wantID, test.wantMatch)
+				}
+			},
+		)
+	}
+
+	if _, matched := matchMovie(nil, "Heat", nil); matched {
+		t.Error("matchMovie() matched without results")
+	}
+}
@@ -0,0 +1,307 @@
+package imports
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	// Row is a row of an uploaded export file. Its number is the number of the record in the file, counting the header
+	// as the first one, so it matches the row shown by a spreadsheet for files without multi-line reviews
+	Row struct {
+		Number    int32
+		Title     string
+		Year      *int32
+		IMDbID    *string
+		Rating    *int32
+		Review    string
+		Tags      []string
+		WatchedOn *time.Time
+		Status    string
+		Reason    *string
+	}
+
+	// recordParser parses a record of an export file into a row, returning the reason to skip it if it is invalid
+	recordParser func(columns map[string]int, record []string, row *Row) string
+)
+
+// Parse parses an uploaded export file. The invalid rows are returned already skipped, with the reason they cannot be
+// imported, so they show up in the report of the import
+//
+// Parameters:
+//
+// - source: the source of the export file
+// - reader: the export file reader
+//
+// Returns:
+//
+// - []Row: the parsed rows
+// - error: if the source is unknown, the file is not a valid CSV file or it is missing a required column
+func Parse(source string, reader io.Reader) ([]Row, error) {
+	var (
+		parseRecord     recordParser
+		requiredColumns []string
+	)
+	switch source {
+	case SourceLetterboxd:
+		parseRecord = parseLetterboxdRecord
+		requiredColumns = []string{LetterboxdTitleColumn, LetterboxdRatingColumn}
+	case SourceIMDb:
+		parseRecord = parseIMDbRecord
+		requiredColumns = []string{IMDbIDColumn, IMDbRatingColumn}
+	default:
+		return nil, ErrUnknownSource
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	// Read the header, mapping the lower case column names to their index
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for index, column := range header {
+		if index == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(column))] = index
+	}
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf(ErrMissingColumn, column)
+		}
+	}
+
+	var rows []Row
+	for number := int32(2); ; number++ {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		// Ignore the blank records
+		if !slices.ContainsFunc(
+			record, func(field string) bool {
+				return strings.TrimSpace(field) != ""
+			},
+		) {
+			continue
+		}
+
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf(ErrTooManyRows, MaxRows)
+		}
+
+		row := Row{
+			Number: number,
+			Tags:   []string{},
+			Status: RowStatusPending,
+		}
+		if reason := parseRecord(columns, record, &row); reason != "" {
+			row.Status = RowStatusSkipped
+			row.Reason = &reason
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return rows, nil
+}
+
+// CopyFromRows gets the copy from source of the rows of a review import, in the order of the
+// internalpostgres.UserReviewImportRowsColumns
+//
+// Parameters:
+//
+// - importID: the ID of the review import
+// - rows: the parsed rows
+//
+// Returns:
+//
+// - pgx.CopyFromSource: the copy from source of the rows
+func CopyFromRows(importID int64, rows []Row) pgx.CopyFromSource {
+	return pgx.CopyFromSlice(
+		len(rows),
+		func(index int) ([]any, error) {
+			row := rows[index]
+			return []any{
+				importID,
+				row.Number,
+				row.Title,
+				row.Year,
+				row.IMDbID,
+				row.Rating,
+				row.Review,
+				row.Tags,
+				row.WatchedOn,
+				row.Status,
+				row.Reason,
+			}, nil
+		},
+	)
+}
+
+// field gets the trimmed value of a column of a record, or an empty string if the record does not have it
+//
+// Parameters:
+//
+// - columns: the index of the columns by their lower case name
+// - record: the record
+// - column: the lower case column name
+//
+// Returns:
+//
+// - string: the trimmed value
+func field(columns map[string]int, record []string, column string) string {
+	index, ok := columns[column]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// parseYear parses an optional release year
+//
+// Parameters:
+//
+// - value: the release year value
+//
+// Returns:
+//
+// - *int32: the release year, or nil if the value is empty
+// - bool: if the value is empty or a valid release year
+func parseYear(value string) (*int32, bool) {
+	if value == "" {
+		return nil, true
+	}
+	year, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || year <= 0 {
+		return nil, false
+	}
+	parsedYear := int32(year)
+	return &parsedYear, true
+}
+
+// parseLetterboxdRecord parses a record of a Letterboxd export. The half-star ratings are doubled to match the
+// review ratings
+//
+// Parameters:
+//
+// - columns: the index of the columns by their lower case name
+// - record: the record
+// - row: the row to fill
+//
+// Returns:
+//
+// - string: the reason to skip the row, or an empty string if it is valid
+func parseLetterboxdRecord(columns map[string]int, record []string, row *Row) string {
+	row.Title = field(columns, record, LetterboxdTitleColumn)
+	if row.Title == "" {
+		return ReasonMissingTitle
+	}
+
+	year, ok := parseYear(field(columns, record, LetterboxdYearColumn))
+	if !ok {
+		return ReasonInvalidYear
+	}
+	row.Year = year
+
+	// Parse the star rating
+	ratingValue := field(columns, record, LetterboxdRatingColumn)
+	if ratingValue == "" {
+		return ReasonMissingRating
+	}
+	stars, err := strconv.ParseFloat(ratingValue, 64)
+	if err != nil {
+		return ReasonInvalidRating
+	}
+	scaledRating := stars * LetterboxdRatingScale
+	if scaledRating != math.Trunc(scaledRating) || scaledRating < MinRating || scaledRating > MaxRating {
+		return ReasonInvalidRating
+	}
+	rating := int32(scaledRating)
+	row.Rating = &rating
+
+	// Parse the watched date of the diary and reviews exports
+	if watchedDate := field(columns, record, LetterboxdWatchedDateColumn); watchedDate != "" {
+		watchedOn, parseErr := time.Parse(WatchedOnLayout, watchedDate)
+		if parseErr != nil {
+			return ReasonInvalidWatchedDate
+		}
+		row.WatchedOn = &watchedOn
+	}
+
+	row.Review = field(columns, record, LetterboxdReviewColumn)
+	for _, tag := range strings.Split(field(columns, record, LetterboxdTagsColumn), TagsSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			row.Tags = append(row.Tags, tag)
+		}
+	}
+	return ""
+}
+
+// parseIMDbRecord parses a record of an IMDb ratings export. The titles that are not movies are skipped
+//
+// Parameters:
+//
+// - columns: the index of the columns by their lower case name
+// - record: the record
+// - row: the row to fill
+//
+// Returns:
+//
+// - string: the reason to skip the row, or an empty string if it is valid
+func parseIMDbRecord(columns map[string]int, record []string, row *Row) string {
+	row.Title = field(columns, record, IMDbTitleColumn)
+	if imdbID := field(columns, record, IMDbIDColumn); imdbID != "" {
+		row.IMDbID = &imdbID
+	}
+	if row.IMDbID == nil && row.Title == "" {
+		return ReasonMissingTitle
+	}
+
+	year, ok := parseYear(field(columns, record, IMDbYearColumn))
+	if !ok {
+		return ReasonInvalidYear
+	}
+	row.Year = year
+
+	// Check the title type, if the export has it
+	if titleType := field(columns, record, IMDbTitleTypeColumn); titleType != "" {
+		if _, isMovie := IMDbMovieTitleTypes[strings.ToLower(titleType)]; !isMovie {
+			return ReasonNotAMovie
+		}
+	}
+
+	// Parse the rating
+	ratingValue := field(columns, record, IMDbRatingColumn)
+	if ratingValue == "" {
+		return ReasonMissingRating
+	}
+	rating, err := strconv.ParseInt(ratingValue, 10, 32)
+	if err != nil || rating < MinRating || rating > MaxRating {
+		return ReasonInvalidRating
+	}
+	parsedRating := int32(rating)
+	row.Rating = &parsedRating
+	return ""
+}
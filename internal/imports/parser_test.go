@@ -0,0 +1,245 @@
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// int32Pointer gets a pointer to the given value
+//
+// Parameters:
+//
+// - value: the value
+//
+// Returns:
+//
+// - *int32: the pointer to the value
+func int32Pointer(value int32) *int32 {
+	return &value
+}
+
+// parseTestRecord parses a record with the given parser, mapping the given header to the columns
+//
+// Parameters:
+//
+// - parse: the record parser
+// - header: the lower case column names
+// - record: the record
+//
+// Returns:
+//
+// - Row: the parsed row
+// - string: the reason to skip the row
+func parseTestRecord(parse recordParser, header []string, record []string) (Row, string) {
+	columns := make(map[string]int, len(header))
+	for index, column := range header {
+		columns[column] = index
+	}
+	row := Row{Tags: []string{}}
+	reason := parse(columns, record, &row)
+	return row, reason
+}
+
+func TestParseLetterboxdRecord(t *testing.T) {
+	header := []string{"name", "year", "rating", "watched date", "tags", "review"}
+	watchedOn := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		name       string
+		record     []string
+		wantReason string
+		wantRow    Row
+	}{
+		{
+			name:   "diary entry",
+			record: []string{" Heat ", "1995", "4.5", "2024-03-09", "rewatch, , crime ", "Great"},
+			wantRow: Row{
+				Title:     "Heat",
+				Year:      int32Pointer(1995),
+				Rating:    int32Pointer(9),
+				Review:    "Great",
+				Tags:      []string{"rewatch", "crime"},
+				WatchedOn: &watchedOn,
+			},
+		},
+		{
+			name:    "ratings entry without the optional columns",
+			record:  []string{"Heat", "", "0.5"},
+			wantRow: Row{Title: "Heat", Rating: int32Pointer(1), Tags: []string{}},
+		},
+		{name: "missing title", record: []string{"", "1995", "4"}, wantReason: ReasonMissingTitle},
+		{name: "invalid year", record: []string{"Heat", "199x", "4"}, wantReason: ReasonInvalidYear},
+		{name: "negative year", record: []string{"Heat", "-1", "4"}, wantReason: ReasonInvalidYear},
+		{name: "missing rating", record: []string{"Heat", "1995", ""}, wantReason: ReasonMissingRating},
+		{name: "invalid rating", record: []string{"Heat", "1995", "four"}, wantReason: ReasonInvalidRating},
+		{name: "quarter star rating", record: []string{"Heat", "1995", "4.25"}, wantReason: ReasonInvalidRating},
+		{name: "zero rating", record: []string{"Heat", "1995", "0"}, wantReason: ReasonInvalidRating},
+		{name: "rating above five stars", record: []string{"Heat", "1995", "5.5"}, wantReason: ReasonInvalidRating},
+		{
+			name:       "invalid watched date",
+			record:     []string{"Heat", "1995", "4", "09/03/2024"},
+			wantReason: ReasonInvalidWatchedDate,
+		},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				row, reason := parseTestRecord(parseLetterboxdRecord, header, test.record)
+				if reason != test.wantReason {
+					t.Fatalf("parseLetterboxdRecord() reason = %q, want %q", reason, test.wantReason)
+				}
+				if test.wantReason == "" && !reflect.DeepEqual(row, test.wantRow) {
+					t.Errorf("parseLetterboxdRecord() row = %+v, want %+v", row, test.wantRow)
+				}
+			},
+		)
+	}
+}
+
+func TestParseIMDbRecord(t *testing.T) {
+	header := []string{"const", "your rating", "title", "year", "title type"}
+	imdbID := "tt0113277"
+
+	for _, test := range []struct {
+		name       string
+		record     []string
+		wantReason string
+		wantRow    Row
+	}{
+		{
+			name:   "movie",
+			record: []string{"tt0113277", "9", "Heat", "1995", "Movie"},
+			wantRow: Row{
+				Title:  "Heat",
+				Year:   int32Pointer(1995),
+				IMDbID: &imdbID,
+				Rating: int32Pointer(9),
+				Tags:   []string{},
+			},
+		},
+		{
+			name:   "movie without title type",
+			record: []string{"tt0113277", "9", "Heat", "1995", ""},
+			wantRow: Row{
+				Title:  "Heat",
+				Year:   int32Pointer(1995),
+				IMDbID: &imdbID,
+				Rating: int32Pointer(9),
+				Tags:   []string{},
+			},
+		},
+		{
+			name:    "title without IMDb ID",
+			record:  []string{"", "10", "Heat", "", "TV Movie"},
+			wantRow: Row{Title: "Heat", Rating: int32Pointer(10), Tags: []string{}},
+		},
+		{name: "missing title and IMDb ID", record: []string{"", "9", "", "1995"}, wantReason: ReasonMissingTitle},
+		{name: "invalid year", record: []string{"tt0113277", "9", "Heat", "95a"}, wantReason: ReasonInvalidYear},
+		{
+			name:       "TV series",
+			record:     []string{"tt0903747", "10", "Breaking Bad", "2008", "TV Series"},
+			wantReason: ReasonNotAMovie,
+		},
+		{name: "missing rating", record: []string{"tt0113277", "", "Heat", "1995"}, wantReason: ReasonMissingRating},
+		{name: "decimal rating", record: []string{"tt0113277", "8.5", "Heat", "1995"}, wantReason: ReasonInvalidRating},
+		{name: "rating too high", record: []string{"tt0113277", "11", "Heat", "1995"}, wantReason: ReasonInvalidRating},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				row, reason := parseTestRecord(parseIMDbRecord, header, test.record)
+				if reason != test.wantReason {
+					t.Fatalf("parseIMDbRecord() reason = %q, want %q", reason, test.wantReason)
+				}
+				if test.wantReason == "" && !reflect.DeepEqual(row, test.wantRow) {
+					t.Errorf("parseIMDbRecord() row = %+v, want %+v", row, test.wantRow)
+				}
+			},
+		)
+	}
+}
+
+func TestParse(t *testing.T) {
+	file := "\ufeffName,Year,Rating\n" +
+		"Heat,1995,4.5\n" +
+		" , ,\n" +
+		"\"Multi\nline\",2001,3\n" +
+		"Heat,1995,6\n"
+
+	rows, err := Parse(SourceLetterboxd, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Parse() = %d rows, want 3", len(rows))
+	}
+
+	// The blank record is ignored, but still counted in the row numbers
+	for i, want := range []struct {
+		number int32
+		title  string
+		status string
+	}{
+		{2, "Heat", RowStatusPending},
+		{4, "Multi\nline", RowStatusPending},
+		{5, "Heat", RowStatusSkipped},
+	} {
+		if rows[i].Number != want.number || rows[i].Title != want.title || rows[i].Status != want.status {
+			t.Errorf(
+				"row %d = %d %q %s, want %d %q %s",
+				i,
+				rows[i].Number,
+				rows[i].Title,
+				rows[i].Status,
+				want.number,
+				want.title,
+				want.status,
+			)
+		}
+	}
+	if rows[2].Reason == nil || *rows[2].Reason != ReasonInvalidRating {
+		t.Errorf("skipped row reason = %v, want %q", rows[2].Reason, ReasonInvalidRating)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		source  string
+		file    string
+		wantErr error
+	}{
+		{"unknown source", "trakt", "Name,Rating\nHeat,4\n", ErrUnknownSource},
+		{"empty file", SourceLetterboxd, "", ErrEmptyFile},
+		{"header only", SourceIMDb, "Const,Your Rating\n", ErrEmptyFile},
+		{"blank rows only", SourceLetterboxd, "Name,Rating\n,\n", ErrEmptyFile},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if _, err := Parse(test.source, strings.NewReader(test.file)); !errors.Is(err, test.wantErr) {
+					t.Errorf("Parse() error = %v, want %v", err, test.wantErr)
+				}
+			},
+		)
+	}
+
+	_, err := Parse(SourceIMDb, strings.NewReader("Const,Title\ntt0113277,Heat\n"))
+	if want := fmt.Sprintf(ErrMissingColumn, IMDbRatingColumn); err == nil || err.Error() != want {
+		t.Errorf("Parse() error = %v, want %q", err, want)
+	}
+}
+
+func TestParseTooManyRows(t *testing.T) {
+	var file strings.Builder
+	file.WriteString("Const,Your Rating\n")
+	for range MaxRows + 1 {
+		file.WriteString("tt0113277,9\n")
+	}
+
+	_, err := Parse(SourceIMDb, strings.NewReader(file.String()))
+	if want := fmt.Sprintf(ErrTooManyRows, MaxRows); err == nil || err.Error() != want {
+		t.Errorf("Parse() error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,103 @@
+package moderation
+
+import (
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvProfanityWordlistPaths is the environment variable for the comma-separated paths of the profanity
+	// wordlists, one word or phrase per line. It can be empty to disable the profanity rule
+	EnvProfanityWordlistPaths = "MODERATION_PROFANITY_WORDLIST_PATHS"
+
+	// EnvMinLength is the environment variable for the minimum number of characters of a non-empty text
+	EnvMinLength = "MODERATION_MIN_LENGTH"
+
+	// EnvMaxLength is the environment variable for the maximum number of characters of a text
+	EnvMaxLength = "MODERATION_MAX_LENGTH"
+
+	// EnvMaxLinks is the environment variable for the maximum number of links a text can contain
+	EnvMaxLinks = "MODERATION_MAX_LINKS"
+
+	// EnvReportsHideThreshold is the environment variable for the number of distinct users that must report a
+	// review or a comment for it to be hidden automatically, pending moderation
+	EnvReportsHideThreshold = "MODERATION_REPORTS_HIDE_THRESHOLD"
+)
+
+const (
+	// MaxUppercaseRatio is the maximum ratio of uppercase letters to letters of a text before it is considered
+	// shouting spam
+	MaxUppercaseRatio = 0.7
+
+	// MinLettersForUppercaseCheck is the minimum number of letters of a text for the uppercase ratio to be checked
+	MinLettersForUppercaseCheck = 20
+
+	// MaxRepeatedCharacters is the maximum number of times a character can be repeated in a row
+	MaxRepeatedCharacters = 10
+
+	// MaxRepeatedWordRatio is the maximum ratio of occurrences of the most repeated word to words of a text
+	MaxRepeatedWordRatio = 0.4
+
+	// MinWordsForRepetitionCheck is the minimum number of words of a text for the repeated word ratio to be checked
+	MinWordsForRepetitionCheck = 10
+)
+
+const (
+	// ProfanityRuleName is the name of the profanity rule
+	ProfanityRuleName = "profanity"
+
+	// LinksRuleName is the name of the links rule
+	LinksRuleName = "links"
+
+	// SpamRuleName is the name of the spam rule
+	SpamRuleName = "spam"
+
+	// LengthRuleName is the name of the length rule
+	LengthRuleName = "length"
+
+	// RepetitionRuleName is the name of the repetition rule
+	RepetitionRuleName = "repetition"
+)
+
+var (
+	// ProfanityWordlistPaths are the comma-separated paths of the profanity wordlists
+	ProfanityWordlistPaths string
+
+	// MinLength is the minimum number of characters of a non-empty text
+	MinLength int
+
+	// MaxLength is the maximum number of characters of a text
+	MaxLength int
+
+	// MaxLinks is the maximum number of links a text can contain
+	MaxLinks int
+
+	// ReportsHideThreshold is the number of distinct users that must report a review or a comment for it to be
+	// hidden automatically, pending moderation
+	ReportsHideThreshold int
+)
+
+// Load loads the moderation constants
+func Load() {
+	// Get the profanity wordlist paths from the environment variable
+	if err := internalloader.Loader.LoadVariable(
+		EnvProfanityWordlistPaths,
+		&ProfanityWordlistPaths,
+	); err != nil {
+		panic(err)
+	}
+
+	// Get the length, links and reports limits from the environment variables
+	for env, dest := range map[string]*int{
+		EnvMinLength:            &MinLength,
+		EnvMaxLength:            &MaxLength,
+		EnvMaxLinks:             &MaxLinks,
+		EnvReportsHideThreshold: &ReportsHideThreshold,
+	} {
+		if err := internalloader.Loader.LoadIntVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
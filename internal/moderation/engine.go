@@ -0,0 +1,88 @@
+package moderation
+
+import (
+	"strings"
+)
+
+type (
+	// Engine is the moderation rules engine that checks the user generated texts before they are published
+	Engine struct {
+		rules []Rule
+	}
+)
+
+// NewEngine creates a new moderation engine with the given rules, checked in order
+//
+// Parameters:
+//
+// - rules: the moderation rules
+//
+// Returns:
+//
+// - *Engine: the moderation engine
+// - error: if there was an error creating the moderation engine
+func NewEngine(rules ...Rule) (*Engine, error) {
+	// Check if any rule is nil
+	for _, rule := range rules {
+		if rule == nil {
+			return nil, ErrNilRule
+		}
+	}
+
+	return &Engine{rules: rules}, nil
+}
+
+// NewDefaultEngine creates a new moderation engine with the default rules, configured from the loaded constants
+//
+// Returns:
+//
+// - *Engine: the moderation engine
+// - error: if there was an error creating the moderation engine
+func NewDefaultEngine() (*Engine, error) {
+	rules := []Rule{
+		NewLengthRule(MinLength, MaxLength),
+		NewLinksRule(MaxLinks),
+		NewSpamRule(),
+		NewRepetitionRule(),
+	}
+
+	// Load the profanity wordlists, if any
+	var paths []string
+	for _, path := range strings.Split(ProfanityWordlistPaths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) > 0 {
+		profanityRule, err := LoadProfanityRule(paths)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, profanityRule)
+	}
+
+	return NewEngine(rules...)
+}
+
+// Moderate checks the text against every rule of the engine
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - []Violation: the rule violations, empty if the text can be published
+func (e *Engine) Moderate(text string) []Violation {
+	if e == nil {
+		panic(ErrNilEngine)
+	}
+
+	var violations []Violation
+	for _, rule := range e.rules {
+		if violation := rule.Check(text); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+	return violations
+}
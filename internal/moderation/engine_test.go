@@ -0,0 +1,48 @@
+package moderation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewEngineRejectsNilRule(t *testing.T) {
+	if _, err := NewEngine(NewSpamRule(), nil); !errors.Is(err, ErrNilRule) {
+		t.Errorf("NewEngine() error = %v, want %v", err, ErrNilRule)
+	}
+}
+
+func TestEngineModerate(t *testing.T) {
+	engine, err := NewEngine(
+		NewLengthRule(5, 1000),
+		NewLinksRule(0),
+		NewProfanityRule([]string{"darn"}),
+	)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+
+	if violations := engine.Moderate("A great movie"); len(violations) != 0 {
+		t.Errorf("Moderate() = %v, want no violations", violations)
+	}
+
+	// The violations are returned in the order of the rules
+	violations := engine.Moderate("darn, see www.example.com")
+	if len(violations) != 2 {
+		t.Fatalf("Moderate() = %v, want 2 violations", violations)
+	}
+	if violations[0].Rule != LinksRuleName || violations[1].Rule != ProfanityRuleName {
+		t.Errorf("Moderate() rules = %q, %q, want %q, %q", violations[0].Rule, violations[1].Rule, LinksRuleName,
+			ProfanityRuleName)
+	}
+}
+
+func TestEngineModerateNilEngine(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrNilEngine {
+			t.Errorf("Moderate() panic = %v, want %v", r, ErrNilEngine)
+		}
+	}()
+
+	var engine *Engine
+	engine.Moderate("text")
+}
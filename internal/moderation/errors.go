@@ -0,0 +1,10 @@
+package moderation
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilEngine = errors.New("moderation engine is nil")
+	ErrNilRule   = errors.New("moderation rule is nil")
+)
@@ -0,0 +1,343 @@
+package moderation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	gofilesystem "github.com/ralvarezdev/go-loader/filesystem"
+)
+
+var (
+	// linkRegex matches the links and bare domains of a text
+	linkRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+\.(?:com|net|org|io|ru|xyz|info|biz|ly)\b`)
+
+	// wordRegex matches the words of a text
+	wordRegex = regexp.MustCompile(`[\p{L}\p{N}']+`)
+)
+
+type (
+	// Violation is a moderation rule violation of a text
+	Violation struct {
+		Rule   string
+		Reason string
+	}
+
+	// Rule is a moderation rule checked against a text before it is published
+	Rule interface {
+		Name() string
+		Check(text string) *Violation
+	}
+
+	// ProfanityRule flags the texts that contain a word or phrase of the profanity wordlists
+	ProfanityRule struct {
+		words   map[string]struct{}
+		phrases []string
+	}
+
+	// LinksRule flags the texts that contain too many links
+	LinksRule struct {
+		maxLinks int
+	}
+
+	// SpamRule flags the texts that look like spam, shouting in uppercase or repeating the same character
+	SpamRule struct{}
+
+	// LengthRule flags the non-empty texts that are too short or too long
+	LengthRule struct {
+		minLength int
+		maxLength int
+	}
+
+	// RepetitionRule flags the texts that repeat the same word over and over
+	RepetitionRule struct{}
+)
+
+// NewProfanityRule creates a new profanity rule from the given words and phrases, matched case-insensitively
+//
+// Parameters:
+//
+// - entries: the profane words and phrases
+//
+// Returns:
+//
+// - *ProfanityRule: the profanity rule
+func NewProfanityRule(entries []string) *ProfanityRule {
+	rule := &ProfanityRule{words: make(map[string]struct{})}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		if strings.ContainsAny(entry, " \t") {
+			rule.phrases = append(rule.phrases, entry)
+		} else {
+			rule.words[entry] = struct{}{}
+		}
+	}
+	return rule
+}
+
+// LoadProfanityRule creates a new profanity rule from the wordlist files at the given paths
+//
+// Parameters:
+//
+// - paths: the paths of the wordlist files, one word or phrase per line
+//
+// Returns:
+//
+// - *ProfanityRule: the profanity rule
+// - error: if there was an error reading a wordlist file
+func LoadProfanityRule(paths []string) (*ProfanityRule, error) {
+	var entries []string
+	for _, path := range paths {
+		content, err := gofilesystem.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			entries = append(entries, scanner.Text())
+		}
+		if err = scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return NewProfanityRule(entries), nil
+}
+
+// Name returns the name of the rule
+//
+// Returns:
+//
+// - string: the name of the rule
+func (p *ProfanityRule) Name() string {
+	return ProfanityRuleName
+}
+
+// Check checks the text against the rule
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - *Violation: the rule violation, nil if the text passes the rule
+func (p *ProfanityRule) Check(text string) *Violation {
+	lowerText := strings.ToLower(text)
+	for _, word := range wordRegex.FindAllString(lowerText, -1) {
+		if _, ok := p.words[word]; ok {
+			return &Violation{Rule: ProfanityRuleName, Reason: "contains profanity"}
+		}
+	}
+	for _, phrase := range p.phrases {
+		if strings.Contains(lowerText, phrase) {
+			return &Violation{Rule: ProfanityRuleName, Reason: "contains profanity"}
+		}
+	}
+	return nil
+}
+
+// NewLinksRule creates a new links rule
+//
+// Parameters:
+//
+// - maxLinks: the maximum number of links a text can contain
+//
+// Returns:
+//
+// - *LinksRule: the links rule
+func NewLinksRule(maxLinks int) *LinksRule {
+	return &LinksRule{maxLinks: maxLinks}
+}
+
+// Name returns the name of the rule
+//
+// Returns:
+//
+// - string: the name of the rule
+func (l *LinksRule) Name() string {
+	return LinksRuleName
+}
+
+// Check checks the text against the rule
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - *Violation: the rule violation, nil if the text passes the rule
+func (l *LinksRule) Check(text string) *Violation {
+	if linksCount := len(linkRegex.FindAllString(text, -1)); linksCount > l.maxLinks {
+		return &Violation{
+			Rule:   LinksRuleName,
+			Reason: fmt.Sprintf("contains %d links, at most %d are allowed", linksCount, l.maxLinks),
+		}
+	}
+	return nil
+}
+
+// NewSpamRule creates a new spam rule
+//
+// Returns:
+//
+// - *SpamRule: the spam rule
+func NewSpamRule() *SpamRule {
+	return &SpamRule{}
+}
+
+// Name returns the name of the rule
+//
+// Returns:
+//
+// - string: the name of the rule
+func (s *SpamRule) Name() string {
+	return SpamRuleName
+}
+
+// Check checks the text against the rule
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - *Violation: the rule violation, nil if the text passes the rule
+func (s *SpamRule) Check(text string) *Violation {
+	var (
+		letters, uppercase int
+		lastRune           rune
+		repeated           int
+	)
+	for _, r := range text {
+		// Count the repetitions of the same character in a row
+		if r == lastRune {
+			repeated++
+			if repeated > MaxRepeatedCharacters {
+				return &Violation{Rule: SpamRuleName, Reason: "repeats the same character too many times"}
+			}
+		} else {
+			lastRune, repeated = r, 1
+		}
+
+		// Count the letters and the uppercase letters
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				uppercase++
+			}
+		}
+	}
+
+	// Check the uppercase ratio
+	if letters >= MinLettersForUppercaseCheck && float64(uppercase)/float64(letters) > MaxUppercaseRatio {
+		return &Violation{Rule: SpamRuleName, Reason: "is mostly written in uppercase"}
+	}
+	return nil
+}
+
+// NewLengthRule creates a new length rule
+//
+// Parameters:
+//
+// - minLength: the minimum number of characters of a non-empty text
+// - maxLength: the maximum number of characters of a text
+//
+// Returns:
+//
+// - *LengthRule: the length rule
+func NewLengthRule(minLength, maxLength int) *LengthRule {
+	return &LengthRule{minLength: minLength, maxLength: maxLength}
+}
+
+// Name returns the name of the rule
+//
+// Returns:
+//
+// - string: the name of the rule
+func (l *LengthRule) Name() string {
+	return LengthRuleName
+}
+
+// Check checks the text against the rule
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - *Violation: the rule violation, nil if the text passes the rule
+func (l *LengthRule) Check(text string) *Violation {
+	length := utf8.RuneCountInString(strings.TrimSpace(text))
+	if length == 0 {
+		return nil
+	}
+	if length < l.minLength {
+		return &Violation{
+			Rule:   LengthRuleName,
+			Reason: fmt.Sprintf("is shorter than %d characters", l.minLength),
+		}
+	}
+	if length > l.maxLength {
+		return &Violation{
+			Rule:   LengthRuleName,
+			Reason: fmt.Sprintf("is longer than %d characters", l.maxLength),
+		}
+	}
+	return nil
+}
+
+// NewRepetitionRule creates a new repetition rule
+//
+// Returns:
+//
+// - *RepetitionRule: the repetition rule
+func NewRepetitionRule() *RepetitionRule {
+	return &RepetitionRule{}
+}
+
+// Name returns the name of the rule
+//
+// Returns:
+//
+// - string: the name of the rule
+func (r *RepetitionRule) Name() string {
+	return RepetitionRuleName
+}
+
+// Check checks the text against the rule
+//
+// Parameters:
+//
+// - text: the text to check
+//
+// Returns:
+//
+// - *Violation: the rule violation, nil if the text passes the rule
+func (r *RepetitionRule) Check(text string) *Violation {
+	words := wordRegex.FindAllString(strings.ToLower(text), -1)
+	if len(words) < MinWordsForRepetitionCheck {
+		return nil
+	}
+
+	// Count the occurrences of the most repeated word
+	var maxCount int
+	counts := make(map[string]int, len(words))
+	for _, word := range words {
+		counts[word]++
+		maxCount = max(maxCount, counts[word])
+	}
+	if float64(maxCount)/float64(len(words)) > MaxRepeatedWordRatio {
+		return &Violation{Rule: RepetitionRuleName, Reason: "repeats the same word too many times"}
+	}
+	return nil
+}
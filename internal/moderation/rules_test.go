@@ -0,0 +1,137 @@
+package moderation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// checkRule checks a text against a rule, failing the test if the violation is not the expected one
+//
+// Parameters:
+//
+// - t: the test
+// - rule: the rule to check
+// - text: the text to check
+// - wantViolation: whether the text should violate the rule
+func checkRule(t *testing.T, rule Rule, text string, wantViolation bool) {
+	t.Helper()
+
+	violation := rule.Check(text)
+	if (violation != nil) != wantViolation {
+		t.Fatalf("%s.Check(%q) = %v, want violation %t", rule.Name(), text, violation, wantViolation)
+	}
+	if violation != nil && violation.Rule != rule.Name() {
+		t.Errorf("%s.Check(%q) rule = %q, want %q", rule.Name(), text, violation.Rule, rule.Name())
+	}
+}
+
+func TestProfanityRule(t *testing.T) {
+	rule := NewProfanityRule([]string{"# comment", "", "  Darn ", "bad phrase"})
+
+	for _, test := range []struct {
+		text          string
+		wantViolation bool
+	}{
+		{"A great movie", false},
+		{"What a DARN movie", true},
+		{"darned is not darn", true},
+		{"It was darned good", false},
+		{"This is a Bad  phrase", false},
+		{"This is a bad phrase indeed", true},
+		{"comment", false},
+	} {
+		checkRule(t, rule, test.text, test.wantViolation)
+	}
+}
+
+func TestLoadProfanityRule(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "first.txt"), filepath.Join(dir, "second.txt")}
+	for i, content := range []string{"darn\n# comment\n", "bad phrase\n"} {
+		if err := os.WriteFile(paths[i], []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rule, err := LoadProfanityRule(paths)
+	if err != nil {
+		t.Fatalf("LoadProfanityRule() error = %v", err)
+	}
+	checkRule(t, rule, "darn it", true)
+	checkRule(t, rule, "such a bad phrase", true)
+	checkRule(t, rule, "a comment", false)
+
+	if _, err = LoadProfanityRule([]string{filepath.Join(dir, "missing.txt")}); err == nil {
+		t.Error("LoadProfanityRule() error = nil, want an error for a missing wordlist")
+	}
+}
+
+func TestLinksRule(t *testing.T) {
+	rule := NewLinksRule(1)
+
+	for _, test := range []struct {
+		text          string
+		wantViolation bool
+	}{
+		{"No links here. Really.", false},
+		{"See https://example.com/review", false},
+		{"See https://example.com and www.example.org", true},
+		{"Buy at cheap.xyz or cheap.ru", true},
+		{"Version 1.5 of the cut", false},
+	} {
+		checkRule(t, rule, test.text, test.wantViolation)
+	}
+}
+
+func TestSpamRule(t *testing.T) {
+	rule := NewSpamRule()
+
+	for _, test := range []struct {
+		text          string
+		wantViolation bool
+	}{
+		{"A calm and thoughtful review of the movie", false},
+		{"THIS MOVIE WAS THE BEST MOVIE EVER MADE", true},
+		{"OK FINE", false},
+		{"So good" + strings.Repeat("!", MaxRepeatedCharacters), false},
+		{"So good" + strings.Repeat("!", MaxRepeatedCharacters+1), true},
+	} {
+		checkRule(t, rule, test.text, test.wantViolation)
+	}
+}
+
+func TestLengthRule(t *testing.T) {
+	rule := NewLengthRule(5, 10)
+
+	for _, test := range []struct {
+		text          string
+		wantViolation bool
+	}{
+		{"", false},
+		{"   ", false},
+		{"abcd", true},
+		{"  abcde  ", false},
+		{"ñandúñandú", false},
+		{"abcdefghijk", true},
+	} {
+		checkRule(t, rule, test.text, test.wantViolation)
+	}
+}
+
+func TestRepetitionRule(t *testing.T) {
+	rule := NewRepetitionRule()
+
+	for _, test := range []struct {
+		text          string
+		wantViolation bool
+	}{
+		{"great great great", false},
+		{"one two three four five six seven eight nine ten", false},
+		{strings.Repeat("great ", 5) + "one two three four five", true},
+		{strings.Repeat("Great ", 4) + "one two three four five six", false},
+	} {
+		checkRule(t, rule, test.text, test.wantViolation)
+	}
+}
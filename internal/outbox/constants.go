@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvStream is the environment variable for the Redis stream the outbox events are published to
+	EnvStream = "OUTBOX_STREAM"
+
+	// EnvDeadLetterStream is the environment variable for the Redis stream the outbox events that could not be
+	// published are moved to
+	EnvDeadLetterStream = "OUTBOX_DEAD_LETTER_STREAM"
+
+	// EnvStreamMaxLength is the environment variable for the approximate maximum number of entries kept in the Redis
+	// streams
+	EnvStreamMaxLength = "OUTBOX_STREAM_MAX_LENGTH"
+
+	// EnvPollInterval is the environment variable for the interval between the outbox relay polls
+	EnvPollInterval = "OUTBOX_POLL_INTERVAL"
+
+	// EnvBatchSize is the environment variable for the number of outbox events published per batch
+	EnvBatchSize = "OUTBOX_BATCH_SIZE"
+
+	// EnvMaxAttempts is the environment variable for the number of publishing attempts of an outbox event before it
+	// is moved to the dead-letter stream
+	EnvMaxAttempts = "OUTBOX_MAX_ATTEMPTS"
+
+	// EnvRetryBaseDelay is the environment variable for the delay before retrying a failed outbox event, doubled on
+	// every failed attempt
+	EnvRetryBaseDelay = "OUTBOX_RETRY_BASE_DELAY"
+
+	// EnvRetryMaxDelay is the environment variable for the maximum delay before retrying a failed outbox event
+	EnvRetryMaxDelay = "OUTBOX_RETRY_MAX_DELAY"
+)
+
+const (
+	// EventUserReviewCreated is the event type of the created user reviews
+	EventUserReviewCreated = "user_review.created"
+
+	// EventUserReviewUpdated is the event type of the updated user reviews
+	EventUserReviewUpdated = "user_review.updated"
+
+	// EventUserReviewDeleted is the event type of the deleted user reviews
+	EventUserReviewDeleted = "user_review.deleted"
+
+	// EventUserReviewModerated is the event type of the user reviews whose moderation status was changed by the staff
+	// or by their reports
+	EventUserReviewModerated = "user_review.moderated"
+
+	// UserReviewAggregateID is the aggregate ID format of the user review events by user ID and movie ID
+	UserReviewAggregateID = "%s:%d"
+)
+
+const (
+	// StreamFieldEventID is the Redis stream entry field of the outbox event ID, which consumers use to deduplicate
+	// the events delivered more than once
+	StreamFieldEventID = "event_id"
+
+	// StreamFieldEventType is the Redis stream entry field of the event type
+	StreamFieldEventType = "event_type"
+
+	// StreamFieldAggregateID is the Redis stream entry field of the aggregate ID
+	StreamFieldAggregateID = "aggregate_id"
+
+	// StreamFieldPayload is the Redis stream entry field of the JSON payload
+	StreamFieldPayload = "payload"
+
+	// StreamFieldCreatedAt is the Redis stream entry field of the event creation time
+	StreamFieldCreatedAt = "created_at"
+
+	// StreamFieldAttempts is the dead-letter stream entry field of the number of failed publishing attempts
+	StreamFieldAttempts = "attempts"
+
+	// StreamFieldError is the dead-letter stream entry field of the last publishing error
+	StreamFieldError = "error"
+)
+
+var (
+	// EventTypes are the types of the published domain events
+	EventTypes = []string{
+		EventUserReviewCreated,
+		EventUserReviewUpdated,
+		EventUserReviewDeleted,
+		EventUserReviewModerated,
+	}
+)
+
+var (
+	// Stream is the Redis stream the outbox events are published to
+	Stream string
+
+	// DeadLetterStream is the Redis stream the outbox events that could not be published are moved to
+	DeadLetterStream string
+
+	// StreamMaxLength is the approximate maximum number of entries kept in the Redis streams
+	StreamMaxLength int
+
+	// PollInterval is the interval between the outbox relay polls
+	PollInterval time.Duration
+
+	// BatchSize is the number of outbox events published per batch
+	BatchSize int
+
+	// MaxAttempts is the number of publishing attempts of an outbox event before it is moved to the dead-letter
+	// stream
+	MaxAttempts int
+
+	// RetryBaseDelay is the delay before retrying a failed outbox event, doubled on every failed attempt
+	RetryBaseDelay time.Duration
+
+	// RetryMaxDelay is the maximum delay before retrying a failed outbox event
+	RetryMaxDelay time.Duration
+)
+
+// Load loads the outbox constants
+func Load() {
+	// Get the stream names from the environment variables
+	for env, dest := range map[string]*string{
+		EnvStream:           &Stream,
+		EnvDeadLetterStream: &DeadLetterStream,
+	} {
+		if err := internalloader.Loader.LoadVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the stream maximum length, the batch size and the maximum attempts from the environment variables
+	for env, dest := range map[string]*int{
+		EnvStreamMaxLength: &StreamMaxLength,
+		EnvBatchSize:       &BatchSize,
+		EnvMaxAttempts:     &MaxAttempts,
+	} {
+		if err := internalloader.Loader.LoadIntVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the poll interval and the retry delays from the environment variables
+	for env, dest := range map[string]*time.Duration{
+		EnvPollInterval:   &PollInterval,
+		EnvRetryBaseDelay: &RetryBaseDelay,
+		EnvRetryMaxDelay:  &RetryMaxDelay,
+	} {
+		if err := internalloader.Loader.LoadDurationVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
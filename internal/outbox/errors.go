@@ -0,0 +1,10 @@
+package outbox
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilRelay       = errors.New("outbox relay is nil")
+	ErrNilRedisClient = errors.New("redis client is nil")
+)
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// Event is a domain event written to the outbox
+	Event struct {
+		Type        string
+		AggregateID string
+		Payload     any
+	}
+
+	// UserReviewPayload is the payload of the user review events
+	UserReviewPayload struct {
+		UserID           string `json:"user_id"`
+		MovieID          int32  `json:"movie_id"`
+		Rating           int32  `json:"rating,omitempty"`
+		Version          int64  `json:"version,omitempty"`
+		ModerationStatus string `json:"moderation_status,omitempty"`
+		DeletedBy        string `json:"deleted_by,omitempty"`
+	}
+)
+
+// Write writes a domain event to the outbox in the given transaction, so the event is only published if the change
+// it describes is committed
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction of the change
+// - event: the domain event
+//
+// Returns:
+//
+// - error: if there was an error writing the event
+func Write(ctx context.Context, tx pgx.Tx, event Event) error {
+	_, err := tx.Exec(
+		ctx,
+		internalpostgres.InsertOutboxEventQuery,
+		event.Type,
+		event.AggregateID,
+		event.Payload,
+	)
+	return err
+}
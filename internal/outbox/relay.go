@@ -0,0 +1,307 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+	godatabasespgxpool "github.com/ralvarezdev/go-databases/sql/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// Relay publishes the outbox events to Redis Streams in the background. Events are marked as published only after
+	// Redis acknowledged them, so they are delivered at least once and consumers must deduplicate them by event ID
+	Relay struct {
+		pool        *pgxpool.Pool
+		redisClient *redis.Client
+		logger      *slog.Logger
+	}
+
+	// pendingEvent is an outbox event due to be published
+	pendingEvent struct {
+		id          int64
+		eventType   string
+		aggregateID string
+		payload     string
+		createdAt   time.Time
+		attempts    int
+	}
+)
+
+// NewRelay creates a new outbox relay
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - redisClient: the Redis client
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Relay: the outbox relay
+// - error: if there was an error creating the outbox relay
+func NewRelay(pool *pgxpool.Pool, redisClient *redis.Client, logger *slog.Logger) (*Relay, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the Redis client is nil
+	if redisClient == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	// Create the logger for the relay
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "outbox_relay"),
+		)
+	}
+
+	return &Relay{
+		pool:        pool,
+		redisClient: redisClient,
+		logger:      logger,
+	}, nil
+}
+
+// Run publishes the pending outbox events on every poll interval until the context is done. Full batches are
+// followed right away by the next batch, so a backlog is drained without waiting for the next poll
+//
+// Parameters:
+//
+// - ctx: the context
+func (r *Relay) Run(ctx context.Context) {
+	if r == nil {
+		panic(ErrNilRelay)
+	}
+
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		relayedCount, err := r.RelayBatch(ctx)
+		if err != nil && r.logger != nil {
+			r.logger.Error(
+				"Could not relay outbox events",
+				slog.String("error", err.Error()),
+			)
+		}
+		if err == nil && relayedCount == BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch publishes a batch of the pending outbox events. Events that fail to be published are retried with an
+// exponential backoff, and moved to the dead-letter stream after the maximum number of attempts
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - int: the number of processed events
+// - error: if there was an error reading or updating the outbox
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	if r == nil {
+		panic(ErrNilRelay)
+	}
+
+	var processedCount int
+	err := godatabasespgxpool.CreateTransaction(
+		ctx,
+		r.pool,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock a batch of the pending events
+			events, err := r.listPendingEvents(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			for _, event := range events {
+				if processErr := r.processEvent(ctx, tx, event); processErr != nil {
+					return processErr
+				}
+			}
+			processedCount = len(events)
+			return nil
+		},
+	)
+	return processedCount, err
+}
+
+// listPendingEvents lists and locks a batch of the outbox events due to be published
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+//
+// Returns:
+//
+// - []pendingEvent: the pending events
+// - error: if there was an error listing the events
+func (r *Relay) listPendingEvents(ctx context.Context, tx pgx.Tx) ([]pendingEvent, error) {
+	rows, err := tx.Query(ctx, internalpostgres.ListPendingOutboxEventsQuery, BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]pendingEvent, 0, BatchSize)
+	for rows.Next() {
+		var event pendingEvent
+		if scanErr := rows.Scan(
+			&event.id,
+			&event.eventType,
+			&event.aggregateID,
+			&event.payload,
+			&event.createdAt,
+			&event.attempts,
+		); scanErr != nil {
+			return nil, scanErr
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// processEvent publishes an outbox event and records the outcome of the attempt
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - event: the event to publish
+//
+// Returns:
+//
+// - error: if there was an error recording the outcome of the attempt
+func (r *Relay) processEvent(ctx context.Context, tx pgx.Tx, event pendingEvent) error {
+	// Publish the event
+	publishErr := r.publish(ctx, Stream, event, nil)
+	if publishErr == nil {
+		_, err := tx.Exec(ctx, internalpostgres.MarkOutboxEventPublishedQuery, event.id)
+		return err
+	}
+
+	if r.logger != nil {
+		r.logger.Warn(
+			"Could not publish outbox event",
+			slog.Int64("event_id", event.id),
+			slog.Int("attempts", event.attempts+1),
+			slog.String("error", publishErr.Error()),
+		)
+	}
+
+	// Retry the event later if it has attempts left
+	attempts := event.attempts + 1
+	if attempts < MaxAttempts {
+		_, err := tx.Exec(
+			ctx,
+			internalpostgres.MarkOutboxEventFailedQuery,
+			event.id,
+			publishErr.Error(),
+			retryDelay(attempts).Seconds(),
+		)
+		return err
+	}
+
+	// Move the event to the dead-letter stream, retrying it later if even that fails
+	if deadLetterErr := r.publish(
+		ctx,
+		DeadLetterStream,
+		event,
+		map[string]any{
+			StreamFieldAttempts: attempts,
+			StreamFieldError:    publishErr.Error(),
+		},
+	); deadLetterErr != nil {
+		_, err := tx.Exec(
+			ctx,
+			internalpostgres.MarkOutboxEventFailedQuery,
+			event.id,
+			deadLetterErr.Error(),
+			RetryMaxDelay.Seconds(),
+		)
+		return err
+	}
+
+	if r.logger != nil {
+		r.logger.Error(
+			"Moved outbox event to the dead-letter stream",
+			slog.Int64("event_id", event.id),
+			slog.String("event_type", event.eventType),
+		)
+	}
+	_, err := tx.Exec(ctx, internalpostgres.MarkOutboxEventDeadLetteredQuery, event.id, publishErr.Error())
+	return err
+}
+
+// publish adds an outbox event to a Redis stream
+//
+// Parameters:
+//
+// - ctx: the context
+// - stream: the Redis stream
+// - event: the event to publish
+// - extraFields: the fields to add to the stream entry besides the event fields (optional)
+//
+// Returns:
+//
+// - error: if there was an error adding the event to the stream
+func (r *Relay) publish(ctx context.Context, stream string, event pendingEvent, extraFields map[string]any) error {
+	values := map[string]any{
+		StreamFieldEventID:     strconv.FormatInt(event.id, 10),
+		StreamFieldEventType:   event.eventType,
+		StreamFieldAggregateID: event.aggregateID,
+		StreamFieldPayload:     event.payload,
+		StreamFieldCreatedAt:   event.createdAt.Format(time.RFC3339Nano),
+	}
+	for field, value := range extraFields {
+		values[field] = value
+	}
+
+	return r.redisClient.XAdd(
+		ctx,
+		&redis.XAddArgs{
+			Stream: stream,
+			MaxLen: int64(StreamMaxLength),
+			Approx: true,
+			Values: values,
+		},
+	).Err()
+}
+
+// retryDelay gets the delay before the next attempt of an outbox event, doubling the base delay on every failed
+// attempt up to the maximum delay
+//
+// Parameters:
+//
+// - attempts: the number of failed attempts
+//
+// Returns:
+//
+// - time.Duration: the delay before the next attempt
+func retryDelay(attempts int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempts && delay < RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, RetryMaxDelay)
+}
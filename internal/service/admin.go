@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	sqlmovies "github.com/ralvarezdev/sql-movies/go"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+)
+
+// writeAdminAuditLog writes an entry of the admin audit log in the given transaction, so the entry is only written
+// if the action succeeds
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction of the action
+// - actorUserID: the ID of the staff user that took the action
+// - action: the admin audit log action
+// - target: the target of the action
+// - details: the details of the action (optional)
+//
+// Returns:
+//
+// - error: if there was an error writing the entry
+func writeAdminAuditLog(
+	ctx context.Context,
+	tx pgx.Tx,
+	actorUserID string,
+	action string,
+	target string,
+	details map[string]any,
+) error {
+	if details == nil {
+		details = map[string]any{}
+	}
+	_, err := tx.Exec(
+		ctx,
+		internalpostgres.InsertAdminAuditLogQuery,
+		actorUserID,
+		action,
+		target,
+		details,
+	)
+	return err
+}
+
+// DeleteAnyUserMovieReview deletes any user's movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete any user movie review request
+//
+// Returns:
+//
+// - *v1.DeleteAnyUserMovieReviewResponse: the delete any user movie review response
+// - error: if there was an error deleting the user movie review
+func (s *Service) DeleteAnyUserMovieReview(
+	ctx context.Context,
+	request *v1.DeleteAnyUserMovieReviewRequest,
+) (*v1.DeleteAnyUserMovieReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	staffUserID, err := requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Archive the revisions of the movie review, with its current version, so they outlive the review
+			if _, execErr := tx.Exec(
+				ctx,
+				internalpostgres.ArchiveUserReviewRevisionsQuery,
+				request.GetUserId(),
+				request.GetId(),
+			); execErr != nil {
+				return execErr
+			}
+
+			// Call the stored procedure to delete the movie review in Postgres
+			var userReviewFound sql.NullBool
+			if queryErr := tx.QueryRow(
+				ctx,
+				sqlmovies.DeleteUserReviewProc,
+				request.GetUserId(),
+				request.GetId(),
+				nil,
+			).Scan(
+				&userReviewFound,
+			); queryErr != nil {
+				return queryErr
+			}
+
+			// Check if the user review was found
+			if !userReviewFound.Valid || !userReviewFound.Bool {
+				return ConnErrUserMovieReviewNotFound
+			}
+
+			// Write the deleted event to the outbox
+			aggregateID := fmt.Sprintf(internaloutbox.UserReviewAggregateID, request.GetUserId(), request.GetId())
+			if writeErr := internaloutbox.Write(
+				ctx,
+				tx,
+				internaloutbox.Event{
+					Type:        internaloutbox.EventUserReviewDeleted,
+					AggregateID: aggregateID,
+					Payload: internaloutbox.UserReviewPayload{
+						UserID:    request.GetUserId(),
+						MovieID:   request.GetId(),
+						DeletedBy: staffUserID,
+					},
+				},
+			); writeErr != nil {
+				return writeErr
+			}
+
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				staffUserID,
+				AdminActionDeleteUserReview,
+				fmt.Sprintf(UserReviewTarget, request.GetUserId(), request.GetId()),
+				map[string]any{"reason": request.GetReason()},
+			)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the cached activity feeds of the author's followers, so the deleted review is removed
+	s.invalidateFollowerFeeds(ctx, request.GetUserId())
+
+	return &v1.DeleteAnyUserMovieReviewResponse{}, nil
+}
+
+// PurgeMovieCache deletes the cached values of a movie and, if requested, every cached movie list
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the purge movie cache request
+//
+// Returns:
+//
+// - *v1.PurgeMovieCacheResponse: the purge movie cache response
+// - error: if there was an error purging the movie cache
+func (s *Service) PurgeMovieCache(
+	ctx context.Context,
+	request *v1.PurgeMovieCacheRequest,
+) (*v1.PurgeMovieCacheResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	staffUserID, err := requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete the cached values of the movie and the movie lists, if requested
+	patterns := []string{fmt.Sprintf(internalcache.MovieKeyPattern, request.GetId())}
+	if request.GetIncludeMovieLists() {
+		patterns = append(patterns, internalcache.MovieListsPattern)
+	}
+	var deletedKeysCount int64
+	for _, pattern := range patterns {
+		count, deleteErr := s.cache.DeleteMatching(ctx, pattern)
+		if deleteErr != nil {
+			panic(deleteErr)
+		}
+		deletedKeysCount += count
+	}
+
+	// Write the admin audit log entry
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				staffUserID,
+				AdminActionPurgeMovieCache,
+				fmt.Sprintf(MovieTarget, request.GetId()),
+				map[string]any{
+					"include_movie_lists": request.GetIncludeMovieLists(),
+					"deleted_keys_count":  deletedKeysCount,
+				},
+			)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.PurgeMovieCacheResponse{DeletedKeysCount: deletedKeysCount}, nil
+}
+
+// GetSystemStats gets the stats of the community content, the Postgres pool, the cache and the server runtime
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get system stats request
+//
+// Returns:
+//
+// - *v1.GetSystemStatsResponse: the get system stats response
+// - error: if there was an error getting the system stats
+func (s *Service) GetSystemStats(
+	ctx context.Context,
+	request *v1.GetSystemStatsRequest,
+) (*v1.GetSystemStatsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	if _, err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	response := &v1.GetSystemStatsResponse{}
+
+	// Count the user reviews of each moderation status
+	rows, err := s.pool.Query(ctx, internalpostgres.CountUserReviewsByModerationStatusQuery)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			status string
+			count  int64
+		)
+		if scanErr := rows.Scan(&status, &count); scanErr != nil {
+			panic(scanErr)
+		}
+		switch status {
+		case ModerationStatusPublished:
+			response.PublishedUserReviewsCount = count
+		case ModerationStatusPending:
+			response.PendingUserReviewsCount = count
+		case ModerationStatusRejected:
+			response.RejectedUserReviewsCount = count
+		}
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Count the comments, the votes and the open reports
+	if queryErr := s.pool.QueryRow(ctx, internalpostgres.GetSystemStatsQuery).Scan(
+		&response.CommentsCount,
+		&response.VotesCount,
+		&response.OpenReportsCount,
+	); queryErr != nil {
+		panic(queryErr)
+	}
+
+	// Get the cache size
+	response.CacheKeysCount, err = s.cache.Size(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Get the Postgres pool and the runtime stats
+	poolStat := s.pool.Stat()
+	response.PostgresTotalConns = poolStat.TotalConns()
+	response.PostgresIdleConns = poolStat.IdleConns()
+	response.PostgresAcquiredConns = poolStat.AcquiredConns()
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	response.Goroutines = int32(runtime.NumGoroutine())
+	response.HeapAllocBytes = memStats.HeapAlloc
+	response.Uptime = durationpb.New(time.Since(s.startedAt))
+
+	return response, nil
+}
+
+// ListAdminAuditLog lists a page of the admin audit log, from the newest to the oldest entry
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list admin audit log request
+//
+// Returns:
+//
+// - *v1.ListAdminAuditLogResponse: the list admin audit log response
+// - error: if there was an error listing the admin audit log
+func (s *Service) ListAdminAuditLog(
+	ctx context.Context,
+	request *v1.ListAdminAuditLogRequest,
+) (*v1.ListAdminAuditLogResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	if _, err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse the actor user ID filter
+	var actorUserID *int64
+	if request.ActorUserId != nil {
+		parsedActorUserID, parseErr := strconv.ParseInt(request.GetActorUserId(), 10, 64)
+		if parseErr != nil {
+			return nil, ConnErrInvalidUserID
+		}
+		actorUserID = &parsedActorUserID
+	}
+
+	// Decode the cursor
+	beforeCreatedAt, beforeID, err := DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the page size
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = AdminAuditLogDefaultPageSize
+	}
+	pageSize = min(pageSize, AdminAuditLogMaxPageSize)
+
+	// Query one more entry than the page size to know if there is a next page
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListAdminAuditLogQuery,
+		actorUserID,
+		beforeCreatedAt,
+		beforeID,
+		pageSize+1,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		entries          = make([]*v1.AdminAuditLogEntry, 0, pageSize)
+		entriesCreatedAt = make([]time.Time, 0, pageSize)
+	)
+	for rows.Next() {
+		var (
+			outID          int64
+			outActorUserID string
+			outAction      string
+			outTarget      string
+			outDetails     string
+			outCreatedAt   time.Time
+		)
+		if scanErr := rows.Scan(
+			&outID,
+			&outActorUserID,
+			&outAction,
+			&outTarget,
+			&outDetails,
+			&outCreatedAt,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		entries = append(
+			entries, &v1.AdminAuditLogEntry{
+				Id:          outID,
+				ActorUserId: outActorUserID,
+				Action:      outAction,
+				Target:      outTarget,
+				Details:     outDetails,
+				CreatedAt:   timestamppb.New(outCreatedAt),
+			},
+		)
+		entriesCreatedAt = append(entriesCreatedAt, outCreatedAt)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Get the cursor of the next page
+	var nextCursor string
+	if len(entries) > int(pageSize) {
+		entries = entries[:pageSize]
+		nextCursor = EncodeCursor(entriesCreatedAt[pageSize-1], entries[pageSize-1].GetId())
+	}
+
+	return &v1.ListAdminAuditLogResponse{
+		Entries:    entries,
+		NextCursor: nextCursor,
+	}, nil
+}
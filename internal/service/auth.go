@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalauthorization "github.com/ralvarezdev/connect-movies/internal/authorization"
+)
+
+// requireStaff checks if the authenticated user is an admin or a moderator. The authorization interceptor already
+// enforces the role of each procedure from its policy; these role checks repeat it inside the service, so a method
+// stays protected when it is called from another server or from a procedure with a looser policy
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - string: the ID of the staff user
+// - error: if the authenticated user is neither an admin nor a moderator
+func requireStaff(ctx context.Context) (string, error) {
+	if !internalauthorization.HasAnyRole(ctx, internalauthorization.StaffPolicy.Roles...) {
+		return "", ConnErrStaffRoleRequired
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return userID, nil
+}
+
+// requireAdmin checks if the authenticated user is an admin, like requireStaff does for the staff
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - string: the ID of the admin user
+// - error: if the authenticated user is not an admin
+func requireAdmin(ctx context.Context) (string, error) {
+	if !internalauthorization.HasAnyRole(ctx, internalauthorization.AdminPolicy.Roles...) {
+		return "", ConnErrAdminRoleRequired
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return userID, nil
+}
+
+// requireService checks if the caller is an internal service, like requireStaff does for the staff
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - error: if the caller is not an internal service
+func requireService(ctx context.Context) error {
+	if !internalauthorization.HasAnyRole(ctx, internalauthorization.ServicePolicy.Roles...) {
+		return ConnErrServiceRoleRequired
+	}
+	return nil
+}
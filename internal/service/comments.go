@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// validateCommentContent trims and validates the content of a user review comment
+//
+// Parameters:
+//
+// - content: the comment content
+//
+// Returns:
+//
+// - string: the trimmed comment content
+// - error: if the comment content is empty or too long
+func validateCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > CommentMaxLength {
+		return "", ConnErrInvalidCommentContent
+	}
+	return content, nil
+}
+
+// CreateUserMovieReviewComment comments on a user movie review, or replies to a top level comment on it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the create user movie review comment request
+//
+// Returns:
+//
+// - *v1.CreateUserMovieReviewCommentResponse: the create user movie review comment response
+// - error: if there was an error creating the user movie review comment
+func (s *Service) CreateUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.CreateUserMovieReviewCommentRequest,
+) (*v1.CreateUserMovieReviewCommentResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Validate the review user ID and the comment content
+	reviewUserID, err := parseUserID(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	content, err := validateCommentContent(request.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		outCommentID int64
+		outCreatedAt time.Time
+	)
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Check if the user review exists
+			var userReviewFound bool
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.UserReviewExistsQuery,
+				reviewUserID,
+				request.GetId(),
+			).Scan(&userReviewFound); queryErr != nil {
+				return queryErr
+			}
+			if !userReviewFound {
+				return ConnErrUserMovieReviewNotFound
+			}
+
+			// Check if the parent comment exists and is a top level comment on the same user review
+			if request.ParentId != nil {
+				var (
+					outParentID sql.NullInt64
+					outDeleted  bool
+				)
+				if queryErr := tx.QueryRow(
+					ctx,
+					internalpostgres.GetUserReviewCommentParentQuery,
+					request.GetParentId(),
+					reviewUserID,
+					request.GetId(),
+				).Scan(&outParentID, &outDeleted); queryErr != nil {
+					if errors.Is(queryErr, pgx.ErrNoRows) {
+						return ConnErrUserReviewCommentNotFound
+					}
+					return queryErr
+				}
+				if outDeleted {
+					return ConnErrUserReviewCommentNotFound
+				}
+				if outParentID.Valid {
+					return ConnErrCannotReplyToReply
+				}
+			}
+
+			// Store the username of the author, so the comment lists it after it is no longer cached
+			if storeErr := s.storeUsername(ctx, tx, userID); storeErr != nil {
+				return storeErr
+			}
+
+			// Insert the comment
+			return tx.QueryRow(
+				ctx,
+				internalpostgres.InsertUserReviewCommentQuery,
+				reviewUserID,
+				request.GetId(),
+				userID,
+				request.ParentId,
+				content,
+			).Scan(&outCommentID, &outCreatedAt)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateUserMovieReviewCommentResponse{
+		Comment: &v1.UserMovieReviewComment{
+			Id:        outCommentID,
+			UserId:    userID,
+			Username:  s.getUsernames(ctx, []string{userID})[userID],
+			ParentId:  request.ParentId,
+			Content:   content,
+			CreatedAt: timestamppb.New(outCreatedAt),
+		},
+	}, nil
+}
+
+// UpdateUserMovieReviewComment edits the content of a user movie review comment written by the authenticated user
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the update user movie review comment request
+//
+// Returns:
+//
+// - *v1.UpdateUserMovieReviewCommentResponse: the update user movie review comment response
+// - error: if there was an error updating the user movie review comment
+func (s *Service) UpdateUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.UpdateUserMovieReviewCommentRequest,
+) (*v1.UpdateUserMovieReviewCommentResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Validate the comment content
+	content, err := validateCommentContent(request.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the comment
+	var (
+		outParentID  sql.NullInt64
+		outCreatedAt time.Time
+		outUpdatedAt sql.NullTime
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.UpdateUserReviewCommentQuery,
+		request.GetCommentId(),
+		userID,
+		content,
+	).Scan(&outParentID, &outCreatedAt, &outUpdatedAt); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, ConnErrUserReviewCommentNotFound
+		}
+		panic(queryErr)
+	}
+
+	comment := &v1.UserMovieReviewComment{
+		Id:        request.GetCommentId(),
+		UserId:    userID,
+		Username:  s.getUsernames(ctx, []string{userID})[userID],
+		Content:   content,
+		CreatedAt: timestamppb.New(outCreatedAt),
+		UpdatedAt: MapToOptionalTimestamp(outUpdatedAt),
+	}
+	if outParentID.Valid {
+		comment.ParentId = &outParentID.Int64
+	}
+	return &v1.UpdateUserMovieReviewCommentResponse{Comment: comment}, nil
+}
+
+// DeleteUserMovieReviewComment soft deletes a user movie review comment written by the authenticated user, keeping
+// its replies visible
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete user movie review comment request
+//
+// Returns:
+//
+// - *v1.DeleteUserMovieReviewCommentResponse: the delete user movie review comment response
+// - error: if there was an error deleting the user movie review comment
+func (s *Service) DeleteUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.DeleteUserMovieReviewCommentRequest,
+) (*v1.DeleteUserMovieReviewCommentResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Soft delete the comment
+	commandTag, execErr := s.pool.Exec(
+		ctx,
+		internalpostgres.SoftDeleteUserReviewCommentQuery,
+		request.GetCommentId(),
+		userID,
+	)
+	if execErr != nil {
+		panic(execErr)
+	}
+
+	// Check if the comment was found
+	if commandTag.RowsAffected() == 0 {
+		return nil, ConnErrUserReviewCommentNotFound
+	}
+	return &v1.DeleteUserMovieReviewCommentResponse{}, nil
+}
+
+// ListUserMovieReviewComments lists a page of the top level comments on a user movie review, or of the replies to one
+// of them, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user movie review comments request
+//
+// Returns:
+//
+// - *v1.ListUserMovieReviewCommentsResponse: the list user movie review comments response
+// - error: if there was an error listing the user movie review comments
+func (s *Service) ListUserMovieReviewComments(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewCommentsRequest,
+) (*v1.ListUserMovieReviewCommentsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Validate the review user ID
+	reviewUserID, err := parseUserID(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the cursor
+	afterCreatedAt, afterID, err := DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the page size
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = CommentsDefaultPageSize
+	}
+	pageSize = min(pageSize, CommentsMaxPageSize)
+
+	// Query one more comment than the page size to know if there is a next page
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListUserReviewCommentsQuery,
+		reviewUserID,
+		request.GetId(),
+		request.ParentId,
+		afterCreatedAt,
+		afterID,
+		pageSize+1,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		comments          = make([]*v1.UserMovieReviewComment, 0, pageSize)
+		commentsCreatedAt = make([]time.Time, 0, pageSize)
+	)
+	for rows.Next() {
+		var (
+			outID           int64
+			outUserID       sql.NullString
+			outContent      string
+			outCreatedAt    time.Time
+			outUpdatedAt    sql.NullTime
+			outDeleted      bool
+			outRepliesCount int32
+		)
+		if scanErr := rows.Scan(
+			&outID,
+			&outUserID,
+			&outContent,
+			&outCreatedAt,
+			&outUpdatedAt,
+			&outDeleted,
+			&outRepliesCount,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+
+		// Hide the author and the content of deleted comments, and show the comments of erased users as written by a
+		// deleted user
+		comment := &v1.UserMovieReviewComment{
+			Id:           outID,
+			ParentId:     request.ParentId,
+			Deleted:      outDeleted,
+			RepliesCount: outRepliesCount,
+			CreatedAt:    timestamppb.New(outCreatedAt),
+		}
+		if !outUserID.Valid {
+			comment.Username = DeletedUserUsername
+		}
+		if !outDeleted {
+			comment.UserId = outUserID.String
+			comment.Content = outContent
+			comment.UpdatedAt = MapToOptionalTimestamp(outUpdatedAt)
+		}
+		comments = append(comments, comment)
+		commentsCreatedAt = append(commentsCreatedAt, outCreatedAt)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Get the cursor of the next page
+	var nextCursor string
+	if len(comments) > int(pageSize) {
+		comments = comments[:pageSize]
+		nextCursor = EncodeCursor(commentsCreatedAt[pageSize-1], comments[pageSize-1].GetId())
+	}
+
+	// Resolve the usernames of the comment authors
+	userIDs := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		if comment.GetUserId() != "" {
+			userIDs = append(userIDs, comment.GetUserId())
+		}
+	}
+	usernames := s.getUsernames(ctx, userIDs)
+	for _, comment := range comments {
+		if comment.GetUserId() != "" {
+			comment.Username = usernames[comment.GetUserId()]
+		}
+	}
+
+	return &v1.ListUserMovieReviewCommentsResponse{
+		Comments:   comments,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"time"
+)
+
+const (
+	// CommunityTrendingWeight is the weight of our own review activity when blending it into the TMDB trending
+	// ranking, relative to the TMDB ranking position
+	CommunityTrendingWeight = 0.5
+
+	// TrendingDayWindow is the duration of the day trending time window
+	TrendingDayWindow = 24 * time.Hour
+
+	// TrendingWeekWindow is the duration of the week trending time window
+	TrendingWeekWindow = 7 * 24 * time.Hour
+)
+
+const (
+	// UserReviewsPageSize is the number of user reviews returned per page
+	UserReviewsPageSize = 20
+)
+
+const (
+	// UserReviewMaxTags is the maximum number of tags of a user review
+	UserReviewMaxTags = 10
+
+	// UserReviewTagMaxLength is the maximum number of characters of a user review tag
+	UserReviewTagMaxLength = 32
+
+	// UserReviewMaxSpoilerSpans is the maximum number of inline spoilers of a user review
+	UserReviewMaxSpoilerSpans = 20
+
+	// WatchedOnMaxFutureSkew is how far in the future the watched on date of a user review can be, so users ahead of
+	// UTC can review a movie they watched today
+	WatchedOnMaxFutureSkew = 24 * time.Hour
+)
+
+const (
+	// VoteTypeLike is the user review vote type for likes
+	VoteTypeLike = "like"
+
+	// VoteTypeHelpful is the user review vote type for helpfulness votes
+	VoteTypeHelpful = "helpful"
+)
+
+const (
+	// CommentMaxLength is the maximum number of characters of a user review comment
+	CommentMaxLength = 2000
+
+	// CommentsDefaultPageSize is the number of user review comments returned per page when no page size is given
+	CommentsDefaultPageSize = 20
+
+	// CommentsMaxPageSize is the maximum number of user review comments returned per page
+	CommentsMaxPageSize = 50
+
+	// DeletedUserUsername is the username shown as the author of the comments of the users whose data was erased
+	DeletedUserUsername = "[deleted user]"
+)
+
+const (
+	// ModerationStatusPublished is the moderation status of the published user reviews
+	ModerationStatusPublished = "published"
+
+	// ModerationStatusPending is the moderation status of the flagged user reviews waiting for an admin decision
+	ModerationStatusPending = "pending"
+
+	// ModerationStatusRejected is the moderation status of the user reviews rejected by an admin
+	ModerationStatusRejected = "rejected"
+)
+
+const (
+	// ModerationActionFlagged is the moderation audit action of a user review flagged by the rules engine
+	ModerationActionFlagged = "flagged"
+
+	// ModerationActionApproved is the moderation audit action of a user review approved by an admin
+	ModerationActionApproved = "approved"
+
+	// ModerationActionRejected is the moderation audit action of a user review rejected by an admin
+	ModerationActionRejected = "rejected"
+)
+
+const (
+	// ModerationQueuePageSize is the number of pending user reviews returned per moderation queue page
+	ModerationQueuePageSize = 20
+)
+
+const (
+	// ReportStatusOpen is the status of the reports waiting for an admin decision
+	ReportStatusOpen = "open"
+
+	// ReportStatusDismissed is the status of the reports dismissed by an admin
+	ReportStatusDismissed = "dismissed"
+
+	// ReportStatusUpheld is the status of the reports upheld by an admin, removing the reported content
+	ReportStatusUpheld = "upheld"
+)
+
+const (
+	// ReportsPageSize is the number of open reports returned per page
+	ReportsPageSize = 20
+
+	// ReportsHiddenReason is the moderation reason of the user reviews hidden after being reported
+	ReportsHiddenReason = "reports: reported by %d users"
+)
+
+const (
+	// AdminActionDeleteUserReview is the admin audit log action of a user review deleted by a staff user
+	AdminActionDeleteUserReview = "delete_user_review"
+
+	// AdminActionApproveUserReview is the admin audit log action of a pending user review approved by a staff user
+	AdminActionApproveUserReview = "approve_user_review"
+
+	// AdminActionRejectUserReview is the admin audit log action of a pending user review rejected by a staff user
+	AdminActionRejectUserReview = "reject_user_review"
+
+	// AdminActionResolveContentReport is the admin audit log action of a content report resolved by a staff user
+	AdminActionResolveContentReport = "resolve_content_report"
+
+	// AdminActionPurgeMovieCache is the admin audit log action of a movie cache purged by a staff user
+	AdminActionPurgeMovieCache = "purge_movie_cache"
+
+	// AdminActionCreateWebhook is the admin audit log action of a webhook subscription created by an admin
+	AdminActionCreateWebhook = "create_webhook"
+
+	// AdminActionDeleteWebhook is the admin audit log action of a webhook subscription deleted by an admin
+	AdminActionDeleteWebhook = "delete_webhook"
+)
+
+const (
+	// UserReviewTarget is the admin audit log target format of a user review by user ID and movie ID
+	UserReviewTarget = "user_review:%s:%d"
+
+	// ContentReportTarget is the admin audit log target format of a content report by ID
+	ContentReportTarget = "content_report:%d"
+
+	// MovieTarget is the admin audit log target format of a movie by ID
+	MovieTarget = "movie:%d"
+
+	// WebhookTarget is the admin audit log target format of a webhook subscription by ID
+	WebhookTarget = "webhook:%d"
+)
+
+const (
+	// AdminAuditLogDefaultPageSize is the number of admin audit log entries returned per page when no page size is
+	// given
+	AdminAuditLogDefaultPageSize = 50
+
+	// AdminAuditLogMaxPageSize is the maximum number of admin audit log entries returned per page
+	AdminAuditLogMaxPageSize = 200
+)
+
+const (
+	// UserMovieListNameMaxLength is the maximum number of characters of the name of a user movie list
+	UserMovieListNameMaxLength = 100
+
+	// UserMovieListDescriptionMaxLength is the maximum number of characters of the description of a user movie list
+	UserMovieListDescriptionMaxLength = 1000
+
+	// UserMovieListItemNoteMaxLength is the maximum number of characters of the note of a user movie list item
+	UserMovieListItemNoteMaxLength = 500
+
+	// UserMovieListMaxItems is the maximum number of movies of a user movie list
+	UserMovieListMaxItems = 500
+
+	// UserMovieListItemsPageSize is the number of user movie list items returned per page
+	UserMovieListItemsPageSize = 20
+
+	// SimpleMoviesFetchConcurrency is the maximum number of movies fetched concurrently from the TMDB API when
+	// hydrating a listing
+	SimpleMoviesFetchConcurrency = 8
+)
+
+const (
+	// FollowsDefaultPageSize is the number of followers or followed users returned per page when no page size is given
+	FollowsDefaultPageSize = 50
+
+	// FollowsMaxPageSize is the maximum number of followers or followed users returned per page
+	FollowsMaxPageSize = 100
+
+	// FeedDefaultPageSize is the number of activity feed entries returned per page when no page size is given
+	FeedDefaultPageSize = 20
+
+	// FeedMaxPageSize is the maximum number of activity feed entries returned per page
+	FeedMaxPageSize = 50
+)
+
+const (
+	// FeedActivityKindReview is the activity feed kind of the published reviews without a watched on date
+	FeedActivityKindReview = "review"
+
+	// FeedActivityKindDiaryEntry is the activity feed kind of the published reviews with a watched on date
+	FeedActivityKindDiaryEntry = "diary_entry"
+
+	// FeedActivityKindListItem is the activity feed kind of the movies added to public lists
+	FeedActivityKindListItem = "list_item"
+)
+
+const (
+	// WebhookDeliveryStatusPending is the status of the webhook deliveries waiting for their next attempt
+	WebhookDeliveryStatusPending = "pending"
+
+	// WebhookDeliveryStatusSucceeded is the status of the webhook deliveries accepted by their receiver
+	WebhookDeliveryStatusSucceeded = "succeeded"
+
+	// WebhookDeliveryStatusFailed is the status of the webhook deliveries given up after the maximum number of
+	// attempts
+	WebhookDeliveryStatusFailed = "failed"
+
+	// WebhookDescriptionMaxLength is the maximum number of characters of the description of a webhook subscription
+	WebhookDescriptionMaxLength = 500
+
+	// WebhookDeliveriesDefaultPageSize is the number of webhook deliveries returned per page when no page size is
+	// given
+	WebhookDeliveriesDefaultPageSize = 50
+
+	// WebhookDeliveriesMaxPageSize is the maximum number of webhook deliveries returned per page
+	WebhookDeliveriesMaxPageSize = 200
+)
+
+const (
+	// UserDataExportStatusPending is the status of the data exports waiting to be built
+	UserDataExportStatusPending = "pending"
+
+	// UserDataExportStatusRunning is the status of the data exports being built
+	UserDataExportStatusRunning = "running"
+
+	// UserDataExportStatusSucceeded is the status of the data exports whose bundle is ready to be downloaded
+	UserDataExportStatusSucceeded = "succeeded"
+
+	// UserDataExportStatusFailed is the status of the data exports that could not be built
+	UserDataExportStatusFailed = "failed"
+)
+
+const (
+	// ReviewImportStatusPending is the status of the review imports waiting to be processed
+	ReviewImportStatusPending = "pending"
+
+	// ReviewImportStatusRunning is the status of the review imports being processed
+	ReviewImportStatusRunning = "running"
+
+	// ReviewImportStatusSucceeded is the status of the review imports whose rows were all processed
+	ReviewImportStatusSucceeded = "succeeded"
+)
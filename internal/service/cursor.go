@@ -0,0 +1,59 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// cursorFormat is the format of a decoded pagination cursor: the Unix time in microseconds and the ID of the last
+// returned row
+const cursorFormat = "%d:%d"
+
+// EncodeCursor encodes the position of the last returned row into an opaque pagination cursor
+//
+// Parameters:
+//
+// - createdAt: the creation time of the last returned row
+// - id: the ID of the last returned row
+//
+// Returns:
+//
+// - string: the pagination cursor
+func EncodeCursor(createdAt time.Time, id int64) string {
+	return base64.RawURLEncoding.EncodeToString(
+		fmt.Appendf(nil, cursorFormat, createdAt.UnixMicro(), id),
+	)
+}
+
+// DecodeCursor decodes an opaque pagination cursor into the position of the last returned row
+//
+// Parameters:
+//
+// - cursor: the pagination cursor, empty for the first page
+//
+// Returns:
+//
+// - sql.NullTime: the creation time of the last returned row, null for the first page
+// - int64: the ID of the last returned row
+// - error: if the cursor is invalid
+func DecodeCursor(cursor string) (sql.NullTime, int64, error) {
+	if cursor == "" {
+		return sql.NullTime{}, 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return sql.NullTime{}, 0, ConnErrInvalidCursor
+	}
+
+	var (
+		createdAt int64
+		id        int64
+	)
+	if _, err = fmt.Sscanf(string(decoded), cursorFormat, &createdAt, &id); err != nil {
+		return sql.NullTime{}, 0, ConnErrInvalidCursor
+	}
+	return sql.NullTime{Time: time.UnixMicro(createdAt), Valid: true}, id, nil
+}
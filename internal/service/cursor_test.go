@@ -0,0 +1,58 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 2, 10, 30, 15, 123456789, time.UTC)
+
+	decodedCreatedAt, decodedID, err := DecodeCursor(EncodeCursor(createdAt, 42))
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !decodedCreatedAt.Valid {
+		t.Fatal("DecodeCursor() creation time is null, want a valid time")
+	}
+
+	// The cursors keep the microseconds, the precision of the Postgres timestamps
+	if want := createdAt.Truncate(time.Microsecond); !decodedCreatedAt.Time.Equal(want) {
+		t.Errorf("DecodeCursor() creation time = %s, want %s", decodedCreatedAt.Time, want)
+	}
+	if decodedID != 42 {
+		t.Errorf("DecodeCursor() ID = %d, want 42", decodedID)
+	}
+}
+
+func TestDecodeCursorFirstPage(t *testing.T) {
+	createdAt, id, err := DecodeCursor("")
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if createdAt.Valid || id != 0 {
+		t.Errorf("DecodeCursor() = %v, %d, want a null time and 0", createdAt, id)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("1714645815123456:42"))},
+		{"missing ID", base64.RawURLEncoding.EncodeToString([]byte("1714645815123456"))},
+		{"not numbers", base64.RawURLEncoding.EncodeToString([]byte("yesterday:last"))},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if _, _, err := DecodeCursor(test.cursor); !errors.Is(err, ConnErrInvalidCursor) {
+					t.Errorf("DecodeCursor(%q) error = %v, want %v", test.cursor, err, ConnErrInvalidCursor)
+				}
+			},
+		)
+	}
+}
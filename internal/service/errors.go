@@ -13,6 +13,137 @@ var (
 	ConnErrUserMovieReviewAlreadyExists = connect.NewError(connect.CodeAlreadyExists, ErrUserMovieReviewAlreadyExists)
 	ErrUserMovieReviewNotFound          = errors.New("user movie review not found for the given user and movie")
 	ConnErrUserMovieReviewNotFound      = connect.NewError(connect.CodeNotFound, ErrUserMovieReviewNotFound)
+	ErrCollectionNotFound               = errors.New("collection not found for the given ID")
+	ConnErrCollectionNotFound           = connect.NewError(connect.CodeNotFound, ErrCollectionNotFound)
+	ErrCompanyNotFound                  = errors.New("company not found for the given ID")
+	ConnErrCompanyNotFound              = connect.NewError(connect.CodeNotFound, ErrCompanyNotFound)
+	ErrCannotVoteOwnUserMovieReview     = errors.New("users cannot vote on their own movie reviews")
+	ConnErrCannotVoteOwnUserMovieReview = connect.NewError(
+		connect.CodePermissionDenied,
+		ErrCannotVoteOwnUserMovieReview,
+	)
+	ErrUserMovieReviewVersionMismatch     = errors.New("user movie review was modified since the expected version")
+	ConnErrUserMovieReviewVersionMismatch = connect.NewError(
+		connect.CodeFailedPrecondition,
+		ErrUserMovieReviewVersionMismatch,
+	)
+)
+
+var (
+	ErrInvalidUserReviewTags     = errors.New("user review tags must not be empty, too long nor too many")
+	ConnErrInvalidUserReviewTags = connect.NewError(connect.CodeInvalidArgument, ErrInvalidUserReviewTags)
+	ErrInvalidSpoilerSpans       = errors.New("spoiler spans must be within the review, sorted and not overlap")
+	ConnErrInvalidSpoilerSpans   = connect.NewError(connect.CodeInvalidArgument, ErrInvalidSpoilerSpans)
+	ErrInvalidWatchedOn          = errors.New("watched on date must not be in the future")
+	ConnErrInvalidWatchedOn      = connect.NewError(connect.CodeInvalidArgument, ErrInvalidWatchedOn)
+)
+
+var (
+	ErrUserReviewCommentNotFound     = errors.New("user review comment not found for the given ID")
+	ConnErrUserReviewCommentNotFound = connect.NewError(connect.CodeNotFound, ErrUserReviewCommentNotFound)
+	ErrCannotReplyToReply            = errors.New("cannot reply to a reply, reply to the top level comment instead")
+	ConnErrCannotReplyToReply        = connect.NewError(connect.CodeInvalidArgument, ErrCannotReplyToReply)
+	ErrInvalidCommentContent         = errors.New("comment content must not be empty nor exceed the maximum length")
+	ConnErrInvalidCommentContent     = connect.NewError(connect.CodeInvalidArgument, ErrInvalidCommentContent)
+	ErrInvalidCursor                 = errors.New("invalid pagination cursor")
+	ConnErrInvalidCursor             = connect.NewError(connect.CodeInvalidArgument, ErrInvalidCursor)
+)
+
+var (
+	ErrPendingUserMovieReviewNotFound     = errors.New("pending user movie review not found for the given IDs")
+	ConnErrPendingUserMovieReviewNotFound = connect.NewError(
+		connect.CodeNotFound,
+		ErrPendingUserMovieReviewNotFound,
+	)
+	ErrStaffRoleRequired     = errors.New("the admin or moderator role is required to call this procedure")
+	ConnErrStaffRoleRequired = connect.NewError(connect.CodePermissionDenied, ErrStaffRoleRequired)
+	ErrInvalidUserID         = errors.New("invalid user ID")
+	ConnErrInvalidUserID     = connect.NewError(connect.CodeInvalidArgument, ErrInvalidUserID)
+)
+
+var (
+	ErrInvalidReportReason         = errors.New("invalid report reason")
+	ConnErrInvalidReportReason     = connect.NewError(connect.CodeInvalidArgument, ErrInvalidReportReason)
+	ErrInvalidReportResolution     = errors.New("invalid report resolution")
+	ConnErrInvalidReportResolution = connect.NewError(connect.CodeInvalidArgument, ErrInvalidReportResolution)
+	ErrCannotReportOwnContent      = errors.New("users cannot report their own reviews nor comments")
+	ConnErrCannotReportOwnContent  = connect.NewError(connect.CodePermissionDenied, ErrCannotReportOwnContent)
+	ErrContentReportNotFound       = errors.New("open content report not found for the given ID")
+	ConnErrContentReportNotFound   = connect.NewError(connect.CodeNotFound, ErrContentReportNotFound)
+)
+
+var (
+	ErrUserMovieListNotFound              = errors.New("user movie list not found for the given ID")
+	ConnErrUserMovieListNotFound          = connect.NewError(connect.CodeNotFound, ErrUserMovieListNotFound)
+	ErrUserMovieListAlreadyExists         = errors.New("user movie list already exists with the given name")
+	ConnErrUserMovieListAlreadyExists     = connect.NewError(connect.CodeAlreadyExists, ErrUserMovieListAlreadyExists)
+	ErrInvalidUserMovieList               = errors.New("invalid user movie list name or description")
+	ConnErrInvalidUserMovieList           = connect.NewError(connect.CodeInvalidArgument, ErrInvalidUserMovieList)
+	ErrUserMovieListItemAlreadyExists     = errors.New("movie is already in the user movie list")
+	ConnErrUserMovieListItemAlreadyExists = connect.NewError(
+		connect.CodeAlreadyExists,
+		ErrUserMovieListItemAlreadyExists,
+	)
+	ErrUserMovieListItemNotFound        = errors.New("movie not found in the user movie list")
+	ConnErrUserMovieListItemNotFound    = connect.NewError(connect.CodeNotFound, ErrUserMovieListItemNotFound)
+	ErrUserMovieListFull                = errors.New("user movie list reached its maximum number of movies")
+	ConnErrUserMovieListFull            = connect.NewError(connect.CodeResourceExhausted, ErrUserMovieListFull)
+	ErrInvalidUserMovieListItemNote     = errors.New("user movie list item note is too long")
+	ConnErrInvalidUserMovieListItemNote = connect.NewError(
+		connect.CodeInvalidArgument,
+		ErrInvalidUserMovieListItemNote,
+	)
+	ErrInvalidUserMovieListOrder     = errors.New("reordered movie IDs must be exactly the movies of the list")
+	ConnErrInvalidUserMovieListOrder = connect.NewError(connect.CodeInvalidArgument, ErrInvalidUserMovieListOrder)
+)
+
+var (
+	ErrCannotFollowSelf     = errors.New("users cannot follow themselves")
+	ConnErrCannotFollowSelf = connect.NewError(connect.CodeInvalidArgument, ErrCannotFollowSelf)
+)
+
+var (
+	ErrAdminRoleRequired               = errors.New("the admin role is required to call this procedure")
+	ConnErrAdminRoleRequired           = connect.NewError(connect.CodePermissionDenied, ErrAdminRoleRequired)
+	ErrInvalidWebhookURL               = errors.New("webhook URL must be an absolute http or https URL")
+	ConnErrInvalidWebhookURL           = connect.NewError(connect.CodeInvalidArgument, ErrInvalidWebhookURL)
+	ErrInvalidWebhookEventType         = errors.New("invalid or duplicated webhook event type")
+	ConnErrInvalidWebhookEventType     = connect.NewError(connect.CodeInvalidArgument, ErrInvalidWebhookEventType)
+	ErrWebhookSubscriptionNotFound     = errors.New("webhook subscription not found for the given ID")
+	ConnErrWebhookSubscriptionNotFound = connect.NewError(connect.CodeNotFound, ErrWebhookSubscriptionNotFound)
+	ErrInvalidWebhookDescription       = errors.New("webhook subscription description is too long")
+	ConnErrInvalidWebhookDescription   = connect.NewError(connect.CodeInvalidArgument, ErrInvalidWebhookDescription)
+)
+
+var (
+	ErrServiceRoleRequired          = errors.New("the service role is required to call this procedure")
+	ConnErrServiceRoleRequired      = connect.NewError(connect.CodePermissionDenied, ErrServiceRoleRequired)
+	ErrUserDataExportNotFound       = errors.New("data export not found for the given ID or it expired")
+	ConnErrUserDataExportNotFound   = connect.NewError(connect.CodeNotFound, ErrUserDataExportNotFound)
+	ErrDataDeletionNotConfirmed     = errors.New("the deletion of the account data must be confirmed")
+	ConnErrDataDeletionNotConfirmed = connect.NewError(connect.CodeFailedPrecondition, ErrDataDeletionNotConfirmed)
+)
+
+var (
+	ErrInvalidReviewImportSource      = errors.New("invalid review import source")
+	ConnErrInvalidReviewImportSource  = connect.NewError(connect.CodeInvalidArgument, ErrInvalidReviewImportSource)
+	ErrReviewImportFileTooLarge       = errors.New("review import file is too large")
+	ConnErrReviewImportFileTooLarge   = connect.NewError(connect.CodeInvalidArgument, ErrReviewImportFileTooLarge)
+	ErrUserReviewImportInProgress     = errors.New("a review import of the user is already in progress")
+	ConnErrUserReviewImportInProgress = connect.NewError(connect.CodeFailedPrecondition, ErrUserReviewImportInProgress)
+	ErrUserReviewImportNotFound       = errors.New("review import not found for the given ID")
+	ConnErrUserReviewImportNotFound   = connect.NewError(connect.CodeNotFound, ErrUserReviewImportNotFound)
+	ErrInvalidExportFormat            = errors.New("invalid export format")
+	ConnErrInvalidExportFormat        = connect.NewError(connect.CodeInvalidArgument, ErrInvalidExportFormat)
+	ErrInvalidExportContent           = errors.New("invalid export content")
+	ConnErrInvalidExportContent       = connect.NewError(connect.CodeInvalidArgument, ErrInvalidExportContent)
+)
+
+var (
+	ErrInvalidExternalIDSource     = errors.New("invalid external ID source")
+	ConnErrInvalidExternalIDSource = connect.NewError(connect.CodeInvalidArgument, ErrInvalidExternalIDSource)
+	ErrInvalidExternalID           = errors.New("external ID does not have the format of its source")
+	ConnErrInvalidExternalID       = connect.NewError(connect.CodeInvalidArgument, ErrInvalidExternalID)
 )
 
 var (
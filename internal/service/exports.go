@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalexports "github.com/ralvarezdev/connect-movies/internal/exports"
+)
+
+// ExportUserMovieData streams the reviews, the watchlist or the movie lists of the authenticated user as a CSV file
+// with the columns of the Letterboxd import format or as a JSON array. The first message carries the file name and
+// its content type, and the next ones the chunks of the file. The movies are enriched with their cached TMDB title
+// and release year
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the export user movie data request
+// - stream: the stream of the export user movie data responses
+//
+// Returns:
+//
+// - error: if there was an error exporting the data
+func (s *Service) ExportUserMovieData(
+	ctx context.Context,
+	request *v1.ExportUserMovieDataRequest,
+	stream *connect.ServerStream[v1.ExportUserMovieDataResponse],
+) error {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Map the format and the content of the export
+	format := MapToExportFormatCode(request.GetFormat())
+	if format == "" {
+		return ConnErrInvalidExportFormat
+	}
+	content := MapToExportContentCode(request.GetContent())
+	if content == "" {
+		return ConnErrInvalidExportContent
+	}
+
+	// Read the records of the user, keeping their movies to enrich them
+	var (
+		records []internalexports.Record
+		movies  []*internalexports.Movie
+		columns []string
+	)
+	if content == internalexports.ContentReviews {
+		columns = internalexports.ReviewColumns
+		reviews, listErr := s.userDataManager.ListReviews(ctx, userID)
+		if listErr != nil {
+			panic(listErr)
+		}
+		for _, review := range reviews {
+			record := &internalexports.ReviewRecord{
+				Movie:            internalexports.Movie{ID: review.MovieID},
+				Rating:           review.Rating,
+				Review:           review.Review,
+				ContainsSpoilers: review.ContainsSpoilers,
+				Tags:             review.Tags,
+				WatchedOn:        review.WatchedOn,
+				CreatedAt:        review.CreatedAt,
+			}
+			records = append(records, record)
+			movies = append(movies, &record.Movie)
+		}
+	} else {
+		columns = internalexports.ListColumns
+		if content == internalexports.ContentWatchlist {
+			columns = internalexports.WatchlistColumns
+		}
+		movieLists, listErr := s.userDataManager.ListMovieLists(ctx, userID)
+		if listErr != nil {
+			panic(listErr)
+		}
+		for _, movieList := range movieLists {
+			// Export the watchlist apart from the other lists
+			isWatchlist := movieList.Name == internalexports.WatchlistName
+			if isWatchlist != (content == internalexports.ContentWatchlist) {
+				continue
+			}
+
+			for i, item := range movieList.Items {
+				if isWatchlist {
+					record := &internalexports.WatchlistRecord{
+						Movie:   internalexports.Movie{ID: item.MovieID},
+						AddedAt: item.AddedAt,
+					}
+					records = append(records, record)
+					movies = append(movies, &record.Movie)
+					continue
+				}
+
+				record := &internalexports.ListRecord{
+					List:     movieList.Name,
+					Position: int32(i + 1),
+					Movie:    internalexports.Movie{ID: item.MovieID},
+					Note:     item.Note,
+					AddedAt:  item.AddedAt,
+				}
+				records = append(records, record)
+				movies = append(movies, &record.Movie)
+			}
+		}
+	}
+
+	// Send the file name and its content type
+	if err = stream.Send(
+		&v1.ExportUserMovieDataResponse{
+			FileName:    fmt.Sprintf(internalexports.FileNameFormat, content, format),
+			ContentType: internalexports.ContentTypes[format],
+		},
+	); err != nil {
+		return err
+	}
+
+	// Create the encoder of the records, writing to the stream in chunks
+	writer, err := internalexports.NewChunkWriter(
+		func(chunk []byte) error {
+			return stream.Send(&v1.ExportUserMovieDataResponse{Chunk: chunk})
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+	encoder, err := internalexports.NewEncoder(format, writer, columns)
+	if err != nil {
+		return err
+	}
+
+	// Enrich and encode the records in batches, so the first chunks are sent before every movie is fetched
+	for start := 0; start < len(records); start += internalexports.EnrichBatchSize {
+		end := min(start+internalexports.EnrichBatchSize, len(records))
+		movieIDs := make([]int32, 0, end-start)
+		for _, movie := range movies[start:end] {
+			movieIDs = append(movieIDs, movie.ID)
+		}
+		simpleMovies, getErr := s.getSimpleMovies(ctx, movieIDs, request.GetLanguage())
+		if getErr != nil {
+			panic(getErr)
+		}
+		for i, simpleMovie := range simpleMovies {
+			movies[start+i].Title = simpleMovie.GetTitle()
+			if simpleMovie.GetReleaseDate() != nil {
+				year := int32(simpleMovie.GetReleaseDate().AsTime().Year())
+				movies[start+i].Year = &year
+			}
+		}
+
+		for _, record := range records[start:end] {
+			if err = encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+	}
+	if err = encoder.Close(); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+)
+
+var (
+	// externalIDRegexps are the formats of the external IDs of each TMDB external source
+	externalIDRegexps = map[string]*regexp.Regexp{
+		internaltmdb.ExternalSourceIMDb:     regexp.MustCompile(`^tt\d{1,10}$`),
+		internaltmdb.ExternalSourceWikidata: regexp.MustCompile(`^Q\d{1,12}$`),
+	}
+)
+
+// normalizeExternalID trims an external ID and fixes the case of its prefix, so the same ID is always mapped once
+//
+// Parameters:
+//
+// - source: the TMDB external source of the ID
+// - externalID: the external ID to normalize
+//
+// Returns:
+//
+// - string: the normalized external ID
+// - bool: whether the external ID has the format of its source
+func normalizeExternalID(source, externalID string) (string, bool) {
+	externalID = strings.TrimSpace(externalID)
+	switch source {
+	case internaltmdb.ExternalSourceIMDb:
+		externalID = strings.ToLower(externalID)
+	case internaltmdb.ExternalSourceWikidata:
+		externalID = strings.ToUpper(externalID)
+	}
+
+	externalIDRegexp, ok := externalIDRegexps[source]
+	return externalID, ok && externalIDRegexp.MatchString(externalID)
+}
+
+// FindMovieByExternalId finds the TMDB movie of an IMDb or Wikidata ID. The mapping table is checked first, and the
+// IDs found on TMDB are stored on it, so the repeated lookups skip TMDB
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the find movie by external ID request
+//
+// Returns:
+//
+// - *v1.FindMovieByExternalIdResponse: the find movie by external ID response
+// - error: if there was an error finding the movie
+func (s *Service) FindMovieByExternalId(
+	ctx context.Context,
+	request *v1.FindMovieByExternalIdRequest,
+) (*v1.FindMovieByExternalIdResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Map the source and validate the external ID
+	source := MapToExternalIDSourceCode(request.GetSource())
+	if source == "" {
+		return nil, ConnErrInvalidExternalIDSource
+	}
+	externalID, ok := normalizeExternalID(source, request.GetExternalId())
+	if !ok {
+		return nil, ConnErrInvalidExternalID
+	}
+
+	// Check if the external ID is already mapped
+	var movieID int32
+	if err := s.pool.QueryRow(
+		ctx,
+		internalpostgres.GetMovieIDByExternalIDQuery,
+		source,
+		externalID,
+	).Scan(&movieID); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			panic(err)
+		}
+
+		// Call TMDB API to find the movie by its external ID
+		apiResponse, statusCode, findErr := s.tmdbClient.FindByExternalID(ctx, externalID, source, "")
+		if findErr != nil {
+			if statusCode == http.StatusNotFound {
+				return nil, ConnErrMovieNotFound
+			}
+			panic(findErr)
+		}
+		if len(apiResponse.MovieResults) == 0 {
+			return nil, ConnErrMovieNotFound
+		}
+		movieID = apiResponse.MovieResults[0].ID
+
+		// Map the external ID to the movie
+		if _, err = s.pool.Exec(
+			ctx,
+			internalpostgres.UpsertMovieExternalIDQuery,
+			source,
+			externalID,
+			movieID,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the movie from the cache or the TMDB API
+	movie, err := s.getSimpleMovie(ctx, movieID, request.GetLanguage())
+	if err != nil {
+		panic(err)
+	}
+	return &v1.FindMovieByExternalIdResponse{Movie: movie}, nil
+}
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// parseFollowedUserID parses the ID of the user to follow or unfollow, and checks it is not the authenticated user
+//
+// Parameters:
+//
+// - userID: the ID of the authenticated user
+// - followedUserID: the ID of the user to follow or unfollow
+//
+// Returns:
+//
+// - int64: the parsed ID of the user to follow or unfollow
+// - error: if the ID is invalid or it is the ID of the authenticated user
+func parseFollowedUserID(userID, followedUserID string) (int64, error) {
+	sameUser, err := isSameUser(userID, followedUserID)
+	if err != nil {
+		return 0, err
+	}
+	if sameUser {
+		return 0, ConnErrCannotFollowSelf
+	}
+	parsedFollowedUserID, _ := strconv.ParseInt(followedUserID, 10, 64)
+	return parsedFollowedUserID, nil
+}
+
+// invalidateFollowerFeeds invalidates the cached activity feeds of the followers of a user, so the activity of the
+// user that was removed or hidden is no longer shown to them
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user whose activity changed
+func (s *Service) invalidateFollowerFeeds(ctx context.Context, userID string) {
+	rows, err := s.pool.Query(ctx, internalpostgres.ListUserFollowerIDsQuery, userID)
+	if err != nil {
+		panic(err)
+	}
+	followerIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		panic(err)
+	}
+	for _, followerID := range followerIDs {
+		s.cache.Invalidate(ctx, fmt.Sprintf(internalcache.FeedPattern, followerID))
+	}
+}
+
+// FollowUser makes the authenticated user follow another user. Following an already followed user does nothing
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the follow user request
+//
+// Returns:
+//
+// - *v1.FollowUserResponse: the follow user response
+// - error: if there was an error following the user
+func (s *Service) FollowUser(
+	ctx context.Context,
+	request *v1.FollowUserRequest,
+) (*v1.FollowUserResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Parse the ID of the user to follow
+	followedUserID, err := parseFollowedUserID(userID, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert the follow
+	if _, execErr := s.pool.Exec(ctx, internalpostgres.InsertUserFollowQuery, userID, followedUserID); execErr != nil {
+		panic(execErr)
+	}
+
+	// Invalidate the cached activity feed of the user, so the followed user activity shows up
+	s.cache.Invalidate(ctx, fmt.Sprintf(internalcache.FeedPattern, userID))
+
+	return &v1.FollowUserResponse{}, nil
+}
+
+// UnfollowUser makes the authenticated user unfollow another user. Unfollowing a not followed user does nothing
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the unfollow user request
+//
+// Returns:
+//
+// - *v1.UnfollowUserResponse: the unfollow user response
+// - error: if there was an error unfollowing the user
+func (s *Service) UnfollowUser(
+	ctx context.Context,
+	request *v1.UnfollowUserRequest,
+) (*v1.UnfollowUserResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Parse the ID of the user to unfollow
+	followedUserID, err := parseFollowedUserID(userID, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete the follow
+	if _, execErr := s.pool.Exec(ctx, internalpostgres.DeleteUserFollowQuery, userID, followedUserID); execErr != nil {
+		panic(execErr)
+	}
+
+	// Invalidate the cached activity feed of the user, so the unfollowed user activity is removed
+	s.cache.Invalidate(ctx, fmt.Sprintf(internalcache.FeedPattern, userID))
+
+	return &v1.UnfollowUserResponse{}, nil
+}
+
+// listFollows lists a page of the followers of a user or of the users followed by a user
+//
+// Parameters:
+//
+// - ctx: the context
+// - query: the query of the followers or the followed users
+// - userID: the ID of the user, empty for the authenticated user
+// - cursor: the pagination cursor
+// - pageSize: the requested page size
+//
+// Returns:
+//
+// - []*v1.FollowUser: the users of the page
+// - string: the cursor of the next page, empty if there is no next page
+// - error: if the user ID or the cursor are invalid
+func (s *Service) listFollows(
+	ctx context.Context,
+	query string,
+	userID string,
+	cursor string,
+	pageSize int32,
+) ([]*v1.FollowUser, string, error) {
+	// Default to the authenticated user
+	if userID == "" {
+		var err error
+		userID, err = goauthjwtclaims.GetSubject(ctx)
+		if err != nil {
+			panic(err)
+		}
+	} else if _, err := strconv.ParseInt(userID, 10, 64); err != nil {
+		return nil, "", ConnErrInvalidUserID
+	}
+
+	// Decode the cursor
+	beforeCreatedAt, beforeUserID, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Get the page size
+	if pageSize <= 0 {
+		pageSize = FollowsDefaultPageSize
+	}
+	pageSize = min(pageSize, FollowsMaxPageSize)
+
+	// Query one more user than the page size to know if there is a next page
+	rows, err := s.pool.Query(ctx, query, userID, beforeCreatedAt, beforeUserID, pageSize+1)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		users         = make([]*v1.FollowUser, 0, pageSize)
+		userIDs       = make([]int64, 0, pageSize)
+		usersFollowed = make([]time.Time, 0, pageSize)
+	)
+	for rows.Next() {
+		var (
+			outUserID    int64
+			outCreatedAt time.Time
+		)
+		if scanErr := rows.Scan(&outUserID, &outCreatedAt); scanErr != nil {
+			panic(scanErr)
+		}
+		users = append(
+			users, &v1.FollowUser{
+				UserId:     strconv.FormatInt(outUserID, 10),
+				FollowedAt: timestamppb.New(outCreatedAt),
+			},
+		)
+		userIDs = append(userIDs, outUserID)
+		usersFollowed = append(usersFollowed, outCreatedAt)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Get the cursor of the next page
+	var nextCursor string
+	if len(users) > int(pageSize) {
+		users = users[:pageSize]
+		nextCursor = EncodeCursor(usersFollowed[pageSize-1], userIDs[pageSize-1])
+	}
+
+	// Resolve the usernames
+	usernameUserIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		usernameUserIDs = append(usernameUserIDs, user.GetUserId())
+	}
+	usernames := s.getUsernames(ctx, usernameUserIDs)
+	for _, user := range users {
+		user.Username = usernames[user.GetUserId()]
+	}
+
+	return users, nextCursor, nil
+}
+
+// ListUserFollowers lists a page of the followers of a user, from the newest to the oldest follower
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user followers request
+//
+// Returns:
+//
+// - *v1.ListUserFollowersResponse: the list user followers response
+// - error: if there was an error listing the user followers
+func (s *Service) ListUserFollowers(
+	ctx context.Context,
+	request *v1.ListUserFollowersRequest,
+) (*v1.ListUserFollowersResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	users, nextCursor, err := s.listFollows(
+		ctx,
+		internalpostgres.ListUserFollowersQuery,
+		request.GetUserId(),
+		request.GetCursor(),
+		request.GetPageSize(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListUserFollowersResponse{
+		Users:      users,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ListUserFollowing lists a page of the users followed by a user, from the newest to the oldest follow
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user following request
+//
+// Returns:
+//
+// - *v1.ListUserFollowingResponse: the list user following response
+// - error: if there was an error listing the followed users
+func (s *Service) ListUserFollowing(
+	ctx context.Context,
+	request *v1.ListUserFollowingRequest,
+) (*v1.ListUserFollowingResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	users, nextCursor, err := s.listFollows(
+		ctx,
+		internalpostgres.ListUserFollowingQuery,
+		request.GetUserId(),
+		request.GetCursor(),
+		request.GetPageSize(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ListUserFollowingResponse{
+		Users:      users,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetActivityFeed gets a page of the activity of the users followed by the authenticated user, from the newest to
+// the oldest activity. The feed is built on read from the reviews and the public lists of the followed users, and
+// each page is cached for a short time
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get activity feed request
+//
+// Returns:
+//
+// - *v1.GetActivityFeedResponse: the get activity feed response
+// - error: if there was an error getting the activity feed
+func (s *Service) GetActivityFeed(
+	ctx context.Context,
+	request *v1.GetActivityFeedRequest,
+) (*v1.GetActivityFeedResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Decode the cursor
+	beforeOccurredAt, beforeActivityID, err := DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the page size
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = FeedDefaultPageSize
+	}
+	pageSize = min(pageSize, FeedMaxPageSize)
+
+	return getCachedOrFetch(
+		ctx,
+		s.cache,
+		fmt.Sprintf(internalcache.FeedKey, userID, request.GetLanguage(), pageSize, request.GetCursor()),
+		internalcache.FeedTTL,
+		&v1.GetActivityFeedResponse{},
+		func() *v1.GetActivityFeedResponse {
+			return s.fetchActivityFeed(
+				ctx,
+				userID,
+				beforeOccurredAt,
+				beforeActivityID,
+				pageSize,
+				request.GetLanguage(),
+			)
+		},
+	), nil
+}
+
+// fetchActivityFeed fetches a page of the activity of the users followed by a user from Postgres, hydrating it with
+// the movies and the usernames
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+// - beforeOccurredAt: the time of the last activity of the previous page, null for the first page
+// - beforeActivityID: the ID of the last activity of the previous page
+// - pageSize: the page size
+// - language: the language of the movies
+//
+// Returns:
+//
+// - *v1.GetActivityFeedResponse: the activity feed page
+func (s *Service) fetchActivityFeed(
+	ctx context.Context,
+	userID string,
+	beforeOccurredAt sql.NullTime,
+	beforeActivityID int64,
+	pageSize int32,
+	language string,
+) *v1.GetActivityFeedResponse {
+	// Query one more activity than the page size to know if there is a next page
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListActivityFeedQuery,
+		userID,
+		beforeOccurredAt,
+		beforeActivityID,
+		pageSize+1,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		activities  = make([]*v1.FeedActivity, 0, pageSize)
+		movieIDs    = make([]int32, 0, pageSize)
+		occurredAts = make([]time.Time, 0, pageSize)
+	)
+	for rows.Next() {
+		var (
+			outKind       string
+			outUserID     string
+			outMovieID    int32
+			outListID     sql.NullInt64
+			outListName   sql.NullString
+			outRating     sql.NullInt32
+			outWatchedOn  sql.NullTime
+			outOccurredAt time.Time
+			outActivityID int64
+		)
+		if scanErr := rows.Scan(
+			&outKind,
+			&outUserID,
+			&outMovieID,
+			&outListID,
+			&outListName,
+			&outRating,
+			&outWatchedOn,
+			&outOccurredAt,
+			&outActivityID,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		activities = append(
+			activities, &v1.FeedActivity{
+				Id:         outActivityID,
+				Type:       MapToFeedActivityType(outKind),
+				UserId:     outUserID,
+				ListId:     outListID.Int64,
+				ListName:   outListName.String,
+				Rating:     outRating.Int32,
+				WatchedOn:  MapToOptionalDate(outWatchedOn),
+				OccurredAt: timestamppb.New(outOccurredAt),
+			},
+		)
+		movieIDs = append(movieIDs, outMovieID)
+		occurredAts = append(occurredAts, outOccurredAt)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Get the cursor of the next page
+	var nextCursor string
+	if len(activities) > int(pageSize) {
+		activities = activities[:pageSize]
+		movieIDs = movieIDs[:pageSize]
+		nextCursor = EncodeCursor(occurredAts[pageSize-1], activities[pageSize-1].GetId())
+	}
+
+	// Hydrate the activities with their movies
+	movies, err := s.getSimpleMovies(ctx, movieIDs, language)
+	if err != nil {
+		panic(err)
+	}
+	for i, movie := range movies {
+		activities[i].Movie = movie
+	}
+
+	// Resolve the usernames of the followed users
+	userIDs := make([]string, 0, len(activities))
+	for _, activity := range activities {
+		userIDs = append(userIDs, activity.GetUserId())
+	}
+	usernames := s.getUsernames(ctx, userIDs)
+	for _, activity := range activities {
+		activity.Username = usernames[activity.GetUserId()]
+	}
+
+	return &v1.GetActivityFeedResponse{
+		Activities: activities,
+		NextCursor: nextCursor,
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	godatabasespgx "github.com/ralvarezdev/go-databases/sql/pgx"
+	godatabasespgxpool "github.com/ralvarezdev/go-databases/sql/pgxpool"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalimports "github.com/ralvarezdev/connect-movies/internal/imports"
+)
+
+// ImportUserMovieReviews imports the ratings of the authenticated user from a Letterboxd or IMDb CSV export, uploaded
+// in chunks. The file is parsed and stored right away, and its rows are matched to TMDB movies and turned into reviews
+// in the background
+//
+// Parameters:
+//
+// - ctx: the context
+// - stream: the stream of the import user movie reviews requests, the first one with the source of the file
+//
+// Returns:
+//
+// - *v1.ImportUserMovieReviewsResponse: the import user movie reviews response
+// - error: if there was an error importing the reviews
+func (s *Service) ImportUserMovieReviews(
+	ctx context.Context,
+	stream *connect.ClientStream[v1.ImportUserMovieReviewsRequest],
+) (*v1.ImportUserMovieReviewsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Receive the source and the chunks of the file
+	var (
+		source string
+		file   bytes.Buffer
+	)
+	for stream.Receive() {
+		message := stream.Msg()
+		if source == "" {
+			source = MapToReviewImportSourceCode(message.GetSource())
+		}
+		if file.Len()+len(message.GetChunk()) > internalimports.MaxUploadSize {
+			return nil, ConnErrReviewImportFileTooLarge
+		}
+		file.Write(message.GetChunk())
+	}
+	if err = stream.Err(); err != nil {
+		return nil, err
+	}
+	if source == "" {
+		return nil, ConnErrInvalidReviewImportSource
+	}
+
+	// Parse the rows of the file
+	rows, err := internalimports.Parse(source, &file)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	reviewImport := v1.UserReviewImport{
+		Source:    MapToReviewImportSource(source),
+		Status:    v1.ReviewImportStatus_PENDING,
+		TotalRows: int32(len(rows)),
+	}
+	for _, row := range rows {
+		if row.Status == internalimports.RowStatusSkipped {
+			reviewImport.ProcessedRows++
+			reviewImport.SkippedRows++
+		}
+	}
+
+	var outCreatedAt time.Time
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Insert the import, unless the user has one in progress
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.InsertUserReviewImportQuery,
+				userID,
+				source,
+				len(rows),
+			).Scan(
+				&reviewImport.Id,
+				&outCreatedAt,
+			); queryErr != nil {
+				isUniqueViolation, constraintName := godatabasespgx.IsUniqueViolationError(queryErr)
+				if !isUniqueViolation || constraintName != internalpostgres.UserReviewImportsActiveUserKey {
+					return queryErr
+				}
+				return ConnErrUserReviewImportInProgress
+			}
+
+			// Copy the rows of the import
+			_, copyErr := tx.CopyFrom(
+				ctx,
+				pgx.Identifier{internalpostgres.UserReviewImportRowsTable},
+				internalpostgres.UserReviewImportRowsColumns,
+				internalimports.CopyFromRows(reviewImport.Id, rows),
+			)
+			return copyErr
+		},
+	); err != nil {
+		return nil, err
+	}
+	reviewImport.CreatedAt = timestamppb.New(outCreatedAt)
+
+	return &v1.ImportUserMovieReviewsResponse{Import: &reviewImport}, nil
+}
+
+// GetUserReviewImport gets the progress of a review import of the authenticated user, with the report of the rows that
+// were skipped or did not match a TMDB movie
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get user review import request
+//
+// Returns:
+//
+// - *v1.GetUserReviewImportResponse: the get user review import response
+// - error: if there was an error getting the review import
+func (s *Service) GetUserReviewImport(
+	ctx context.Context,
+	request *v1.GetUserReviewImportRequest,
+) (*v1.GetUserReviewImportResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Get the import of the user with its progress
+	var (
+		reviewImport   = v1.UserReviewImport{Id: request.GetId()}
+		outSource      string
+		outStatus      string
+		outCreatedAt   time.Time
+		outCompletedAt sql.NullTime
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.GetUserReviewImportQuery,
+		request.GetId(),
+		userID,
+	).Scan(
+		&outSource,
+		&outStatus,
+		&reviewImport.TotalRows,
+		&reviewImport.ProcessedRows,
+		&reviewImport.ImportedRows,
+		&reviewImport.SkippedRows,
+		&reviewImport.UnmatchedRows,
+		&outCreatedAt,
+		&outCompletedAt,
+	); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, ConnErrUserReviewImportNotFound
+		}
+		panic(queryErr)
+	}
+	reviewImport.Source = MapToReviewImportSource(outSource)
+	reviewImport.Status = MapToReviewImportStatus(outStatus)
+	reviewImport.CreatedAt = timestamppb.New(outCreatedAt)
+	reviewImport.CompletedAt = MapToOptionalTimestamp(outCompletedAt)
+
+	// List the rows that were not imported
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListUnimportedUserReviewImportRowsQuery,
+		request.GetId(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var unimportedRows []*v1.UserReviewImportRow
+	for rows.Next() {
+		var (
+			row       v1.UserReviewImportRow
+			outStatus string
+			outReason sql.NullString
+		)
+		if scanErr := rows.Scan(
+			&row.RowNumber,
+			&row.Title,
+			&row.Year,
+			&row.ImdbId,
+			&outStatus,
+			&outReason,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		row.Status = MapToReviewImportRowStatus(outStatus)
+		row.Reason = outReason.String
+		unimportedRows = append(unimportedRows, &row)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.GetUserReviewImportResponse{
+		Import:         &reviewImport,
+		UnimportedRows: unimportedRows,
+	}, nil
+}
+
+// CreateImportedUserReview creates the review of an imported row, matched to a TMDB movie. The review is validated as
+// the ones added through AddUserMovieReview, and the errors are returned instead of panicking, so the review importer
+// can tell the rows to skip from the ones to retry
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user that imports the row
+// - movieID: the ID of the matched TMDB movie
+// - row: the imported row
+//
+// Returns:
+//
+// - error: if there was an error creating the review
+func (s *Service) CreateImportedUserReview(
+	ctx context.Context,
+	userID string,
+	movieID int32,
+	row *internalimports.Row,
+) error {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	request := &v1.AddUserMovieReviewRequest{
+		Id:     movieID,
+		Review: row.Review,
+		Tags:   row.Tags,
+	}
+	if row.Rating != nil {
+		request.Rating = *row.Rating
+	}
+	if row.WatchedOn != nil {
+		request.WatchedOn = timestamppb.New(*row.WatchedOn)
+	}
+
+	// Validate the request as the validation interceptor does
+	if err := protovalidate.Validate(request); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Validate the tags and the watched on date of the movie review
+	details, err := validateUserReviewDetails(request)
+	if err != nil {
+		return err
+	}
+
+	return godatabasespgxpool.CreateTransaction(
+		ctx,
+		s.pool,
+		func(ctx context.Context, tx pgx.Tx) error {
+			_, createErr := s.createUserReview(ctx, tx, userID, request, details)
+			return createErr
+		},
+	)
+}
@@ -0,0 +1,762 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	godatabasespgx "github.com/ralvarezdev/go-databases/sql/pgx"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalexports "github.com/ralvarezdev/connect-movies/internal/exports"
+)
+
+// validateUserMovieList trims and validates the name and description of a user movie list. The watchlist name is
+// reserved for the user watchlist, so any casing of it is stored as the watchlist name, and the unique list names keep
+// a single watchlist per user
+//
+// Parameters:
+//
+// - name: the list name
+// - description: the list description
+//
+// Returns:
+//
+// - string: the trimmed list name
+// - string: the trimmed list description
+// - error: if the name is empty, or the name or the description are too long
+func validateUserMovieList(name, description string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" ||
+		utf8.RuneCountInString(name) > UserMovieListNameMaxLength ||
+		utf8.RuneCountInString(description) > UserMovieListDescriptionMaxLength {
+		return "", "", ConnErrInvalidUserMovieList
+	}
+	if strings.EqualFold(name, internalexports.WatchlistName) {
+		name = internalexports.WatchlistName
+	}
+	return name, description, nil
+}
+
+// isUserMovieListNameTaken checks if the given error is the unique violation of the user movie list names
+//
+// Parameters:
+//
+// - err: the error returned by the query
+//
+// Returns:
+//
+// - bool: true if the user already has a list with the same name, false otherwise
+func isUserMovieListNameTaken(err error) bool {
+	isUniqueViolation, constraintName := godatabasespgx.IsUniqueViolationError(err)
+	return isUniqueViolation && constraintName == internalpostgres.UserMovieListsUniqueUserName
+}
+
+// touchUserMovieList sets the update time of a user movie list owned by the given user, locking it until the
+// transaction ends
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - listID: the ID of the list
+// - userID: the ID of the list owner
+//
+// Returns:
+//
+// - error: if the list was not found or there was an error locking it
+func touchUserMovieList(ctx context.Context, tx pgx.Tx, listID int64, userID string) error {
+	commandTag, err := tx.Exec(ctx, internalpostgres.TouchUserMovieListQuery, listID, userID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ConnErrUserMovieListNotFound
+	}
+	return nil
+}
+
+// CreateUserMovieList creates a movie list for the authenticated user. The list named Watchlist, in any casing, is the
+// user watchlist, which is exported apart from the other lists
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the create user movie list request
+//
+// Returns:
+//
+// - *v1.CreateUserMovieListResponse: the create user movie list response
+// - error: if there was an error creating the user movie list
+func (s *Service) CreateUserMovieList(
+	ctx context.Context,
+	request *v1.CreateUserMovieListRequest,
+) (*v1.CreateUserMovieListResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Validate the list
+	name, description, err := validateUserMovieList(request.GetName(), request.GetDescription())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Insert the list
+	var (
+		outID        int64
+		outCreatedAt time.Time
+		outUpdatedAt time.Time
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.InsertUserMovieListQuery,
+		userID,
+		name,
+		description,
+		request.GetIsPublic(),
+	).Scan(
+		&outID,
+		&outCreatedAt,
+		&outUpdatedAt,
+	); queryErr != nil {
+		if isUserMovieListNameTaken(queryErr) {
+			return nil, ConnErrUserMovieListAlreadyExists
+		}
+		panic(queryErr)
+	}
+
+	return &v1.CreateUserMovieListResponse{
+		List: &v1.UserMovieList{
+			Id:          outID,
+			UserId:      userID,
+			Name:        name,
+			Description: description,
+			IsPublic:    request.GetIsPublic(),
+			CreatedAt:   timestamppb.New(outCreatedAt),
+			UpdatedAt:   timestamppb.New(outUpdatedAt),
+		},
+	}, nil
+}
+
+// UpdateUserMovieList updates the name, description and visibility of a movie list of the authenticated user
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the update user movie list request
+//
+// Returns:
+//
+// - *v1.UpdateUserMovieListResponse: the update user movie list response
+// - error: if there was an error updating the user movie list
+func (s *Service) UpdateUserMovieList(
+	ctx context.Context,
+	request *v1.UpdateUserMovieListRequest,
+) (*v1.UpdateUserMovieListResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Validate the list
+	name, description, err := validateUserMovieList(request.GetName(), request.GetDescription())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Update the list
+	var (
+		outCreatedAt  time.Time
+		outUpdatedAt  time.Time
+		outItemsCount int32
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.UpdateUserMovieListQuery,
+		request.GetListId(),
+		userID,
+		name,
+		description,
+		request.GetIsPublic(),
+	).Scan(
+		&outCreatedAt,
+		&outUpdatedAt,
+		&outItemsCount,
+	); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, ConnErrUserMovieListNotFound
+		}
+		if isUserMovieListNameTaken(queryErr) {
+			return nil, ConnErrUserMovieListAlreadyExists
+		}
+		panic(queryErr)
+	}
+
+	// Invalidate the cached activity feeds of the followers, so the items of a list made private are removed
+	s.invalidateFollowerFeeds(ctx, userID)
+
+	return &v1.UpdateUserMovieListResponse{
+		List: &v1.UserMovieList{
+			Id:          request.GetListId(),
+			UserId:      userID,
+			Name:        name,
+			Description: description,
+			IsPublic:    request.GetIsPublic(),
+			ItemsCount:  outItemsCount,
+			CreatedAt:   timestamppb.New(outCreatedAt),
+			UpdatedAt:   timestamppb.New(outUpdatedAt),
+		},
+	}, nil
+}
+
+// DeleteUserMovieList deletes a movie list of the authenticated user with its items
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete user movie list request
+//
+// Returns:
+//
+// - *v1.DeleteUserMovieListResponse: the delete user movie list response
+// - error: if there was an error deleting the user movie list
+func (s *Service) DeleteUserMovieList(
+	ctx context.Context,
+	request *v1.DeleteUserMovieListRequest,
+) (*v1.DeleteUserMovieListResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Delete the list
+	commandTag, err := s.pool.Exec(ctx, internalpostgres.DeleteUserMovieListQuery, request.GetListId(), userID)
+	if err != nil {
+		panic(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return nil, ConnErrUserMovieListNotFound
+	}
+
+	// Invalidate the cached activity feeds of the followers, so the items of the deleted list are removed
+	s.invalidateFollowerFeeds(ctx, userID)
+
+	return &v1.DeleteUserMovieListResponse{}, nil
+}
+
+// ListUserMovieLists lists the movie lists of the authenticated user, from the newest to the oldest list
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user movie lists request
+//
+// Returns:
+//
+// - *v1.ListUserMovieListsResponse: the list user movie lists response
+// - error: if there was an error listing the user movie lists
+func (s *Service) ListUserMovieLists(
+	ctx context.Context,
+	request *v1.ListUserMovieListsRequest,
+) (*v1.ListUserMovieListsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Query the lists of the user
+	rows, err := s.pool.Query(ctx, internalpostgres.ListUserMovieListsQuery, userID)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	lists := make([]*v1.UserMovieList, 0)
+	for rows.Next() {
+		var (
+			outID          int64
+			outName        string
+			outDescription string
+			outIsPublic    bool
+			outCreatedAt   time.Time
+			outUpdatedAt   time.Time
+			outItemsCount  int32
+		)
+		if scanErr := rows.Scan(
+			&outID,
+			&outName,
+			&outDescription,
+			&outIsPublic,
+			&outCreatedAt,
+			&outUpdatedAt,
+			&outItemsCount,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		lists = append(
+			lists, &v1.UserMovieList{
+				Id:          outID,
+				UserId:      userID,
+				Name:        outName,
+				Description: outDescription,
+				IsPublic:    outIsPublic,
+				ItemsCount:  outItemsCount,
+				CreatedAt:   timestamppb.New(outCreatedAt),
+				UpdatedAt:   timestamppb.New(outUpdatedAt),
+			},
+		)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListUserMovieListsResponse{Lists: lists}, nil
+}
+
+// getUserMovieList gets a user movie list with a page of its items hydrated with their movies. Private lists are only
+// visible to their owner, and are reported as not found to anyone else
+//
+// Parameters:
+//
+// - ctx: the context
+// - listID: the ID of the list
+// - userID: the ID of the authenticated user, empty for anonymous calls
+// - language: the language of the movies
+// - page: the page number, starting at 1
+//
+// Returns:
+//
+// - *v1.UserMovieList: the list
+// - []*v1.UserMovieListItem: the items of the page
+// - int32: the page number
+// - error: if the list was not found
+func (s *Service) getUserMovieList(
+	ctx context.Context,
+	listID int64,
+	userID string,
+	language string,
+	page int32,
+) (*v1.UserMovieList, []*v1.UserMovieListItem, int32, error) {
+	// Get the list
+	list := &v1.UserMovieList{Id: listID}
+	var (
+		outCreatedAt time.Time
+		outUpdatedAt time.Time
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.GetUserMovieListQuery,
+		listID,
+	).Scan(
+		&list.UserId,
+		&list.Name,
+		&list.Description,
+		&list.IsPublic,
+		&outCreatedAt,
+		&outUpdatedAt,
+		&list.ItemsCount,
+	); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, nil, 0, ConnErrUserMovieListNotFound
+		}
+		panic(queryErr)
+	}
+	list.CreatedAt = timestamppb.New(outCreatedAt)
+	list.UpdatedAt = timestamppb.New(outUpdatedAt)
+
+	// Check if the list is visible to the user
+	if !list.GetIsPublic() && list.GetUserId() != userID {
+		return nil, nil, 0, ConnErrUserMovieListNotFound
+	}
+
+	// Query the items of the page
+	page = max(page, 1)
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListUserMovieListItemsQuery,
+		listID,
+		UserMovieListItemsPageSize,
+		(page-1)*UserMovieListItemsPageSize,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		items    = make([]*v1.UserMovieListItem, 0, UserMovieListItemsPageSize)
+		movieIDs = make([]int32, 0, UserMovieListItemsPageSize)
+	)
+	for rows.Next() {
+		var (
+			outMovieID  int32
+			outPosition int32
+			outNote     string
+			outAddedAt  time.Time
+		)
+		if scanErr := rows.Scan(
+			&outMovieID,
+			&outPosition,
+			&outNote,
+			&outAddedAt,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		items = append(
+			items, &v1.UserMovieListItem{
+				Position: outPosition,
+				Note:     outNote,
+				AddedAt:  timestamppb.New(outAddedAt),
+			},
+		)
+		movieIDs = append(movieIDs, outMovieID)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Hydrate the items with their movies
+	movies, err := s.getSimpleMovies(ctx, movieIDs, language)
+	if err != nil {
+		panic(err)
+	}
+	for i, movie := range movies {
+		items[i].Movie = movie
+	}
+
+	return list, items, page, nil
+}
+
+// GetUserMovieList gets a movie list owned by the authenticated user, or a public movie list of another user, with
+// a page of its items
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get user movie list request
+//
+// Returns:
+//
+// - *v1.GetUserMovieListResponse: the get user movie list response
+// - error: if there was an error getting the user movie list
+func (s *Service) GetUserMovieList(
+	ctx context.Context,
+	request *v1.GetUserMovieListRequest,
+) (*v1.GetUserMovieListResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	list, items, page, err := s.getUserMovieList(
+		ctx,
+		request.GetListId(),
+		userID,
+		request.GetLanguage(),
+		request.GetPage(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.GetUserMovieListResponse{
+		List:       list,
+		Items:      items,
+		Page:       page,
+		TotalPages: (list.GetItemsCount() + UserMovieListItemsPageSize - 1) / UserMovieListItemsPageSize,
+	}, nil
+}
+
+// GetPublicUserMovieList gets a public user movie list with a page of its items. It can be called without an access
+// token, so shared lists can be opened by anyone
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get public user movie list request
+//
+// Returns:
+//
+// - *v1.GetPublicUserMovieListResponse: the get public user movie list response
+// - error: if there was an error getting the public user movie list
+func (s *Service) GetPublicUserMovieList(
+	ctx context.Context,
+	request *v1.GetPublicUserMovieListRequest,
+) (*v1.GetPublicUserMovieListResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	list, items, page, err := s.getUserMovieList(
+		ctx,
+		request.GetListId(),
+		"",
+		request.GetLanguage(),
+		request.GetPage(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.GetPublicUserMovieListResponse{
+		List:       list,
+		Items:      items,
+		Page:       page,
+		TotalPages: (list.GetItemsCount() + UserMovieListItemsPageSize - 1) / UserMovieListItemsPageSize,
+	}, nil
+}
+
+// AddUserMovieListItem appends a movie to a movie list of the authenticated user
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the add user movie list item request
+//
+// Returns:
+//
+// - *v1.AddUserMovieListItemResponse: the add user movie list item response
+// - error: if there was an error adding the user movie list item
+func (s *Service) AddUserMovieListItem(
+	ctx context.Context,
+	request *v1.AddUserMovieListItemRequest,
+) (*v1.AddUserMovieListItemResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Validate the note
+	note := strings.TrimSpace(request.GetNote())
+	if utf8.RuneCountInString(note) > UserMovieListItemNoteMaxLength {
+		return nil, ConnErrInvalidUserMovieListItemNote
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock the list
+			if touchErr := touchUserMovieList(ctx, tx, request.GetListId(), userID); touchErr != nil {
+				return touchErr
+			}
+
+			// Check if the list is full
+			var itemsCount int
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.CountUserMovieListItemsQuery,
+				request.GetListId(),
+			).Scan(&itemsCount); queryErr != nil {
+				return queryErr
+			}
+			if itemsCount >= UserMovieListMaxItems {
+				return ConnErrUserMovieListFull
+			}
+
+			// Append the movie to the list
+			commandTag, execErr := tx.Exec(
+				ctx,
+				internalpostgres.InsertUserMovieListItemQuery,
+				request.GetListId(),
+				request.GetId(),
+				note,
+			)
+			if execErr != nil {
+				return execErr
+			}
+			if commandTag.RowsAffected() == 0 {
+				return ConnErrUserMovieListItemAlreadyExists
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.AddUserMovieListItemResponse{}, nil
+}
+
+// RemoveUserMovieListItem removes a movie from a movie list of the authenticated user, moving up the movies after it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the remove user movie list item request
+//
+// Returns:
+//
+// - *v1.RemoveUserMovieListItemResponse: the remove user movie list item response
+// - error: if there was an error removing the user movie list item
+func (s *Service) RemoveUserMovieListItem(
+	ctx context.Context,
+	request *v1.RemoveUserMovieListItemRequest,
+) (*v1.RemoveUserMovieListItemResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock the list
+			if touchErr := touchUserMovieList(ctx, tx, request.GetListId(), userID); touchErr != nil {
+				return touchErr
+			}
+
+			// Delete the movie from the list
+			var position int32
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.DeleteUserMovieListItemQuery,
+				request.GetListId(),
+				request.GetId(),
+			).Scan(&position); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return ConnErrUserMovieListItemNotFound
+				}
+				return queryErr
+			}
+
+			// Move up the movies after the deleted one
+			_, execErr := tx.Exec(
+				ctx,
+				internalpostgres.ShiftUserMovieListItemsQuery,
+				request.GetListId(),
+				position,
+			)
+			return execErr
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the cached activity feeds of the followers, so the removed movie is no longer shown
+	s.invalidateFollowerFeeds(ctx, userID)
+
+	return &v1.RemoveUserMovieListItemResponse{}, nil
+}
+
+// ReorderUserMovieListItems sets the order of the movies of a movie list of the authenticated user. The given movie
+// IDs must be exactly the movies of the list, in their new order
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the reorder user movie list items request
+//
+// Returns:
+//
+// - *v1.ReorderUserMovieListItemsResponse: the reorder user movie list items response
+// - error: if there was an error reordering the user movie list items
+func (s *Service) ReorderUserMovieListItems(
+	ctx context.Context,
+	request *v1.ReorderUserMovieListItemsRequest,
+) (*v1.ReorderUserMovieListItemsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock the list
+			if touchErr := touchUserMovieList(ctx, tx, request.GetListId(), userID); touchErr != nil {
+				return touchErr
+			}
+
+			// Get the movies of the list
+			rows, queryErr := tx.Query(
+				ctx,
+				internalpostgres.ListUserMovieListItemMovieIDsQuery,
+				request.GetListId(),
+			)
+			if queryErr != nil {
+				return queryErr
+			}
+			movieIDs, collectErr := pgx.CollectRows(rows, pgx.RowTo[int32])
+			if collectErr != nil {
+				return collectErr
+			}
+
+			// Check the given movie IDs are exactly the movies of the list
+			if len(request.GetMovieIds()) != len(movieIDs) {
+				return ConnErrInvalidUserMovieListOrder
+			}
+			pendingMovieIDs := make(map[int32]struct{}, len(movieIDs))
+			for _, movieID := range movieIDs {
+				pendingMovieIDs[movieID] = struct{}{}
+			}
+			for _, movieID := range request.GetMovieIds() {
+				if _, ok := pendingMovieIDs[movieID]; !ok {
+					return ConnErrInvalidUserMovieListOrder
+				}
+				delete(pendingMovieIDs, movieID)
+			}
+
+			// Set the positions of the movies
+			_, execErr := tx.Exec(
+				ctx,
+				internalpostgres.ReorderUserMovieListItemsQuery,
+				request.GetListId(),
+				request.GetMovieIds(),
+			)
+			return execErr
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.ReorderUserMovieListItemsResponse{}, nil
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internalexports "github.com/ralvarezdev/connect-movies/internal/exports"
+	internalimports "github.com/ralvarezdev/connect-movies/internal/imports"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+	internaluserdata "github.com/ralvarezdev/connect-movies/internal/userdata"
+)
+
+// MapToOptionalTimestamp maps a nullable time to a timestamppb.Timestamp
+//
+// Parameters:
+//
+// - value: the nullable time to map
+//
+// Returns:
+//
+// - *timestamppb.Timestamp: the mapped timestamppb.Timestamp, nil if the time is null
+func MapToOptionalTimestamp(value sql.NullTime) *timestamppb.Timestamp {
+	if !value.Valid {
+		return nil
+	}
+	return timestamppb.New(value.Time)
+}
+
+type (
+	// SpoilerSpan is an inline spoiler of a user review, stored as JSON. Start and End are the [Start, End) rune
+	// offsets of the spoiler in the review text
+	SpoilerSpan struct {
+		Start int32 `json:"start"`
+		End   int32 `json:"end"`
+	}
+)
+
+// MapToOptionalDate maps a nullable date to a timestamppb.Timestamp at midnight UTC
+//
+// Parameters:
+//
+// - value: the nullable date to map
+//
+// Returns:
+//
+// - *timestamppb.Timestamp: the mapped timestamppb.Timestamp, nil if the date is null
+func MapToOptionalDate(value sql.NullTime) *timestamppb.Timestamp {
+	if !value.Valid {
+		return nil
+	}
+	year, month, day := value.Time.Date()
+	return timestamppb.New(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// MapToSpoilerSpans maps the stored spoiler spans of a user review to v1.SpoilerSpan
+//
+// Parameters:
+//
+// - spans: the spoiler spans to map
+//
+// Returns:
+//
+// - []*v1.SpoilerSpan: the mapped v1.SpoilerSpan
+func MapToSpoilerSpans(spans []SpoilerSpan) []*v1.SpoilerSpan {
+	mappedSpans := make([]*v1.SpoilerSpan, 0, len(spans))
+	for _, span := range spans {
+		mappedSpans = append(mappedSpans, &v1.SpoilerSpan{Start: span.Start, End: span.End})
+	}
+	return mappedSpans
+}
+
+// MapToModerationStatus maps a user review moderation status to a v1.ModerationStatus
+//
+// Parameters:
+//
+// - status: the moderation status to map
+//
+// Returns:
+//
+// - v1.ModerationStatus: the mapped v1.ModerationStatus
+func MapToModerationStatus(status string) v1.ModerationStatus {
+	switch status {
+	case ModerationStatusPending:
+		return v1.ModerationStatus_PENDING
+	case ModerationStatusRejected:
+		return v1.ModerationStatus_REJECTED
+	default:
+		return v1.ModerationStatus_PUBLISHED
+	}
+}
+
+// MapToReportReasonCode maps a v1.ReportReason to its reason code
+//
+// Parameters:
+//
+// - reason: the v1.ReportReason to map
+//
+// Returns:
+//
+// - string: the mapped reason code, empty if the reason is not valid
+func MapToReportReasonCode(reason v1.ReportReason) string {
+	switch reason {
+	case v1.ReportReason_SPAM:
+		return "spam"
+	case v1.ReportReason_HARASSMENT:
+		return "harassment"
+	case v1.ReportReason_HATE_SPEECH:
+		return "hate_speech"
+	case v1.ReportReason_SPOILERS:
+		return "spoilers"
+	case v1.ReportReason_SEXUAL_CONTENT:
+		return "sexual_content"
+	case v1.ReportReason_OTHER:
+		return "other"
+	default:
+		return ""
+	}
+}
+
+// MapToReportReason maps a reason code to a v1.ReportReason
+//
+// Parameters:
+//
+// - code: the reason code to map
+//
+// Returns:
+//
+// - v1.ReportReason: the mapped v1.ReportReason
+func MapToReportReason(code string) v1.ReportReason {
+	switch code {
+	case "spam":
+		return v1.ReportReason_SPAM
+	case "harassment":
+		return v1.ReportReason_HARASSMENT
+	case "hate_speech":
+		return v1.ReportReason_HATE_SPEECH
+	case "spoilers":
+		return v1.ReportReason_SPOILERS
+	case "sexual_content":
+		return v1.ReportReason_SEXUAL_CONTENT
+	default:
+		return v1.ReportReason_OTHER
+	}
+}
+
+// MapToFeedActivityType maps an activity feed kind to a v1.FeedActivityType
+//
+// Parameters:
+//
+// - kind: the activity feed kind to map
+//
+// Returns:
+//
+// - v1.FeedActivityType: the mapped v1.FeedActivityType
+func MapToFeedActivityType(kind string) v1.FeedActivityType {
+	switch kind {
+	case FeedActivityKindDiaryEntry:
+		return v1.FeedActivityType_DIARY_ENTRY
+	case FeedActivityKindListItem:
+		return v1.FeedActivityType_LIST_ITEM
+	default:
+		return v1.FeedActivityType_REVIEW
+	}
+}
+
+// MapToWebhookDeliveryStatus maps a webhook delivery status to a v1.WebhookDeliveryStatus
+//
+// Parameters:
+//
+// - status: the webhook delivery status to map
+//
+// Returns:
+//
+// - v1.WebhookDeliveryStatus: the mapped v1.WebhookDeliveryStatus
+func MapToWebhookDeliveryStatus(status string) v1.WebhookDeliveryStatus {
+	switch status {
+	case WebhookDeliveryStatusSucceeded:
+		return v1.WebhookDeliveryStatus_SUCCEEDED
+	case WebhookDeliveryStatusFailed:
+		return v1.WebhookDeliveryStatus_FAILED
+	default:
+		return v1.WebhookDeliveryStatus_PENDING
+	}
+}
+
+// MapToUserDataExportStatus maps a data export status to a v1.UserDataExportStatus
+//
+// Parameters:
+//
+// - status: the data export status to map
+//
+// Returns:
+//
+// - v1.UserDataExportStatus: the mapped v1.UserDataExportStatus
+func MapToUserDataExportStatus(status string) v1.UserDataExportStatus {
+	switch status {
+	case UserDataExportStatusRunning:
+		return v1.UserDataExportStatus_RUNNING
+	case UserDataExportStatusSucceeded:
+		return v1.UserDataExportStatus_SUCCEEDED
+	case UserDataExportStatusFailed:
+		return v1.UserDataExportStatus_FAILED
+	default:
+		return v1.UserDataExportStatus_PENDING
+	}
+}
+
+// MapToReviewImportSourceCode maps a v1.ReviewImportSource to its source code
+//
+// Parameters:
+//
+// - source: the v1.ReviewImportSource to map
+//
+// Returns:
+//
+// - string: the mapped source code, empty if the source is not valid
+func MapToReviewImportSourceCode(source v1.ReviewImportSource) string {
+	switch source {
+	case v1.ReviewImportSource_LETTERBOXD:
+		return internalimports.SourceLetterboxd
+	case v1.ReviewImportSource_IMDB:
+		return internalimports.SourceIMDb
+	default:
+		return ""
+	}
+}
+
+// MapToReviewImportSource maps a source code to a v1.ReviewImportSource
+//
+// Parameters:
+//
+// - code: the source code to map
+//
+// Returns:
+//
+// - v1.ReviewImportSource: the mapped v1.ReviewImportSource
+func MapToReviewImportSource(code string) v1.ReviewImportSource {
+	switch code {
+	case internalimports.SourceLetterboxd:
+		return v1.ReviewImportSource_LETTERBOXD
+	case internalimports.SourceIMDb:
+		return v1.ReviewImportSource_IMDB
+	default:
+		return v1.ReviewImportSource_REVIEW_IMPORT_SOURCE_UNSPECIFIED
+	}
+}
+
+// MapToReviewImportStatus maps a review import status to a v1.ReviewImportStatus
+//
+// Parameters:
+//
+// - status: the review import status to map
+//
+// Returns:
+//
+// - v1.ReviewImportStatus: the mapped v1.ReviewImportStatus
+func MapToReviewImportStatus(status string) v1.ReviewImportStatus {
+	switch status {
+	case ReviewImportStatusRunning:
+		return v1.ReviewImportStatus_RUNNING
+	case ReviewImportStatusSucceeded:
+		return v1.ReviewImportStatus_SUCCEEDED
+	default:
+		return v1.ReviewImportStatus_PENDING
+	}
+}
+
+// MapToReviewImportRowStatus maps a review import row status to a v1.ReviewImportRowStatus
+//
+// Parameters:
+//
+// - status: the review import row status to map
+//
+// Returns:
+//
+// - v1.ReviewImportRowStatus: the mapped v1.ReviewImportRowStatus
+func MapToReviewImportRowStatus(status string) v1.ReviewImportRowStatus {
+	switch status {
+	case internalimports.RowStatusImported:
+		return v1.ReviewImportRowStatus_IMPORTED
+	case internalimports.RowStatusSkipped:
+		return v1.ReviewImportRowStatus_SKIPPED
+	case internalimports.RowStatusUnmatched:
+		return v1.ReviewImportRowStatus_UNMATCHED
+	default:
+		return v1.ReviewImportRowStatus_PENDING
+	}
+}
+
+// MapToExportFormatCode maps a v1.ExportFormat to its format code
+//
+// Parameters:
+//
+// - format: the v1.ExportFormat to map
+//
+// Returns:
+//
+// - string: the mapped format code, empty if the format is not valid
+func MapToExportFormatCode(format v1.ExportFormat) string {
+	switch format {
+	case v1.ExportFormat_CSV:
+		return internalexports.FormatCSV
+	case v1.ExportFormat_JSON:
+		return internalexports.FormatJSON
+	default:
+		return ""
+	}
+}
+
+// MapToExportContentCode maps a v1.ExportContent to its content code
+//
+// Parameters:
+//
+// - content: the v1.ExportContent to map
+//
+// Returns:
+//
+// - string: the mapped content code, empty if the content is not valid
+func MapToExportContentCode(content v1.ExportContent) string {
+	switch content {
+	case v1.ExportContent_REVIEWS:
+		return internalexports.ContentReviews
+	case v1.ExportContent_WATCHLIST:
+		return internalexports.ContentWatchlist
+	case v1.ExportContent_LISTS:
+		return internalexports.ContentLists
+	default:
+		return ""
+	}
+}
+
+// MapToExternalIDSourceCode maps a v1.ExternalIdSource to its TMDB external source
+//
+// Parameters:
+//
+// - source: the v1.ExternalIdSource to map
+//
+// Returns:
+//
+// - string: the mapped TMDB external source, empty if the source is not valid
+func MapToExternalIDSourceCode(source v1.ExternalIdSource) string {
+	switch source {
+	case v1.ExternalIdSource_IMDB:
+		return internaltmdb.ExternalSourceIMDb
+	case v1.ExternalIdSource_WIKIDATA:
+		return internaltmdb.ExternalSourceWikidata
+	default:
+		return ""
+	}
+}
+
+// MapToUserDataErasureReport maps an erasure report to a v1.UserDataErasureReport
+//
+// Parameters:
+//
+// - report: the erasure report to map
+//
+// Returns:
+//
+// - *v1.UserDataErasureReport: the mapped v1.UserDataErasureReport
+func MapToUserDataErasureReport(report *internaluserdata.ErasureReport) *v1.UserDataErasureReport {
+	if report == nil {
+		panic(ErrNilModelToMap)
+	}
+
+	return &v1.UserDataErasureReport{
+		Reviews:                report.Reviews,
+		Votes:                  report.Votes,
+		Comments:               report.Comments,
+		ContentReports:         report.ContentReports,
+		ModerationAuditEntries: report.ModerationAuditEntries,
+		MovieLists:             report.MovieLists,
+		Follows:                report.Follows,
+	}
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+)
+
+// writeUserReviewModeratedEvent writes the moderated event of a user review whose moderation status changed to the
+// outbox, in the transaction of the change
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction the moderation status was changed in
+// - reviewUserID: the ID of the user that wrote the review
+// - movieID: the ID of the reviewed movie
+// - status: the new moderation status of the user review
+//
+// Returns:
+//
+// - error: if there was an error writing the event
+func writeUserReviewModeratedEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	reviewUserID string,
+	movieID int32,
+	status string,
+) error {
+	return internaloutbox.Write(
+		ctx,
+		tx,
+		internaloutbox.Event{
+			Type:        internaloutbox.EventUserReviewModerated,
+			AggregateID: fmt.Sprintf(internaloutbox.UserReviewAggregateID, reviewUserID, movieID),
+			Payload: internaloutbox.UserReviewPayload{
+				UserID:           reviewUserID,
+				MovieID:          movieID,
+				ModerationStatus: status,
+			},
+		},
+	)
+}
+
+// moderateUserReview runs the moderation rules engine on the text of a user review that was just stored, holding it
+// as pending and recording the decision in the audit trail if it was flagged. A rejected review stays rejected, and a
+// review held by its reports stays pending
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction the user review was stored in
+// - userID: the ID of the user that wrote the review
+// - movieID: the ID of the reviewed movie
+// - reviewText: the text of the user review
+//
+// Returns:
+//
+// - string: the moderation status of the user review
+// - []string: the reasons the user review is not published, empty if it was published
+// - error: if there was an error moderating the user review
+func (s *Service) moderateUserReview(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	movieID int32,
+	reviewText string,
+) (string, []string, error) {
+	// Check the text against the moderation rules
+	violations := s.moderationEngine.Moderate(reviewText)
+	reasons := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", violation.Rule, violation.Reason))
+	}
+	status := ModerationStatusPublished
+	if len(violations) > 0 {
+		status = ModerationStatusPending
+	}
+
+	// Set the moderation status, so an edited review is moderated again
+	var (
+		outStatus  string
+		outReasons []string
+	)
+	if err := tx.QueryRow(
+		ctx,
+		internalpostgres.SetUserReviewModerationQuery,
+		userID,
+		movieID,
+		status,
+		reasons,
+	).Scan(&outStatus, &outReasons); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return "", nil, err
+		}
+		return ModerationStatusRejected, reasons, nil
+	}
+
+	// Record the flagged user review in the audit trail
+	if status == ModerationStatusPending {
+		if _, err := tx.Exec(
+			ctx,
+			internalpostgres.InsertUserReviewModerationAuditQuery,
+			userID,
+			movieID,
+			ModerationActionFlagged,
+			nil,
+			reasons,
+			nil,
+			reviewText,
+		); err != nil {
+			return "", nil, err
+		}
+	}
+	return outStatus, outReasons, nil
+}
+
+// resolvePendingUserReview approves or rejects a pending user review, recording the decision in the audit trails
+//
+// Parameters:
+//
+// - ctx: the context
+// - reviewUserID: the ID of the user that wrote the review
+// - movieID: the ID of the reviewed movie
+// - status: the new moderation status of the user review
+// - action: the moderation audit action
+// - note: the note of the staff user (optional)
+//
+// Returns:
+//
+// - error: if there was an error resolving the pending user review
+func (s *Service) resolvePendingUserReview(
+	ctx context.Context,
+	reviewUserID string,
+	movieID int32,
+	status string,
+	action string,
+	note *string,
+) error {
+	// Check if the authenticated user is an admin or a moderator
+	staffUserID, err := requireStaff(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Set the moderation status of the pending user review
+			var (
+				outReviewText sql.NullString
+				outReasons    []string
+			)
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.ResolvePendingUserReviewQuery,
+				reviewUserID,
+				movieID,
+				status,
+			).Scan(&outReviewText, &outReasons); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return ConnErrPendingUserMovieReviewNotFound
+				}
+				return queryErr
+			}
+
+			// Write the moderated event to the outbox
+			if writeErr := writeUserReviewModeratedEvent(ctx, tx, reviewUserID, movieID, status); writeErr != nil {
+				return writeErr
+			}
+
+			// Record the decision in the moderation audit trail and in the admin audit log
+			if _, execErr := tx.Exec(
+				ctx,
+				internalpostgres.InsertUserReviewModerationAuditQuery,
+				reviewUserID,
+				movieID,
+				action,
+				staffUserID,
+				outReasons,
+				note,
+				outReviewText,
+			); execErr != nil {
+				return execErr
+			}
+			adminAction := AdminActionApproveUserReview
+			if action == ModerationActionRejected {
+				adminAction = AdminActionRejectUserReview
+			}
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				staffUserID,
+				adminAction,
+				fmt.Sprintf(UserReviewTarget, reviewUserID, movieID),
+				map[string]any{"note": note},
+			)
+		},
+	); err != nil {
+		return err
+	}
+
+	// Invalidate the cached activity feeds of the author's followers, so a rejected review stays out of them
+	s.invalidateFollowerFeeds(ctx, reviewUserID)
+	return nil
+}
+
+// ListModerationQueue lists a page of the user reviews pending moderation, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list moderation queue request
+//
+// Returns:
+//
+// - *v1.ListModerationQueueResponse: the list moderation queue response
+// - error: if there was an error listing the moderation queue
+func (s *Service) ListModerationQueue(
+	ctx context.Context,
+	request *v1.ListModerationQueueRequest,
+) (*v1.ListModerationQueueResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	if _, err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	// Query the pending user reviews of the page
+	page := max(request.GetPage(), 1)
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListPendingUserReviewsQuery,
+		ModerationQueuePageSize,
+		(page-1)*ModerationQueuePageSize,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var totalResults int32
+	pendingReviews := make([]*v1.PendingUserMovieReview, 0, ModerationQueuePageSize)
+	for rows.Next() {
+		var (
+			outUserID     string
+			outMovieID    int32
+			outRating     sql.NullInt32
+			outReviewText sql.NullString
+			outReasons    []string
+			outCreatedAt  sql.NullTime
+			outUpdatedAt  sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&outUserID,
+			&outMovieID,
+			&outRating,
+			&outReviewText,
+			&outReasons,
+			&outCreatedAt,
+			&outUpdatedAt,
+			&totalResults,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		pendingReviews = append(
+			pendingReviews, &v1.PendingUserMovieReview{
+				MovieId: outMovieID,
+				UserReview: &v1.UserMovieReview{
+					UserId:    outUserID,
+					Rating:    outRating.Int32,
+					Review:    outReviewText.String,
+					CreatedAt: MapToOptionalTimestamp(outCreatedAt),
+					UpdatedAt: MapToOptionalTimestamp(outUpdatedAt),
+				},
+				Reasons: outReasons,
+			},
+		)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListModerationQueueResponse{
+		Page:         page,
+		Results:      pendingReviews,
+		TotalPages:   (totalResults + ModerationQueuePageSize - 1) / ModerationQueuePageSize,
+		TotalResults: totalResults,
+	}, nil
+}
+
+// ApproveUserMovieReview approves a pending user movie review, publishing it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the approve user movie review request
+//
+// Returns:
+//
+// - *v1.ApproveUserMovieReviewResponse: the approve user movie review response
+// - error: if there was an error approving the user movie review
+func (s *Service) ApproveUserMovieReview(
+	ctx context.Context,
+	request *v1.ApproveUserMovieReviewRequest,
+) (*v1.ApproveUserMovieReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.resolvePendingUserReview(
+		ctx,
+		request.GetUserId(),
+		request.GetId(),
+		ModerationStatusPublished,
+		ModerationActionApproved,
+		request.Note,
+	); err != nil {
+		return nil, err
+	}
+	return &v1.ApproveUserMovieReviewResponse{}, nil
+}
+
+// RejectUserMovieReview rejects a pending user movie review, keeping it hidden
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the reject user movie review request
+//
+// Returns:
+//
+// - *v1.RejectUserMovieReviewResponse: the reject user movie review response
+// - error: if there was an error rejecting the user movie review
+func (s *Service) RejectUserMovieReview(
+	ctx context.Context,
+	request *v1.RejectUserMovieReviewRequest,
+) (*v1.RejectUserMovieReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.resolvePendingUserReview(
+		ctx,
+		request.GetUserId(),
+		request.GetId(),
+		ModerationStatusRejected,
+		ModerationActionRejected,
+		request.Note,
+	); err != nil {
+		return nil, err
+	}
+	return &v1.RejectUserMovieReviewResponse{}, nil
+}
+
+// ListUserMovieReviewModerationAudit lists the moderation audit trail of a user movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user movie review moderation audit request
+//
+// Returns:
+//
+// - *v1.ListUserMovieReviewModerationAuditResponse: the list user movie review moderation audit response
+// - error: if there was an error listing the user movie review moderation audit
+func (s *Service) ListUserMovieReviewModerationAudit(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewModerationAuditRequest,
+) (*v1.ListUserMovieReviewModerationAuditResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	if _, err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	// Query the audit trail of the user review
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListUserReviewModerationAuditQuery,
+		request.GetUserId(),
+		request.GetId(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	entries := make([]*v1.ModerationAuditEntry, 0)
+	for rows.Next() {
+		var (
+			outID          int64
+			outAction      string
+			outActorUserID sql.NullString
+			outReasons     []string
+			outNote        sql.NullString
+			outReviewText  sql.NullString
+			outCreatedAt   sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&outID,
+			&outAction,
+			&outActorUserID,
+			&outReasons,
+			&outNote,
+			&outReviewText,
+			&outCreatedAt,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		entries = append(
+			entries, &v1.ModerationAuditEntry{
+				Id:          outID,
+				Action:      outAction,
+				ActorUserId: outActorUserID.String,
+				Reasons:     outReasons,
+				Note:        outNote.String,
+				Review:      outReviewText.String,
+				CreatedAt:   MapToOptionalTimestamp(outCreatedAt),
+			},
+		)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListUserMovieReviewModerationAuditResponse{Entries: entries}, nil
+}
@@ -0,0 +1,575 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalmoderation "github.com/ralvarezdev/connect-movies/internal/moderation"
+)
+
+// ReportReview reports another user's movie review, hiding it pending moderation once enough distinct users reported
+// it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the report review request
+//
+// Returns:
+//
+// - *v1.ReportReviewResponse: the report review response
+// - error: if there was an error reporting the review
+func (s *Service) ReportReview(
+	ctx context.Context,
+	request *v1.ReportReviewRequest,
+) (*v1.ReportReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Check if the user is reporting their own review
+	sameUser, err := isSameUser(userID, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if sameUser {
+		return nil, ConnErrCannotReportOwnContent
+	}
+
+	// Map the reason
+	reason := MapToReportReasonCode(request.GetReason())
+	if reason == "" {
+		return nil, ConnErrInvalidReportReason
+	}
+
+	hidden := false
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Check if the user review exists, locking it so concurrent reports cannot both count below the threshold
+			var outLocked int
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.LockPublishedUserReviewQuery,
+				request.GetUserId(),
+				request.GetId(),
+			).Scan(&outLocked); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return ConnErrUserMovieReviewNotFound
+				}
+				return queryErr
+			}
+
+			// Insert the report, ignoring repeated reports of the same user
+			commandTag, execErr := tx.Exec(
+				ctx,
+				internalpostgres.InsertUserReviewReportQuery,
+				userID,
+				request.GetUserId(),
+				request.GetId(),
+				reason,
+				request.Details,
+			)
+			if execErr != nil || commandTag.RowsAffected() == 0 {
+				return execErr
+			}
+
+			// Count the distinct users that reported the user review
+			var reportersCount int
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.CountOpenUserReviewReportsQuery,
+				request.GetUserId(),
+				request.GetId(),
+			).Scan(&reportersCount); queryErr != nil {
+				return queryErr
+			}
+			if reportersCount < internalmoderation.ReportsHideThreshold {
+				return nil
+			}
+
+			// Hold the user review as pending, writing the moderated event to the outbox and recording it in the audit
+			// trail
+			hiddenReason := fmt.Sprintf(ReportsHiddenReason, reportersCount)
+			var outReviewText sql.NullString
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.HideReportedUserReviewQuery,
+				request.GetUserId(),
+				request.GetId(),
+				hiddenReason,
+			).Scan(&outReviewText); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return nil
+				}
+				return queryErr
+			}
+			if writeErr := writeUserReviewModeratedEvent(
+				ctx,
+				tx,
+				request.GetUserId(),
+				request.GetId(),
+				ModerationStatusPending,
+			); writeErr != nil {
+				return writeErr
+			}
+			hidden = true
+			_, execErr = tx.Exec(
+				ctx,
+				internalpostgres.InsertUserReviewModerationAuditQuery,
+				request.GetUserId(),
+				request.GetId(),
+				ModerationActionFlagged,
+				nil,
+				[]string{hiddenReason},
+				nil,
+				outReviewText,
+			)
+			return execErr
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the cached activity feeds of the author's followers if the reports hid the user review
+	if hidden {
+		s.invalidateFollowerFeeds(ctx, request.GetUserId())
+	}
+	return &v1.ReportReviewResponse{}, nil
+}
+
+// ReportUserMovieReviewComment reports another user's comment on a movie review, hiding it once enough distinct users
+// reported it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the report user movie review comment request
+//
+// Returns:
+//
+// - *v1.ReportUserMovieReviewCommentResponse: the report user movie review comment response
+// - error: if there was an error reporting the user movie review comment
+func (s *Service) ReportUserMovieReviewComment(
+	ctx context.Context,
+	request *v1.ReportUserMovieReviewCommentRequest,
+) (*v1.ReportUserMovieReviewCommentResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Map the reason
+	reason := MapToReportReasonCode(request.GetReason())
+	if reason == "" {
+		return nil, ConnErrInvalidReportReason
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Get the user review and the author of the comment
+			var (
+				outReviewUserID  string
+				outMovieID       int32
+				outCommentUserID string
+			)
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.GetVisibleUserReviewCommentQuery,
+				request.GetCommentId(),
+			).Scan(&outReviewUserID, &outMovieID, &outCommentUserID); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return ConnErrUserReviewCommentNotFound
+				}
+				return queryErr
+			}
+
+			// Check if the user is reporting their own comment
+			if userID == outCommentUserID {
+				return ConnErrCannotReportOwnContent
+			}
+
+			// Insert the report, ignoring repeated reports of the same user
+			commandTag, execErr := tx.Exec(
+				ctx,
+				internalpostgres.InsertUserReviewCommentReportQuery,
+				userID,
+				outReviewUserID,
+				outMovieID,
+				request.GetCommentId(),
+				reason,
+				request.Details,
+			)
+			if execErr != nil || commandTag.RowsAffected() == 0 {
+				return execErr
+			}
+
+			// Count the distinct users that reported the comment
+			var reportersCount int
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.CountOpenUserReviewCommentReportsQuery,
+				request.GetCommentId(),
+			).Scan(&reportersCount); queryErr != nil {
+				return queryErr
+			}
+			if reportersCount < internalmoderation.ReportsHideThreshold {
+				return nil
+			}
+
+			// Hide the comment
+			_, execErr = tx.Exec(ctx, internalpostgres.HideUserReviewCommentQuery, request.GetCommentId())
+			return execErr
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &v1.ReportUserMovieReviewCommentResponse{}, nil
+}
+
+// ListContentReports lists a page of the open reports, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list content reports request
+//
+// Returns:
+//
+// - *v1.ListContentReportsResponse: the list content reports response
+// - error: if there was an error listing the content reports
+func (s *Service) ListContentReports(
+	ctx context.Context,
+	request *v1.ListContentReportsRequest,
+) (*v1.ListContentReportsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	if _, err := requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	// Query the open reports of the page
+	page := max(request.GetPage(), 1)
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListOpenContentReportsQuery,
+		ReportsPageSize,
+		(page-1)*ReportsPageSize,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var totalResults int32
+	reports := make([]*v1.ContentReport, 0, ReportsPageSize)
+	for rows.Next() {
+		var (
+			outID             int64
+			outReporterUserID string
+			outReviewUserID   string
+			outMovieID        int32
+			outCommentID      sql.NullInt64
+			outReason         string
+			outDetails        sql.NullString
+			outCreatedAt      sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&outID,
+			&outReporterUserID,
+			&outReviewUserID,
+			&outMovieID,
+			&outCommentID,
+			&outReason,
+			&outDetails,
+			&outCreatedAt,
+			&totalResults,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+
+		report := &v1.ContentReport{
+			Id:             outID,
+			ReporterUserId: outReporterUserID,
+			ReviewUserId:   outReviewUserID,
+			MovieId:        outMovieID,
+			Reason:         MapToReportReason(outReason),
+			Details:        outDetails.String,
+			CreatedAt:      MapToOptionalTimestamp(outCreatedAt),
+		}
+		if outCommentID.Valid {
+			report.CommentId = &outCommentID.Int64
+		}
+		reports = append(reports, report)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListContentReportsResponse{
+		Page:         page,
+		Results:      reports,
+		TotalPages:   (totalResults + ReportsPageSize - 1) / ReportsPageSize,
+		TotalResults: totalResults,
+	}, nil
+}
+
+// ResolveContentReport resolves an open report together with every other open report on the same review or comment,
+// either dismissing them and restoring the reported content, or upholding them and removing it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the resolve content report request
+//
+// Returns:
+//
+// - *v1.ResolveContentReportResponse: the resolve content report response
+// - error: if there was an error resolving the content report
+func (s *Service) ResolveContentReport(
+	ctx context.Context,
+	request *v1.ResolveContentReportRequest,
+) (*v1.ResolveContentReportResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin or a moderator
+	staffUserID, err := requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the resolution to the reports status
+	var status string
+	switch request.GetResolution() {
+	case v1.ReportResolution_DISMISS:
+		status = ReportStatusDismissed
+	case v1.ReportResolution_UPHOLD:
+		status = ReportStatusUpheld
+	default:
+		return nil, ConnErrInvalidReportResolution
+	}
+
+	var (
+		resolvedReportsCount int64
+		reviewUserID         string
+	)
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Get the reported user review and comment
+			var (
+				outReviewUserID string
+				outMovieID      int32
+				outCommentID    sql.NullInt64
+			)
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.GetOpenContentReportTargetQuery,
+				request.GetReportId(),
+			).Scan(&outReviewUserID, &outMovieID, &outCommentID); queryErr != nil {
+				if errors.Is(queryErr, pgx.ErrNoRows) {
+					return ConnErrContentReportNotFound
+				}
+				return queryErr
+			}
+
+			// Resolve the reports and apply the resolution to the reported content
+			var resolveErr error
+			if outCommentID.Valid {
+				resolvedReportsCount, resolveErr = resolveUserReviewCommentReports(
+					ctx,
+					tx,
+					outCommentID.Int64,
+					status,
+					staffUserID,
+				)
+			} else {
+				reviewUserID = outReviewUserID
+				resolvedReportsCount, resolveErr = resolveUserReviewReports(
+					ctx,
+					tx,
+					outReviewUserID,
+					outMovieID,
+					status,
+					staffUserID,
+					request.Note,
+				)
+			}
+			if resolveErr != nil {
+				return resolveErr
+			}
+
+			// Record the resolution in the admin audit log
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				staffUserID,
+				AdminActionResolveContentReport,
+				fmt.Sprintf(ContentReportTarget, request.GetReportId()),
+				map[string]any{
+					"status":                 status,
+					"resolved_reports_count": resolvedReportsCount,
+					"note":                   request.Note,
+				},
+			)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the cached activity feeds of the author's followers if the reports on a user review were resolved,
+	// so a rejected review is removed from them
+	if reviewUserID != "" {
+		s.invalidateFollowerFeeds(ctx, reviewUserID)
+	}
+
+	return &v1.ResolveContentReportResponse{ResolvedReportsCount: int32(resolvedReportsCount)}, nil
+}
+
+// resolveUserReviewReports resolves every open report on a user review, publishing the review again if the dismissed
+// reports hid it or rejecting it if they were upheld, and recording the decision in the moderation audit trail
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - reviewUserID: the ID of the user that wrote the review
+// - movieID: the ID of the reviewed movie
+// - status: the new reports status
+// - staffUserID: the ID of the staff user resolving the reports
+// - note: the note of the staff user (optional)
+//
+// Returns:
+//
+// - int64: the number of resolved reports
+// - error: if there was an error resolving the reports
+func resolveUserReviewReports(
+	ctx context.Context,
+	tx pgx.Tx,
+	reviewUserID string,
+	movieID int32,
+	status string,
+	staffUserID string,
+	note *string,
+) (int64, error) {
+	// Resolve the open reports
+	commandTag, err := tx.Exec(
+		ctx,
+		internalpostgres.ResolveUserReviewReportsQuery,
+		reviewUserID,
+		movieID,
+		status,
+		staffUserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Publish the user review again if the reports hid it, or reject it. A review flagged by the moderation rules stays
+	// pending until staff approve it
+	query, action := internalpostgres.ReleaseReportedUserReviewQuery, ModerationActionApproved
+	moderationStatus := ModerationStatusPublished
+	if status == ReportStatusUpheld {
+		query, action = internalpostgres.RejectUserReviewQuery, ModerationActionRejected
+		moderationStatus = ModerationStatusRejected
+	}
+	var (
+		outReviewText sql.NullString
+		outReasons    []string
+	)
+	if err = tx.QueryRow(ctx, query, reviewUserID, movieID).Scan(&outReviewText, &outReasons); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return commandTag.RowsAffected(), nil
+		}
+		return 0, err
+	}
+
+	// Write the moderated event to the outbox and record the decision in the audit trail
+	if err = writeUserReviewModeratedEvent(ctx, tx, reviewUserID, movieID, moderationStatus); err != nil {
+		return 0, err
+	}
+	if _, err = tx.Exec(
+		ctx,
+		internalpostgres.InsertUserReviewModerationAuditQuery,
+		reviewUserID,
+		movieID,
+		action,
+		staffUserID,
+		outReasons,
+		note,
+		outReviewText,
+	); err != nil {
+		return 0, err
+	}
+	return commandTag.RowsAffected(), nil
+}
+
+// resolveUserReviewCommentReports resolves every open report on a comment of a user review, making the comment
+// visible again if the reports were dismissed or removing it if they were upheld
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - commentID: the ID of the comment
+// - status: the new reports status
+// - staffUserID: the ID of the staff user resolving the reports
+//
+// Returns:
+//
+// - int64: the number of resolved reports
+// - error: if there was an error resolving the reports
+func resolveUserReviewCommentReports(
+	ctx context.Context,
+	tx pgx.Tx,
+	commentID int64,
+	status string,
+	staffUserID string,
+) (int64, error) {
+	// Resolve the open reports
+	commandTag, err := tx.Exec(
+		ctx,
+		internalpostgres.ResolveUserReviewCommentReportsQuery,
+		commentID,
+		status,
+		staffUserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Make the comment visible again, or remove it
+	query := internalpostgres.UnhideUserReviewCommentQuery
+	if status == ReportStatusUpheld {
+		query = internalpostgres.RemoveUserReviewCommentQuery
+	}
+	if _, err = tx.Exec(ctx, query, commentID); err != nil {
+		return 0, err
+	}
+	return commandTag.RowsAffected(), nil
+}
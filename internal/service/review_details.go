@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// userReviewDetailsRequest is implemented by the requests that write the details of a user review
+	userReviewDetailsRequest interface {
+		GetReview() string
+		GetContainsSpoilers() bool
+		GetSpoilerSpans() []*v1.SpoilerSpan
+		GetTags() []string
+		GetWatchedOn() *timestamppb.Timestamp
+	}
+
+	// userReviewDetails are the validated details of a user review, besides its rating and text
+	userReviewDetails struct {
+		containsSpoilers bool
+		spoilerSpans     []SpoilerSpan
+		tags             []string
+		watchedOn        *time.Time
+	}
+)
+
+// validateUserReviewTags normalizes and validates the tags of a user review. Tags are trimmed, lowercased and
+// deduplicated, keeping their order
+//
+// Parameters:
+//
+// - tags: the tags to validate
+//
+// Returns:
+//
+// - []string: the normalized tags
+// - error: if there are too many tags, or a tag is empty or too long
+func validateUserReviewTags(tags []string) ([]string, error) {
+	normalizedTags := make([]string, 0, len(tags))
+	seenTags := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > UserReviewTagMaxLength {
+			return nil, ConnErrInvalidUserReviewTags
+		}
+		if _, ok := seenTags[tag]; ok {
+			continue
+		}
+		seenTags[tag] = struct{}{}
+		normalizedTags = append(normalizedTags, tag)
+	}
+	if len(normalizedTags) > UserReviewMaxTags {
+		return nil, ConnErrInvalidUserReviewTags
+	}
+	return normalizedTags, nil
+}
+
+// validateSpoilerSpans validates the inline spoilers of a user review. The spans must be sorted, must not overlap
+// and must be within the review text
+//
+// Parameters:
+//
+// - review: the review text
+// - spans: the spoiler spans to validate
+//
+// Returns:
+//
+// - []SpoilerSpan: the spoiler spans to store
+// - error: if there are too many spans or a span is not valid
+func validateSpoilerSpans(review string, spans []*v1.SpoilerSpan) ([]SpoilerSpan, error) {
+	if len(spans) > UserReviewMaxSpoilerSpans {
+		return nil, ConnErrInvalidSpoilerSpans
+	}
+
+	reviewLength := int32(utf8.RuneCountInString(review))
+	validatedSpans := make([]SpoilerSpan, 0, len(spans))
+	var previousEnd int32
+	for _, span := range spans {
+		if span.GetStart() < previousEnd || span.GetStart() >= span.GetEnd() || span.GetEnd() > reviewLength {
+			return nil, ConnErrInvalidSpoilerSpans
+		}
+		previousEnd = span.GetEnd()
+		validatedSpans = append(validatedSpans, SpoilerSpan{Start: span.GetStart(), End: span.GetEnd()})
+	}
+	return validatedSpans, nil
+}
+
+// validateUserReviewDetails validates the spoiler marking, the tags and the watched on date of a user review
+//
+// Parameters:
+//
+// - request: the request that writes the user review
+//
+// Returns:
+//
+// - *userReviewDetails: the validated details
+// - error: if any of the details is not valid
+func validateUserReviewDetails(request userReviewDetailsRequest) (*userReviewDetails, error) {
+	spoilerSpans, err := validateSpoilerSpans(request.GetReview(), request.GetSpoilerSpans())
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := validateUserReviewTags(request.GetTags())
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the watched on date is not in the future
+	var watchedOn *time.Time
+	if request.GetWatchedOn() != nil {
+		watchedOnTime := request.GetWatchedOn().AsTime()
+		if watchedOnTime.After(time.Now().Add(WatchedOnMaxFutureSkew)) {
+			return nil, ConnErrInvalidWatchedOn
+		}
+		watchedOn = &watchedOnTime
+	}
+
+	return &userReviewDetails{
+		containsSpoilers: request.GetContainsSpoilers(),
+		spoilerSpans:     spoilerSpans,
+		tags:             tags,
+		watchedOn:        watchedOn,
+	}, nil
+}
+
+// setUserReviewDetails sets the spoiler marking, the tags and the watched on date of a user review
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - userID: the ID of the user that wrote the review
+// - movieID: the ID of the movie
+// - details: the validated details
+//
+// Returns:
+//
+// - error: if there was an error setting the details
+func setUserReviewDetails(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	movieID int32,
+	details *userReviewDetails,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		internalpostgres.SetUserReviewDetailsQuery,
+		userID,
+		movieID,
+		details.containsSpoilers,
+		details.spoilerSpans,
+		details.tags,
+		details.watchedOn,
+	)
+	return err
+}
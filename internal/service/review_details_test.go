@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+)
+
+func TestValidateUserReviewTags(t *testing.T) {
+	tooManyTags := make([]string, 0, UserReviewMaxTags+1)
+	for i := range UserReviewMaxTags + 1 {
+		tooManyTags = append(tooManyTags, fmt.Sprintf("tag%d", i))
+	}
+
+	for _, test := range []struct {
+		name     string
+		tags     []string
+		wantTags []string
+		wantErr  bool
+	}{
+		{"no tags", nil, []string{}, false},
+		{"normalized", []string{"  Rewatch ", "SCI-FI"}, []string{"rewatch", "sci-fi"}, false},
+		{"deduplicated in order", []string{"b", "a", "B", " a"}, []string{"b", "a"}, false},
+		{
+			"duplicates over the limit",
+			append(tooManyTags[:UserReviewMaxTags:UserReviewMaxTags], "TAG0"),
+			tooManyTags[:UserReviewMaxTags],
+			false,
+		},
+		{"empty tag", []string{"rewatch", "  "}, nil, true},
+		{"tag at the maximum length", []string{strings.Repeat("é", UserReviewTagMaxLength)}, nil, false},
+		{"tag too long", []string{strings.Repeat("a", UserReviewTagMaxLength+1)}, nil, true},
+		{"too many tags", tooManyTags, nil, true},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				tags, err := validateUserReviewTags(test.tags)
+				if test.wantErr {
+					if !errors.Is(err, ConnErrInvalidUserReviewTags) {
+						t.Errorf("validateUserReviewTags() error = %v, want %v", err, ConnErrInvalidUserReviewTags)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("validateUserReviewTags() error = %v", err)
+				}
+				if test.wantTags != nil && !reflect.DeepEqual(tags, test.wantTags) {
+					t.Errorf("validateUserReviewTags() = %q, want %q", tags, test.wantTags)
+				}
+			},
+		)
+	}
+}
+
+func TestValidateSpoilerSpans(t *testing.T) {
+	// The spans count characters, not bytes
+	review := "Ñandú dies at the end"
+
+	tooManySpans := make([]*v1.SpoilerSpan, 0, UserReviewMaxSpoilerSpans+1)
+	for i := range int32(UserReviewMaxSpoilerSpans + 1) {
+		tooManySpans = append(tooManySpans, &v1.SpoilerSpan{Start: i, End: i + 1})
+	}
+
+	for _, test := range []struct {
+		name      string
+		spans     []*v1.SpoilerSpan
+		wantSpans []SpoilerSpan
+		wantErr   bool
+	}{
+		{"no spans", nil, []SpoilerSpan{}, false},
+		{
+			"sorted spans",
+			[]*v1.SpoilerSpan{{Start: 0, End: 5}, {Start: 6, End: 21}},
+			[]SpoilerSpan{{Start: 0, End: 5}, {Start: 6, End: 21}},
+			false,
+		},
+		{
+			"adjacent spans",
+			[]*v1.SpoilerSpan{{Start: 0, End: 5}, {Start: 5, End: 10}},
+			[]SpoilerSpan{{Start: 0, End: 5}, {Start: 5, End: 10}},
+			false,
+		},
+		{"empty span", []*v1.SpoilerSpan{{Start: 3, End: 3}}, nil, true},
+		{"reversed span", []*v1.SpoilerSpan{{Start: 5, End: 2}}, nil, true},
+		{"span past the review", []*v1.SpoilerSpan{{Start: 6, End: 22}}, nil, true},
+		{"overlapping spans", []*v1.SpoilerSpan{{Start: 0, End: 6}, {Start: 5, End: 10}}, nil, true},
+		{"unsorted spans", []*v1.SpoilerSpan{{Start: 6, End: 10}, {Start: 0, End: 5}}, nil, true},
+		{"too many spans", tooManySpans, nil, true},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				spans, err := validateSpoilerSpans(review, test.spans)
+				if test.wantErr {
+					if !errors.Is(err, ConnErrInvalidSpoilerSpans) {
+						t.Errorf("validateSpoilerSpans() error = %v, want %v", err, ConnErrInvalidSpoilerSpans)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("validateSpoilerSpans() error = %v", err)
+				}
+				if !reflect.DeepEqual(spans, test.wantSpans) {
+					t.Errorf("validateSpoilerSpans() = %+v, want %+v", spans, test.wantSpans)
+				}
+			},
+		)
+	}
+}
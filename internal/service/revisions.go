@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// ListUserMovieReviewRevisions lists the previous versions of a user movie review, from the newest to the oldest one.
+// Users can list the revisions of their own reviews, while admins and moderators can list the revisions of any review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list user movie review revisions request
+//
+// Returns:
+//
+// - *v1.ListUserMovieReviewRevisionsResponse: the list user movie review revisions response
+// - error: if there was an error listing the user movie review revisions
+func (s *Service) ListUserMovieReviewRevisions(
+	ctx context.Context,
+	request *v1.ListUserMovieReviewRevisionsRequest,
+) (*v1.ListUserMovieReviewRevisionsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Check if the authenticated user is an admin or a moderator when listing the revisions of another user's review,
+	// who can also see the revisions archived when the staff deleted the review
+	reviewUserID := userID
+	includeArchived := false
+	if request.UserId != nil {
+		sameUser, sameUserErr := isSameUser(userID, request.GetUserId())
+		if sameUserErr != nil {
+			return nil, sameUserErr
+		}
+		if !sameUser {
+			if _, err = requireStaff(ctx); err != nil {
+				return nil, err
+			}
+			reviewUserID = request.GetUserId()
+			includeArchived = true
+		}
+	}
+
+	// Query the revisions of the user review
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListUserReviewRevisionsQuery,
+		reviewUserID,
+		request.GetId(),
+		includeArchived,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	revisions := make([]*v1.UserMovieReviewRevision, 0)
+	for rows.Next() {
+		var (
+			outVersion          int64
+			outRating           int32
+			outReviewText       sql.NullString
+			outWrittenAt        sql.NullTime
+			outReplacedAt       sql.NullTime
+			outContainsSpoilers bool
+			outSpoilerSpans     []SpoilerSpan
+			outTags             []string
+			outWatchedOn        sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&outVersion,
+			&outRating,
+			&outReviewText,
+			&outWrittenAt,
+			&outReplacedAt,
+			&outContainsSpoilers,
+			&outSpoilerSpans,
+			&outTags,
+			&outWatchedOn,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		revisions = append(
+			revisions, &v1.UserMovieReviewRevision{
+				Version:          outVersion,
+				Rating:           outRating,
+				Review:           outReviewText.String,
+				WrittenAt:        MapToOptionalTimestamp(outWrittenAt),
+				ReplacedAt:       MapToOptionalTimestamp(outReplacedAt),
+				ContainsSpoilers: outContainsSpoilers,
+				SpoilerSpans:     MapToSpoilerSpans(outSpoilerSpans),
+				Tags:             outTags,
+				WatchedOn:        MapToOptionalDate(outWatchedOn),
+			},
+		)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListUserMovieReviewRevisionsResponse{Revisions: revisions}, nil
+}
@@ -3,27 +3,47 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	godatabases "github.com/ralvarezdev/go-databases"
 	godatabasespgx "github.com/ralvarezdev/go-databases/sql/pgx"
+	godatabasespgxpool "github.com/ralvarezdev/go-databases/sql/pgxpool"
 	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
 	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
 	redisauthtypes "github.com/ralvarezdev/redis-auth-types-go"
 	sqlmovies "github.com/ralvarezdev/sql-movies/go"
+	"google.golang.org/protobuf/proto"
 
 	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
 
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalcommunity "github.com/ralvarezdev/connect-movies/internal/community"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internalmoderation "github.com/ralvarezdev/connect-movies/internal/moderation"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
 	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+	internaluserdata "github.com/ralvarezdev/connect-movies/internal/userdata"
 )
 
 type (
 	// Service is the service for the gRPC server
 	Service struct {
-		tmdbClient           *gotmdbapi.Client
+		tmdbClient           *internaltmdb.Client
 		pool                 *pgxpool.Pool
 		redisUsernameHandler *redisauthtypes.UsernameHandler
+		cache                *internalcache.Cache
+		leaderboard          *internalcommunity.Leaderboard
+		moderationEngine     *internalmoderation.Engine
+		userDataManager      *internaluserdata.Manager
+		startedAt            time.Time
 	}
 )
 
@@ -34,15 +54,23 @@ type (
 // - tmdbClient: the TMDB API client
 // - pool: the Postgres connection pool
 // - redisUsernameHandler: the Redis username handler
+// - cache: the Redis cache
+// - leaderboard: the community leaderboard
+// - moderationEngine: the moderation rules engine
+// - userDataManager: the user data manager
 //
 // Returns:
 //
 // - *Service: the service
 // - error: if there was an error creating the service
 func NewService(
-	tmdbClient *gotmdbapi.Client,
+	tmdbClient *internaltmdb.Client,
 	pool *pgxpool.Pool,
 	redisUsernameHandler *redisauthtypes.UsernameHandler,
+	cache *internalcache.Cache,
+	leaderboard *internalcommunity.Leaderboard,
+	moderationEngine *internalmoderation.Engine,
+	userDataManager *internaluserdata.Manager,
 ) (*Service, error) {
 	// Check if the Postgres pool is nil
 	if pool == nil {
@@ -59,13 +87,96 @@ func NewService(
 		return nil, gotmdbapi.ErrNilClient
 	}
 
+	// Check if the cache is nil
+	if cache == nil {
+		return nil, internalcache.ErrNilCache
+	}
+
+	// Check if the community leaderboard is nil
+	if leaderboard == nil {
+		return nil, internalcommunity.ErrNilLeaderboard
+	}
+
+	// Check if the moderation engine is nil
+	if moderationEngine == nil {
+		return nil, internalmoderation.ErrNilEngine
+	}
+
+	// Check if the user data manager is nil
+	if userDataManager == nil {
+		return nil, internaluserdata.ErrNilManager
+	}
+
 	return &Service{
 		tmdbClient:           tmdbClient,
 		pool:                 pool,
 		redisUsernameHandler: redisUsernameHandler,
+		cache:                cache,
+		leaderboard:          leaderboard,
+		moderationEngine:     moderationEngine,
+		userDataManager:      userDataManager,
+		startedAt:            time.Now(),
 	}, nil
 }
 
+// getCachedOrFetch gets a response from the cache or, on a cache miss, fetches it and caches it
+//
+// Parameters:
+//
+// - ctx: the context
+// - cache: the Redis cache
+// - key: the cache key
+// - ttl: the time to live of the cached response
+// - cached: the empty response to unmarshal the cached value into
+// - fetch: the function to fetch the response on a cache miss
+//
+// Returns:
+//
+// - T: the cached or fetched response
+func getCachedOrFetch[T proto.Message](
+	ctx context.Context,
+	cache *internalcache.Cache,
+	key string,
+	ttl time.Duration,
+	cached T,
+	fetch func() T,
+) T {
+	// Check if the response is cached
+	if cache.Get(ctx, key, cached) {
+		return cached
+	}
+
+	// Fetch and cache the response
+	response := fetch()
+	cache.Set(ctx, key, response, ttl)
+	return response
+}
+
+// runInTransaction runs the given function in a Postgres transaction. Connect errors returned by the function are
+// returned as is, while any other error is unexpected and panics, like the queries run outside transactions
+//
+// Parameters:
+//
+// - ctx: the context
+// - fn: the function to run in the transaction
+//
+// Returns:
+//
+// - error: the connect error returned by the function, if any
+func (s *Service) runInTransaction(ctx context.Context, fn godatabasespgxpool.TransactionFn) error {
+	err := godatabasespgxpool.CreateTransaction(ctx, s.pool, fn)
+	if err == nil {
+		return nil
+	}
+
+	// Check if the error is an expected connect error
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
+	}
+	panic(err)
+}
+
 // GetMovieCredits gets the movie credits
 //
 // Parameters:
@@ -117,19 +228,33 @@ func (s *Service) GetTopRatedMovies(
 		panic(ErrNilService)
 	}
 
-	// Call TMDB API to get top rated movies
-	apiResponse, _, err := s.tmdbClient.GetMoviesTopRated(
+	return getCachedOrFetch(
 		ctx,
-		request.GetLanguage(),
-		request.GetPage(),
-		request.GetRegion(),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	// Map TMDB API response to gRPC response
-	return internaltmdb.MapToGetTopRatedMoviesResponse(apiResponse), nil
+		s.cache,
+		fmt.Sprintf(
+			internalcache.MovieListKey,
+			internalcache.TopRatedMovieList,
+			request.GetLanguage(),
+			request.GetRegion(),
+			request.GetPage(),
+		),
+		internalcache.MovieListsTTL,
+		&v1.GetTopRatedMoviesResponse{},
+		func() *v1.GetTopRatedMoviesResponse {
+			// Call TMDB API to get top rated movies
+			apiResponse, _, err := s.tmdbClient.GetMoviesTopRated(
+				ctx,
+				request.GetLanguage(),
+				request.GetPage(),
+				request.GetRegion(),
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return internaltmdb.MapToGetTopRatedMoviesResponse(apiResponse)
+		},
+	), nil
 }
 
 // GetPopularMovies gets the popular movies
@@ -151,18 +276,33 @@ func (s *Service) GetPopularMovies(
 		panic(ErrNilService)
 	}
 
-	// Call TMDB API to get popular movies
-	apiResponse, _, err := s.tmdbClient.GetMoviesPopular(
+	return getCachedOrFetch(
 		ctx,
-		request.GetLanguage(),
-		request.GetPage(),
-		request.GetRegion(),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	return internaltmdb.MapToGetPopularMoviesResponse(apiResponse), nil
+		s.cache,
+		fmt.Sprintf(
+			internalcache.MovieListKey,
+			internalcache.PopularMovieList,
+			request.GetLanguage(),
+			request.GetRegion(),
+			request.GetPage(),
+		),
+		internalcache.MovieListsTTL,
+		&v1.GetPopularMoviesResponse{},
+		func() *v1.GetPopularMoviesResponse {
+			// Call TMDB API to get popular movies
+			apiResponse, _, err := s.tmdbClient.GetMoviesPopular(
+				ctx,
+				request.GetLanguage(),
+				request.GetPage(),
+				request.GetRegion(),
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return internaltmdb.MapToGetPopularMoviesResponse(apiResponse)
+		},
+	), nil
 }
 
 // GetNowPlayingMovies gets the now playing movies
@@ -184,18 +324,33 @@ func (s *Service) GetNowPlayingMovies(
 		panic(ErrNilService)
 	}
 
-	// Call TMDB API to get now playing movies
-	apiResponse, _, err := s.tmdbClient.GetMoviesNowPlaying(
+	return getCachedOrFetch(
 		ctx,
-		request.GetLanguage(),
-		request.GetPage(),
-		request.GetRegion(),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	return internaltmdb.MapToGetNowPlayingMoviesResponse(apiResponse), nil
+		s.cache,
+		fmt.Sprintf(
+			internalcache.MovieListKey,
+			internalcache.NowPlayingMovieList,
+			request.GetLanguage(),
+			request.GetRegion(),
+			request.GetPage(),
+		),
+		internalcache.MovieListsTTL,
+		&v1.GetNowPlayingMoviesResponse{},
+		func() *v1.GetNowPlayingMoviesResponse {
+			// Call TMDB API to get now playing movies
+			apiResponse, _, err := s.tmdbClient.GetMoviesNowPlaying(
+				ctx,
+				request.GetLanguage(),
+				request.GetPage(),
+				request.GetRegion(),
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return internaltmdb.MapToGetNowPlayingMoviesResponse(apiResponse)
+		},
+	), nil
 }
 
 // GetUpcomingMovies gets the upcoming movies
@@ -217,18 +372,33 @@ func (s *Service) GetUpcomingMovies(
 		panic(ErrNilService)
 	}
 
-	// Call TMDB API to get upcoming movies
-	apiResponse, _, err := s.tmdbClient.GetMoviesUpcoming(
+	return getCachedOrFetch(
 		ctx,
-		request.GetLanguage(),
-		request.GetPage(),
-		request.GetRegion(),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	return internaltmdb.MapToGetUpcomingMoviesResponse(apiResponse), nil
+		s.cache,
+		fmt.Sprintf(
+			internalcache.MovieListKey,
+			internalcache.UpcomingMovieList,
+			request.GetLanguage(),
+			request.GetRegion(),
+			request.GetPage(),
+		),
+		internalcache.MovieListsTTL,
+		&v1.GetUpcomingMoviesResponse{},
+		func() *v1.GetUpcomingMoviesResponse {
+			// Call TMDB API to get upcoming movies
+			apiResponse, _, err := s.tmdbClient.GetMoviesUpcoming(
+				ctx,
+				request.GetLanguage(),
+				request.GetPage(),
+				request.GetRegion(),
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return internaltmdb.MapToGetUpcomingMoviesResponse(apiResponse)
+		},
+	), nil
 }
 
 // SimilarMovies maps similar movies
@@ -301,7 +471,8 @@ func (s *Service) SearchMovies(ctx context.Context, request *v1.SearchMoviesRequ
 	return internaltmdb.MapToSearchMoviesResponse(apiResponse), nil
 }
 
-// GetMovieDetails gets the movie details
+// GetMovieDetails gets the movie details, including the requested sections (credits, videos, images, release dates and
+// similar movies) from a single TMDB API call
 //
 // Parameters:
 //
@@ -320,8 +491,28 @@ func (s *Service) GetMovieDetails(
 		panic(ErrNilService)
 	}
 
-	// Call TMDB API to get movie details
-	apiResponse, statusCode, err := s.tmdbClient.GetMovieDetails(ctx, request.GetId(), request.GetLanguage())
+	// Map the includes to the TMDB API append to response values
+	appendToResponse := internaltmdb.MapToAppendToResponse(request.GetInclude())
+	if len(appendToResponse) == 0 {
+		// Call TMDB API to get movie details
+		apiResponse, statusCode, err := s.tmdbClient.GetMovieDetails(ctx, request.GetId(), request.GetLanguage())
+		if err != nil {
+			if statusCode == http.StatusNotFound {
+				return nil, ConnErrMovieNotFound
+			}
+			panic(err)
+		}
+
+		return internaltmdb.MapToGetMovieDetailsResponse(apiResponse), nil
+	}
+
+	// Call TMDB API to get movie details with the included sections in a single request
+	apiResponse, statusCode, err := s.tmdbClient.GetMovieDetailsWithAppend(
+		ctx,
+		request.GetId(),
+		request.GetLanguage(),
+		appendToResponse,
+	)
 	if err != nil {
 		if statusCode == http.StatusNotFound {
 			return nil, ConnErrMovieNotFound
@@ -329,7 +520,7 @@ func (s *Service) GetMovieDetails(
 		panic(err)
 	}
 
-	return internaltmdb.MapToGetMovieDetailsResponse(apiResponse), nil
+	return internaltmdb.MapToGetMovieDetailsAppendResponse(apiResponse), nil
 }
 
 // GetMovieGenres gets the movie genres
@@ -437,6 +628,105 @@ func (s *Service) DiscoverMovies(
 	return internaltmdb.MapToDiscoverMoviesResponse(apiResponse), nil
 }
 
+// GetMovieWatchProviders gets the watch providers of a movie for a region
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get movie watch providers request
+//
+// Returns:
+//
+// - *v1.GetMovieWatchProvidersResponse: the get movie watch providers response
+// - error: if there was an error getting the movie watch providers
+func (s *Service) GetMovieWatchProviders(
+	ctx context.Context,
+	request *v1.GetMovieWatchProvidersRequest,
+) (*v1.GetMovieWatchProvidersResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get movie watch providers
+	apiResponse, statusCode, err := s.tmdbClient.GetMovieWatchProviders(ctx, request.GetId())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, ConnErrMovieNotFound
+		}
+		panic(err)
+	}
+
+	return internaltmdb.MapToGetMovieWatchProvidersResponse(apiResponse, request.GetRegion()), nil
+}
+
+// ListWatchProviderRegions lists the regions that have watch provider data
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list watch provider regions request
+//
+// Returns:
+//
+// - *v1.ListWatchProviderRegionsResponse: the list watch provider regions response
+// - error: if there was an error listing the watch provider regions
+func (s *Service) ListWatchProviderRegions(
+	ctx context.Context,
+	request *v1.ListWatchProviderRegionsRequest,
+) (*v1.ListWatchProviderRegionsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get watch provider regions
+	apiResponse, _, err := s.tmdbClient.GetWatchProviderRegions(ctx, request.GetLanguage())
+	if err != nil {
+		panic(err)
+	}
+
+	return internaltmdb.MapToListWatchProviderRegionsResponse(apiResponse), nil
+}
+
+// GetMovieVideos gets the movie videos, falling back to English when the requested language has no results
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get movie videos request
+//
+// Returns:
+//
+// - *v1.GetMovieVideosResponse: the get movie videos response
+// - error: if there was an error getting the movie videos
+func (s *Service) GetMovieVideos(
+	ctx context.Context,
+	request *v1.GetMovieVideosRequest,
+) (*v1.GetMovieVideosResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get movie videos
+	apiResponse, statusCode, err := s.tmdbClient.GetMovieVideos(ctx, request.GetId(), request.GetLanguage())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, ConnErrMovieNotFound
+		}
+		panic(err)
+	}
+
+	// Fall back to English if there are no videos in the requested language
+	language := request.GetLanguage()
+	if len(apiResponse.Results) == 0 && language != "" && !strings.HasPrefix(language, "en") {
+		apiResponse, _, err = s.tmdbClient.GetMovieVideos(ctx, request.GetId(), internaltmdb.FallbackLanguage)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return internaltmdb.MapToGetMovieVideosResponse(apiResponse), nil
+}
+
 // GetMovieReviews gets the movie reviews
 //
 // Parameters:
@@ -470,9 +760,117 @@ func (s *Service) GetMovieReviews(
 		panic(err)
 	}
 
-	// TODO: Add user reviews too
+	// Map TMDB API response to gRPC response and add the user reviews
+	response := internaltmdb.MapToGetMovieReviewsResponse(apiResponse)
+	response.UserReviews, response.UserReviewsTotalResults = s.listMovieUserReviews(
+		ctx,
+		request.GetId(),
+		request.GetUserReviewsSortBy(),
+		request.GetHideSpoilers(),
+		request.GetPage(),
+	)
+	return response, nil
+}
+
+// listMovieUserReviews lists a page of the user reviews of a movie
+//
+// Parameters:
+//
+// - ctx: the context
+// - movieID: the ID of the movie
+// - sortBy: the user reviews sort order
+// - hideSpoilers: whether to skip the user reviews flagged as spoilers
+// - page: the page number, starting at 1
+//
+// Returns:
+//
+// - []*v1.UserMovieReview: the user reviews of the page
+// - int32: the total number of user reviews of the movie
+func (s *Service) listMovieUserReviews(
+	ctx context.Context,
+	movieID int32,
+	sortBy v1.UserReviewsSortBy,
+	hideSpoilers bool,
+	page int32,
+) ([]*v1.UserMovieReview, int32) {
+	// Map the sort order to the order by clause
+	var orderBy string
+	switch sortBy {
+	case v1.UserReviewsSortBy_MOST_HELPFUL:
+		orderBy = internalpostgres.UserReviewsOrderByMostHelpful
+	case v1.UserReviewsSortBy_MOST_LIKED:
+		orderBy = internalpostgres.UserReviewsOrderByMostLiked
+	default:
+		orderBy = internalpostgres.UserReviewsOrderByNewest
+	}
 
-	return internaltmdb.MapToGetMovieReviewsResponse(apiResponse), nil
+	// Query the user reviews of the page
+	page = max(page, 1)
+	rows, err := s.pool.Query(
+		ctx,
+		fmt.Sprintf(internalpostgres.ListMovieUserReviewsQuery, orderBy),
+		movieID,
+		UserReviewsPageSize,
+		(page-1)*UserReviewsPageSize,
+		hideSpoilers,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var totalResults int32
+	userReviews := make([]*v1.UserMovieReview, 0, UserReviewsPageSize)
+	for rows.Next() {
+		var (
+			outUserID           string
+			outRating           sql.NullInt32
+			outReviewText       sql.NullString
+			outLikesCount       int32
+			outHelpfulCount     int32
+			outCreatedAt        sql.NullTime
+			outUpdatedAt        sql.NullTime
+			outContainsSpoilers bool
+			outSpoilerSpans     []SpoilerSpan
+			outTags             []string
+			outWatchedOn        sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&outUserID,
+			&outRating,
+			&outReviewText,
+			&outLikesCount,
+			&outHelpfulCount,
+			&outCreatedAt,
+			&outUpdatedAt,
+			&outContainsSpoilers,
+			&outSpoilerSpans,
+			&outTags,
+			&outWatchedOn,
+			&totalResults,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		userReviews = append(
+			userReviews, &v1.UserMovieReview{
+				UserId:           outUserID,
+				Rating:           outRating.Int32,
+				Review:           outReviewText.String,
+				LikesCount:       outLikesCount,
+				HelpfulCount:     outHelpfulCount,
+				CreatedAt:        MapToOptionalTimestamp(outCreatedAt),
+				UpdatedAt:        MapToOptionalTimestamp(outUpdatedAt),
+				ContainsSpoilers: outContainsSpoilers,
+				SpoilerSpans:     MapToSpoilerSpans(outSpoilerSpans),
+				Tags:             outTags,
+				WatchedOn:        MapToOptionalDate(outWatchedOn),
+			},
+		)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+	return userReviews, totalResults
 }
 
 // AddUserMovieReview adds a user movie review
@@ -500,27 +898,167 @@ func (s *Service) AddUserMovieReview(
 		panic(err)
 	}
 
+	// Validate the spoiler marking, the tags and the watched on date of the movie review
+	details, err := validateUserReviewDetails(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *v1.AddUserMovieReviewResponse
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			var createErr error
+			response, createErr = s.createUserReview(ctx, tx, userID, request, details)
+			return createErr
+		},
+	); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// createUserReview creates a user movie review with its details, moderates it before it is published and writes the
+// created event to the outbox
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - userID: the ID of the user that writes the review
+// - request: the add user movie review request
+// - details: the validated details of the review
+//
+// Returns:
+//
+// - *v1.AddUserMovieReviewResponse: the add user movie review response
+// - error: if there was an error creating the user movie review
+func (s *Service) createUserReview(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	request *v1.AddUserMovieReviewRequest,
+	details *userReviewDetails,
+) (*v1.AddUserMovieReviewResponse, error) {
 	// Call the stored procedure to create the movie review in Postgres
-	if _, queryErr := s.pool.Exec(
+	if _, err := tx.Exec(
 		ctx,
 		sqlmovies.CreateUserReviewProc,
 		userID,
 		request.GetId(),
 		request.GetRating(),
 		request.GetReview(),
-	); queryErr != nil {
-		isUniqueViolation, constraintName := godatabasespgx.IsUniqueViolationError(queryErr)
+	); err != nil {
+		isUniqueViolation, constraintName := godatabasespgx.IsUniqueViolationError(err)
 		if !isUniqueViolation {
-			panic(queryErr)
+			return nil, err
 		}
 
 		// Check which unique constraint was violated
 		if constraintName != sqlmovies.UserReviewsUniqueUserMovieReview {
-			panic(queryErr)
+			return nil, err
 		}
 		return nil, ConnErrUserMovieReviewAlreadyExists
 	}
-	return &v1.AddUserMovieReviewResponse{}, nil
+
+	// Set the details of the movie review
+	if err := setUserReviewDetails(ctx, tx, userID, request.GetId(), details); err != nil {
+		return nil, err
+	}
+
+	// Store the username of the author, so the feeds list it after it is no longer cached
+	if err := s.storeUsername(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	// Moderate the movie review before it is published
+	moderationStatus, moderationReasons, err := s.moderateUserReview(
+		ctx,
+		tx,
+		userID,
+		request.GetId(),
+		request.GetReview(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the version of the created movie review
+	var version int64
+	if err = tx.QueryRow(
+		ctx,
+		internalpostgres.GetUserReviewVersionQuery,
+		userID,
+		request.GetId(),
+	).Scan(&version); err != nil {
+		return nil, err
+	}
+
+	// Write the created event to the outbox
+	if err = internaloutbox.Write(
+		ctx,
+		tx,
+		internaloutbox.Event{
+			Type:        internaloutbox.EventUserReviewCreated,
+			AggregateID: fmt.Sprintf(internaloutbox.UserReviewAggregateID, userID, request.GetId()),
+			Payload: internaloutbox.UserReviewPayload{
+				UserID:           userID,
+				MovieID:          request.GetId(),
+				Rating:           request.GetRating(),
+				Version:          version,
+				ModerationStatus: moderationStatus,
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.AddUserMovieReviewResponse{
+		ModerationStatus:  MapToModerationStatus(moderationStatus),
+		ModerationReasons: moderationReasons,
+		Version:           version,
+	}, nil
+}
+
+// lockUserReviewVersion locks a user review until the transaction ends and checks its version against the expected
+// one, if given
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - userID: the ID of the user that wrote the review
+// - movieID: the ID of the movie
+// - expectedVersion: the version the client expects the review to have (optional)
+//
+// Returns:
+//
+// - error: if the review was not found, its version does not match the expected one, or there was an error locking it
+func lockUserReviewVersion(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	movieID int32,
+	expectedVersion *int64,
+) error {
+	var version int64
+	if err := tx.QueryRow(
+		ctx,
+		internalpostgres.LockUserReviewVersionQuery,
+		userID,
+		movieID,
+	).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ConnErrUserMovieReviewNotFound
+		}
+		return err
+	}
+
+	// Check if the review was modified since the client read it
+	if expectedVersion != nil && *expectedVersion != version {
+		return ConnErrUserMovieReviewVersionMismatch
+	}
+	return nil
 }
 
 // UpdateUserMovieReview updates a user movie review
@@ -548,28 +1086,121 @@ func (s *Service) UpdateUserMovieReview(
 		panic(err)
 	}
 
-	// Call the stored procedure to update the movie review in Postgres
-	var userReviewFound sql.NullBool
-	if queryErr := s.pool.QueryRow(
+	// Validate the spoiler marking, the tags and the watched on date of the movie review
+	details, err := validateUserReviewDetails(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		moderationStatus  string
+		moderationReasons []string
+		version           int64
+	)
+	if err = s.runInTransaction(
 		ctx,
-		sqlmovies.UpdateUserReviewProc,
-		userID,
-		request.GetId(),
-		request.GetRating(),
-		request.GetReview(),
-		nil,
-	).Scan(
-		&userReviewFound,
-	); queryErr != nil {
-		panic(queryErr)
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock the movie review so concurrent edits are serialized, and check its version
+			if lockErr := lockUserReviewVersion(
+				ctx,
+				tx,
+				userID,
+				request.GetId(),
+				request.ExpectedVersion,
+			); lockErr != nil {
+				return lockErr
+			}
+
+			// Keep the current version of the movie review in its revisions before it is overwritten
+			if _, revisionErr := tx.Exec(
+				ctx,
+				internalpostgres.InsertUserReviewRevisionQuery,
+				userID,
+				request.GetId(),
+			); revisionErr != nil {
+				return revisionErr
+			}
+
+			// Call the stored procedure to update the movie review in Postgres
+			var userReviewFound sql.NullBool
+			if queryErr := tx.QueryRow(
+				ctx,
+				sqlmovies.UpdateUserReviewProc,
+				userID,
+				request.GetId(),
+				request.GetRating(),
+				request.GetReview(),
+				nil,
+			).Scan(
+				&userReviewFound,
+			); queryErr != nil {
+				return queryErr
+			}
+
+			// Check if the user review was found
+			if !userReviewFound.Valid || !userReviewFound.Bool {
+				return ConnErrUserMovieReviewNotFound
+			}
+
+			// Set the details of the movie review
+			if detailsErr := setUserReviewDetails(ctx, tx, userID, request.GetId(), details); detailsErr != nil {
+				return detailsErr
+			}
+
+			// Moderate the edited movie review before it is published again
+			var moderateErr error
+			moderationStatus, moderationReasons, moderateErr = s.moderateUserReview(
+				ctx,
+				tx,
+				userID,
+				request.GetId(),
+				request.GetReview(),
+			)
+			if moderateErr != nil {
+				return moderateErr
+			}
+
+			// Increment the version of the movie review
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.IncrementUserReviewVersionQuery,
+				userID,
+				request.GetId(),
+			).Scan(&version); queryErr != nil {
+				return queryErr
+			}
+
+			// Write the updated event to the outbox
+			return internaloutbox.Write(
+				ctx,
+				tx,
+				internaloutbox.Event{
+					Type:        internaloutbox.EventUserReviewUpdated,
+					AggregateID: fmt.Sprintf(internaloutbox.UserReviewAggregateID, userID, request.GetId()),
+					Payload: internaloutbox.UserReviewPayload{
+						UserID:           userID,
+						MovieID:          request.GetId(),
+						Rating:           request.GetRating(),
+						Version:          version,
+						ModerationStatus: moderationStatus,
+					},
+				},
+			)
+		},
+	); err != nil {
+		return nil, err
 	}
 
-	// Check if the user review was found
-	if !userReviewFound.Valid || !userReviewFound.Bool {
-		return nil, ConnErrUserMovieReviewNotFound
+	// Invalidate the cached activity feeds of the followers if the edited review was held by the moderation rules
+	if moderationStatus != ModerationStatusPublished {
+		s.invalidateFollowerFeeds(ctx, userID)
 	}
 
-	return &v1.UpdateUserMovieReviewResponse{}, nil
+	return &v1.UpdateUserMovieReviewResponse{
+		ModerationStatus:  MapToModerationStatus(moderationStatus),
+		ModerationReasons: moderationReasons,
+		Version:           version,
+	}, nil
 }
 
 // DeleteUserMovieReview deletes a user movie review
@@ -597,28 +1228,84 @@ func (s *Service) DeleteUserMovieReview(
 		panic(err)
 	}
 
-	// Call the stored procedure to delete the movie review in Postgres
-	var userReviewFound sql.NullBool
-	if queryErr := s.pool.QueryRow(
+	if err = s.runInTransaction(
 		ctx,
-		sqlmovies.DeleteUserReviewProc,
-		userID,
-		request.GetId(),
-		nil,
-	).Scan(
-		&userReviewFound,
-	); queryErr != nil {
-		panic(queryErr)
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Lock the movie review and check its version
+			if lockErr := lockUserReviewVersion(
+				ctx,
+				tx,
+				userID,
+				request.GetId(),
+				request.ExpectedVersion,
+			); lockErr != nil {
+				return lockErr
+			}
+
+			// Call the stored procedure to delete the movie review in Postgres
+			var userReviewFound sql.NullBool
+			if queryErr := tx.QueryRow(
+				ctx,
+				sqlmovies.DeleteUserReviewProc,
+				userID,
+				request.GetId(),
+				nil,
+			).Scan(
+				&userReviewFound,
+			); queryErr != nil {
+				return queryErr
+			}
+
+			// Check if the user review was found
+			if !userReviewFound.Valid || !userReviewFound.Bool {
+				return ConnErrUserMovieReviewNotFound
+			}
+
+			// Delete the revisions of the movie review
+			if _, execErr := tx.Exec(
+				ctx,
+				internalpostgres.DeleteUserReviewRevisionsQuery,
+				userID,
+				request.GetId(),
+			); execErr != nil {
+				return execErr
+			}
+
+			// Write the deleted event to the outbox
+			return internaloutbox.Write(
+				ctx,
+				tx,
+				internaloutbox.Event{
+					Type:        internaloutbox.EventUserReviewDeleted,
+					AggregateID: fmt.Sprintf(internaloutbox.UserReviewAggregateID, userID, request.GetId()),
+					Payload: internaloutbox.UserReviewPayload{
+						UserID:  userID,
+						MovieID: request.GetId(),
+					},
+				},
+			)
+		},
+	); err != nil {
+		return nil, err
 	}
 
-	// Check if the user review was found
-	if !userReviewFound.Valid || !userReviewFound.Bool {
-		return nil, ConnErrUserMovieReviewNotFound
-	}
+	// Invalidate the cached activity feeds of the followers, so the deleted review is removed
+	s.invalidateFollowerFeeds(ctx, userID)
 
 	return &v1.DeleteUserMovieReviewResponse{}, nil
 }
 
+// GetUserMovieReview gets a user movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get user movie review request
+//
+// Returns:
+//
+// - *v1.GetUserMovieReviewResponse: the get user movie review response
+// - error: if there was an error getting the user movie review
 func (s *Service) GetUserMovieReview(
 	ctx context.Context,
 	request *v1.GetUserMovieReviewRequest,
@@ -633,6 +1320,33 @@ func (s *Service) GetUserMovieReview(
 		panic(err)
 	}
 
+	// Get the version and the details of the movie review before its content, so a concurrent edit makes the returned
+	// version stale instead of pairing the old version with the new content
+	var (
+		version             int64
+		outContainsSpoilers bool
+		outSpoilerSpans     []SpoilerSpan
+		outTags             []string
+		outWatchedOn        sql.NullTime
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.GetUserReviewDetailsQuery,
+		userID,
+		request.GetId(),
+	).Scan(
+		&version,
+		&outContainsSpoilers,
+		&outSpoilerSpans,
+		&outTags,
+		&outWatchedOn,
+	); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, ConnErrUserMovieReviewNotFound
+		}
+		panic(queryErr)
+	}
+
 	// Call the stored procedure to get the movie review in Postgres
 	var (
 		outRating          sql.NullInt32
@@ -668,8 +1382,370 @@ func (s *Service) GetUserMovieReview(
 
 	return &v1.GetUserMovieReviewResponse{
 		UserReview: &v1.UserMovieReview{
-			Rating: outRating.Int32,
-			Review: outReviewText.String,
+			Rating:           outRating.Int32,
+			Review:           outReviewText.String,
+			CreatedAt:        MapToOptionalTimestamp(outCreatedAt),
+			UpdatedAt:        MapToOptionalTimestamp(outUpdatedAt),
+			Version:          version,
+			ContainsSpoilers: outContainsSpoilers,
+			SpoilerSpans:     MapToSpoilerSpans(outSpoilerSpans),
+			Tags:             outTags,
+			WatchedOn:        MapToOptionalDate(outWatchedOn),
+		},
+	}, nil
+}
+
+// SearchKeywords searches for keywords
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the search keywords request
+//
+// Returns:
+//
+// - *v1.SearchKeywordsResponse: the search keywords response
+// - error: if there was an error searching for keywords
+func (s *Service) SearchKeywords(
+	ctx context.Context,
+	request *v1.SearchKeywordsRequest,
+) (*v1.SearchKeywordsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to search for keywords
+	apiResponse, _, err := s.tmdbClient.SearchKeywords(ctx, request.GetQuery(), request.GetPage())
+	if err != nil {
+		panic(err)
+	}
+
+	return internaltmdb.MapToSearchKeywordsResponse(apiResponse), nil
+}
+
+// SearchCompanies searches for companies
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the search companies request
+//
+// Returns:
+//
+// - *v1.SearchCompaniesResponse: the search companies response
+// - error: if there was an error searching for companies
+func (s *Service) SearchCompanies(
+	ctx context.Context,
+	request *v1.SearchCompaniesRequest,
+) (*v1.SearchCompaniesResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to search for companies
+	apiResponse, _, err := s.tmdbClient.SearchCompanies(ctx, request.GetQuery(), request.GetPage())
+	if err != nil {
+		panic(err)
+	}
+
+	return internaltmdb.MapToSearchCompaniesResponse(apiResponse), nil
+}
+
+// GetCollection gets a collection with the movies that belong to it
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get collection request
+//
+// Returns:
+//
+// - *v1.GetCollectionResponse: the get collection response
+// - error: if there was an error getting the collection
+func (s *Service) GetCollection(
+	ctx context.Context,
+	request *v1.GetCollectionRequest,
+) (*v1.GetCollectionResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get the collection
+	apiResponse, statusCode, err := s.tmdbClient.GetCollection(ctx, request.GetId(), request.GetLanguage())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, ConnErrCollectionNotFound
+		}
+		panic(err)
+	}
+
+	return internaltmdb.MapToGetCollectionResponse(apiResponse), nil
+}
+
+// GetCompanyDetails gets the company details
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get company details request
+//
+// Returns:
+//
+// - *v1.GetCompanyDetailsResponse: the get company details response
+// - error: if there was an error getting the company details
+func (s *Service) GetCompanyDetails(
+	ctx context.Context,
+	request *v1.GetCompanyDetailsRequest,
+) (*v1.GetCompanyDetailsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get the company details
+	apiResponse, statusCode, err := s.tmdbClient.GetCompanyDetails(ctx, request.GetId())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, ConnErrCompanyNotFound
+		}
+		panic(err)
+	}
+
+	return internaltmdb.MapToGetCompanyDetailsResponse(apiResponse), nil
+}
+
+// GetMovieCertifications gets the valid movie certifications of every country
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get movie certifications request
+//
+// Returns:
+//
+// - *v1.GetMovieCertificationsResponse: the get movie certifications response
+// - error: if there was an error getting the movie certifications
+func (s *Service) GetMovieCertifications(
+	ctx context.Context,
+	request *v1.GetMovieCertificationsRequest,
+) (*v1.GetMovieCertificationsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get movie certifications
+	apiResponse, _, err := s.tmdbClient.GetMovieCertifications(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	return internaltmdb.MapToGetMovieCertificationsResponse(apiResponse, request.GetCountry()), nil
+}
+
+// GetMovieReleaseDates gets the release dates and certifications of a movie by country and release type
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get movie release dates request
+//
+// Returns:
+//
+// - *v1.GetMovieReleaseDatesResponse: the get movie release dates response
+// - error: if there was an error getting the movie release dates
+func (s *Service) GetMovieReleaseDates(
+	ctx context.Context,
+	request *v1.GetMovieReleaseDatesRequest,
+) (*v1.GetMovieReleaseDatesResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Call TMDB API to get movie release dates
+	apiResponse, statusCode, err := s.tmdbClient.GetMovieReleaseDates(ctx, request.GetId())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, ConnErrMovieNotFound
+		}
+		panic(err)
+	}
+
+	return internaltmdb.MapToGetMovieReleaseDatesResponse(
+		apiResponse,
+		request.GetCountry(),
+		request.GetTypes(),
+	), nil
+}
+
+// GetTrendingMovies gets the trending movies of a time window, optionally blending our community review activity of
+// the same time window into the TMDB ranking
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get trending movies request
+//
+// Returns:
+//
+// - *v1.GetTrendingMoviesResponse: the get trending movies response
+// - error: if there was an error getting the trending movies
+func (s *Service) GetTrendingMovies(
+	ctx context.Context,
+	request *v1.GetTrendingMoviesRequest,
+) (*v1.GetTrendingMoviesResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Map the time window
+	timeWindow := internaltmdb.MapToTrendingTimeWindow(request.GetTimeWindow())
+
+	response := getCachedOrFetch(
+		ctx,
+		s.cache,
+		fmt.Sprintf(
+			internalcache.MovieListKey,
+			fmt.Sprintf(internalcache.TrendingMovieList, timeWindow),
+			request.GetLanguage(),
+			"",
+			request.GetPage(),
+		),
+		internalcache.MovieListsTTL,
+		&v1.GetTrendingMoviesResponse{},
+		func() *v1.GetTrendingMoviesResponse {
+			// Call TMDB API to get trending movies
+			apiResponse, _, err := s.tmdbClient.GetTrendingMovies(
+				ctx,
+				timeWindow,
+				request.GetLanguage(),
+				request.GetPage(),
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return internaltmdb.MapToGetTrendingMoviesResponse(apiResponse)
+		},
+	)
+
+	// Blend our community review activity into the ranking, if requested
+	if request.GetBlendCommunity() {
+		window := TrendingDayWindow
+		if timeWindow == internaltmdb.TrendingTimeWindowWeek {
+			window = TrendingWeekWindow
+		}
+		s.blendCommunityTrending(ctx, response.Results, time.Now().Add(-window))
+	}
+	return response, nil
+}
+
+// blendCommunityTrending reorders the given movies by blending their TMDB ranking position with the number of reviews
+// our users have written for them since the given time
+//
+// Parameters:
+//
+// - ctx: the context
+// - movies: the movies to reorder, in TMDB ranking order
+// - since: the start of the community review activity time window
+func (s *Service) blendCommunityTrending(
+	ctx context.Context,
+	movies []*v1.SimpleMovie,
+	since time.Time,
+) {
+	if len(movies) == 0 {
+		return
+	}
+
+	// Get the movie IDs
+	movieIDs := make([]int32, len(movies))
+	for i, movie := range movies {
+		movieIDs[i] = movie.GetId()
+	}
+
+	// Count the recent user reviews of each movie
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.CountRecentUserReviewsByMovieQuery,
+		movieIDs,
+		since,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var maxReviewsCount int64
+	reviewsCount := make(map[int32]int64, len(movies))
+	for rows.Next() {
+		var (
+			movieID int32
+			count   int64
+		)
+		if scanErr := rows.Scan(&movieID, &count); scanErr != nil {
+			panic(scanErr)
+		}
+		reviewsCount[movieID] = count
+		maxReviewsCount = max(maxReviewsCount, count)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Check if there is any community review activity to blend
+	if maxReviewsCount == 0 {
+		return
+	}
+
+	// Compute the blended score of each movie
+	scores := make(map[int32]float64, len(movies))
+	for i, movie := range movies {
+		tmdbScore := float64(len(movies)-i) / float64(len(movies))
+		communityScore := float64(reviewsCount[movie.GetId()]) / float64(maxReviewsCount)
+		scores[movie.GetId()] = tmdbScore + CommunityTrendingWeight*communityScore
+	}
+
+	// Sort the movies by their blended score
+	sort.SliceStable(
+		movies, func(i, j int) bool {
+			return scores[movies[i].GetId()] > scores[movies[j].GetId()]
 		},
+	)
+}
+
+// GetCommunityTopMovies gets the community leaderboard of the movies our users are reviewing and rating highly
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get community top movies request
+//
+// Returns:
+//
+// - *v1.GetCommunityTopMoviesResponse: the get community top movies response
+// - error: if there was an error getting the community top movies
+func (s *Service) GetCommunityTopMovies(
+	ctx context.Context,
+	request *v1.GetCommunityTopMoviesRequest,
+) (*v1.GetCommunityTopMoviesResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the page of the community leaderboard
+	page := max(request.GetPage(), 1)
+	topMovies, totalResults, err := s.leaderboard.Top(
+		ctx,
+		internaltmdb.MapToTrendingTimeWindow(request.GetTimeWindow()),
+		request.GenreId,
+		page,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &v1.GetCommunityTopMoviesResponse{
+		Page:         page,
+		Results:      topMovies,
+		TotalPages:   int32((totalResults + internalcommunity.PageSize - 1) / internalcommunity.PageSize),
+		TotalResults: int32(totalResults),
 	}, nil
 }
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"golang.org/x/sync/errgroup"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internaltmdb "github.com/ralvarezdev/connect-movies/internal/tmdb"
+)
+
+// getSimpleMovie gets a simple movie from the cache or, on a cache miss, from the TMDB API. Movies not found on TMDB
+// are returned with only their ID, so a removed movie does not break the listings that reference it
+//
+// Parameters:
+//
+// - ctx: the context
+// - movieID: the ID of the movie
+// - language: the language of the movie
+//
+// Returns:
+//
+// - *v1.SimpleMovie: the simple movie
+// - error: if there was an error getting the movie from the TMDB API
+func (s *Service) getSimpleMovie(ctx context.Context, movieID int32, language string) (*v1.SimpleMovie, error) {
+	// Check if the movie is cached
+	key := fmt.Sprintf(internalcache.SimpleMovieKey, movieID, language)
+	movie := &v1.SimpleMovie{}
+	if s.cache.Get(ctx, key, movie) {
+		return movie, nil
+	}
+
+	// Call TMDB API to get the movie details
+	apiResponse, statusCode, err := s.tmdbClient.GetMovieDetails(ctx, movieID, language)
+	if err != nil {
+		if statusCode != http.StatusNotFound {
+			return nil, err
+		}
+		movie = &v1.SimpleMovie{Id: movieID}
+	} else {
+		movie = internaltmdb.MapMovieDetailsToSimpleMovie(apiResponse)
+	}
+
+	// Cache the movie
+	s.cache.Set(ctx, key, movie, internalcache.MoviesTTL)
+	return movie, nil
+}
+
+// getSimpleMovies gets the simple movies of the given movie IDs, fetching the ones that are not cached concurrently
+//
+// Parameters:
+//
+// - ctx: the context
+// - movieIDs: the IDs of the movies
+// - language: the language of the movies
+//
+// Returns:
+//
+// - []*v1.SimpleMovie: the simple movies, in the same order as the movie IDs
+// - error: if there was an error getting any of the movies
+func (s *Service) getSimpleMovies(ctx context.Context, movieIDs []int32, language string) (
+	[]*v1.SimpleMovie,
+	error,
+) {
+	movies := make([]*v1.SimpleMovie, len(movieIDs))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(SimpleMoviesFetchConcurrency)
+	for i, movieID := range movieIDs {
+		group.Go(
+			func() error {
+				movie, err := s.getSimpleMovie(groupCtx, movieID, language)
+				if err != nil {
+					return err
+				}
+				movies[i] = movie
+				return nil
+			},
+		)
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
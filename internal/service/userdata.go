@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// ExportMyData requests an export of the data of the authenticated user. The ZIP bundle is built in the background,
+// and the export already in progress is returned instead if the user has one
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the export my data request
+//
+// Returns:
+//
+// - *v1.ExportMyDataResponse: the export my data response
+// - error: if there was an error requesting the data export
+func (s *Service) ExportMyData(
+	ctx context.Context,
+	request *v1.ExportMyDataRequest,
+) (*v1.ExportMyDataResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Insert the export, or get the one in progress
+	var (
+		export       v1.UserDataExport
+		outStatus    string
+		outCreatedAt time.Time
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.InsertUserDataExportQuery,
+		userID,
+	).Scan(
+		&export.Id,
+		&outStatus,
+		&outCreatedAt,
+	); queryErr != nil {
+		panic(queryErr)
+	}
+	export.Status = MapToUserDataExportStatus(outStatus)
+	export.CreatedAt = timestamppb.New(outCreatedAt)
+
+	return &v1.ExportMyDataResponse{Export: &export}, nil
+}
+
+// GetMyDataExport gets a data export of the authenticated user, with its ZIP bundle once it is built
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the get my data export request
+//
+// Returns:
+//
+// - *v1.GetMyDataExportResponse: the get my data export response
+// - error: if there was an error getting the data export
+func (s *Service) GetMyDataExport(
+	ctx context.Context,
+	request *v1.GetMyDataExportRequest,
+) (*v1.GetMyDataExportResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Get the export of the user
+	var (
+		export         = v1.UserDataExport{Id: request.GetId()}
+		outStatus      string
+		outError       sql.NullString
+		outCreatedAt   time.Time
+		outCompletedAt sql.NullTime
+		outExpiresAt   sql.NullTime
+		outBundle      []byte
+	)
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.GetUserDataExportQuery,
+		request.GetId(),
+		userID,
+	).Scan(
+		&outStatus,
+		&outError,
+		&outCreatedAt,
+		&outCompletedAt,
+		&outExpiresAt,
+		&outBundle,
+	); queryErr != nil {
+		if errors.Is(queryErr, pgx.ErrNoRows) {
+			return nil, ConnErrUserDataExportNotFound
+		}
+		panic(queryErr)
+	}
+	export.Status = MapToUserDataExportStatus(outStatus)
+	if outError.Valid {
+		export.Error = &outError.String
+	}
+	export.CreatedAt = timestamppb.New(outCreatedAt)
+	export.CompletedAt = MapToOptionalTimestamp(outCompletedAt)
+	export.ExpiresAt = MapToOptionalTimestamp(outExpiresAt)
+
+	return &v1.GetMyDataExportResponse{
+		Export: &export,
+		Bundle: outBundle,
+	}, nil
+}
+
+// DeleteMyAccountData erases the data of the authenticated user, deleting or anonymizing it across every table and
+// invalidating the cached values that show it. The account itself is kept by the auth service
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete my account data request
+//
+// Returns:
+//
+// - *v1.DeleteMyAccountDataResponse: the delete my account data response
+// - error: if there was an error erasing the data
+func (s *Service) DeleteMyAccountData(
+	ctx context.Context,
+	request *v1.DeleteMyAccountDataRequest,
+) (*v1.DeleteMyAccountDataResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the deletion was confirmed, as it cannot be undone
+	if !request.GetConfirm() {
+		return nil, ConnErrDataDeletionNotConfirmed
+	}
+
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	report, err := s.userDataManager.Erase(ctx, userID)
+	if err != nil {
+		panic(err)
+	}
+	return &v1.DeleteMyAccountDataResponse{Report: MapToUserDataErasureReport(report)}, nil
+}
+
+// DeleteUserData erases the data of a deleted account. It is called by the auth service once the account is deleted,
+// so erasing the data of a user without data succeeds
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete user data request
+//
+// Returns:
+//
+// - *v1.DeleteUserDataResponse: the delete user data response
+// - error: if there was an error erasing the data
+func (s *Service) DeleteUserData(
+	ctx context.Context,
+	request *v1.DeleteUserDataRequest,
+) (*v1.DeleteUserDataResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the caller is an internal service
+	if err := requireService(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse the ID of the deleted user
+	if _, err := strconv.ParseInt(request.GetUserId(), 10, 64); err != nil {
+		return nil, ConnErrInvalidUserID
+	}
+
+	report, err := s.userDataManager.Erase(ctx, request.GetUserId())
+	if err != nil {
+		panic(err)
+	}
+	return &v1.DeleteUserDataResponse{Report: MapToUserDataErasureReport(report)}, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// storeUsername copies the username of a user from Redis to Postgres, so it can still be resolved by getUsernames
+// once it is no longer cached. It is called when the user writes content that lists its author, and does nothing if
+// the username is not cached
+//
+// Parameters:
+//
+// - ctx: the context
+// - tx: the transaction
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - error: if there was an error storing the username
+func (s *Service) storeUsername(ctx context.Context, tx pgx.Tx, userID string) error {
+	username, err := s.redisUsernameHandler.GetUsername(ctx, userID)
+	if err != nil || username == "" {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, internalpostgres.UpsertUsernameQuery, userID, username)
+	return err
+}
+
+// getUsernames gets the usernames of the given users from Redis, falling back to Postgres for the users whose
+// username is not cached
+//
+// Parameters:
+//
+// - ctx: the context
+// - userIDs: the IDs of the users, may contain duplicates
+//
+// Returns:
+//
+// - map[string]string: the usernames by user ID, missing the users that were not found
+func (s *Service) getUsernames(ctx context.Context, userIDs []string) map[string]string {
+	usernames := make(map[string]string, len(userIDs))
+	missingUserIDs := make([]int64, 0)
+	for _, userID := range userIDs {
+		if _, ok := usernames[userID]; ok {
+			continue
+		}
+
+		// Get the username from Redis
+		username, err := s.redisUsernameHandler.GetUsername(ctx, userID)
+		if err == nil && username != "" {
+			usernames[userID] = username
+			continue
+		}
+
+		// Keep the user to look up in Postgres, marking it as seen
+		usernames[userID] = ""
+		if parsedUserID, parseErr := strconv.ParseInt(userID, 10, 64); parseErr == nil {
+			missingUserIDs = append(missingUserIDs, parsedUserID)
+		}
+	}
+
+	// Get the usernames that were not cached from Postgres
+	if len(missingUserIDs) > 0 {
+		rows, err := s.pool.Query(ctx, internalpostgres.ListUsernamesQuery, missingUserIDs)
+		if err != nil {
+			panic(err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var userID, username string
+			if scanErr := rows.Scan(&userID, &username); scanErr != nil {
+				panic(scanErr)
+			}
+			usernames[userID] = username
+		}
+		if rowsErr := rows.Err(); rowsErr != nil {
+			panic(rowsErr)
+		}
+	}
+
+	// Remove the users that were not found
+	for userID, username := range usernames {
+		if username == "" {
+			delete(usernames, userID)
+		}
+	}
+	return usernames
+}
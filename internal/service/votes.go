@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+
+	goauthjwtclaims "github.com/ralvarezdev/connect-auth-types-go/jwt/claims"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+// parseUserID parses a user ID given in a request, so the non-numeric IDs are rejected before reaching the queries
+//
+// Parameters:
+//
+// - userID: the user ID given in the request
+//
+// Returns:
+//
+// - int64: the parsed user ID
+// - error: if the user ID is not valid
+func parseUserID(userID string) (int64, error) {
+	parsedUserID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return 0, ConnErrInvalidUserID
+	}
+	return parsedUserID, nil
+}
+
+// isSameUser checks if a user ID given in a request is the ID of the authenticated user. The IDs are compared as
+// numbers, so a non-canonical ID like "007" is not taken for another user
+//
+// Parameters:
+//
+// - userID: the ID of the authenticated user
+// - otherUserID: the user ID given in the request
+//
+// Returns:
+//
+// - bool: true if both IDs are the same user, false otherwise
+// - error: if the user ID given in the request is not valid
+func isSameUser(userID, otherUserID string) (bool, error) {
+	parsedOtherUserID, err := parseUserID(otherUserID)
+	if err != nil {
+		return false, err
+	}
+	parsedUserID, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return parsedUserID == parsedOtherUserID, nil
+}
+
+// setUserReviewVote casts or removes the vote of the authenticated user on another user's review, keeping the
+// denormalized vote count of the review in sync
+//
+// Parameters:
+//
+// - ctx: the context
+// - voteType: the vote type (VoteTypeLike or VoteTypeHelpful)
+// - reviewUserID: the ID of the user that wrote the review
+// - movieID: the ID of the reviewed movie
+// - cast: true to cast the vote, false to remove it
+//
+// Returns:
+//
+// - error: if there was an error setting the vote
+func (s *Service) setUserReviewVote(
+	ctx context.Context,
+	voteType string,
+	reviewUserID string,
+	movieID int32,
+	cast bool,
+) error {
+	// Get the user ID from the auth response
+	userID, err := goauthjwtclaims.GetSubject(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	// Check if the user is voting on their own review
+	sameUser, err := isSameUser(userID, reviewUserID)
+	if err != nil {
+		return err
+	}
+	if sameUser {
+		return ConnErrCannotVoteOwnUserMovieReview
+	}
+
+	// Get the query to update the vote count of the review
+	updateCountQuery := internalpostgres.UpdateUserReviewLikesCountQuery
+	if voteType == VoteTypeHelpful {
+		updateCountQuery = internalpostgres.UpdateUserReviewHelpfulCountQuery
+	}
+
+	return s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Check if the user review exists
+			var userReviewFound bool
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.UserReviewExistsQuery,
+				reviewUserID,
+				movieID,
+			).Scan(&userReviewFound); queryErr != nil {
+				return queryErr
+			}
+			if !userReviewFound {
+				return ConnErrUserMovieReviewNotFound
+			}
+
+			// Cast or remove the vote
+			voteQuery, delta := internalpostgres.InsertUserReviewVoteQuery, 1
+			if !cast {
+				voteQuery, delta = internalpostgres.DeleteUserReviewVoteQuery, -1
+			}
+			commandTag, execErr := tx.Exec(ctx, voteQuery, userID, reviewUserID, movieID, voteType)
+			if execErr != nil {
+				return execErr
+			}
+
+			// Update the vote count only if the vote changed, so repeated calls are idempotent
+			if commandTag.RowsAffected() == 0 {
+				return nil
+			}
+			_, execErr = tx.Exec(ctx, updateCountQuery, reviewUserID, movieID, delta)
+			return execErr
+		},
+	)
+}
+
+// LikeUserMovieReview likes another user's movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the like user movie review request
+//
+// Returns:
+//
+// - *v1.LikeUserMovieReviewResponse: the like user movie review response
+// - error: if there was an error liking the user movie review
+func (s *Service) LikeUserMovieReview(
+	ctx context.Context,
+	request *v1.LikeUserMovieReviewRequest,
+) (*v1.LikeUserMovieReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.setUserReviewVote(ctx, VoteTypeLike, request.GetUserId(), request.GetId(), true); err != nil {
+		return nil, err
+	}
+	return &v1.LikeUserMovieReviewResponse{}, nil
+}
+
+// UnlikeUserMovieReview removes the like from another user's movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the unlike user movie review request
+//
+// Returns:
+//
+// - *v1.UnlikeUserMovieReviewResponse: the unlike user movie review response
+// - error: if there was an error unliking the user movie review
+func (s *Service) UnlikeUserMovieReview(
+	ctx context.Context,
+	request *v1.UnlikeUserMovieReviewRequest,
+) (*v1.UnlikeUserMovieReviewResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.setUserReviewVote(ctx, VoteTypeLike, request.GetUserId(), request.GetId(), false); err != nil {
+		return nil, err
+	}
+	return &v1.UnlikeUserMovieReviewResponse{}, nil
+}
+
+// MarkUserMovieReviewHelpful marks another user's movie review as helpful
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the mark user movie review helpful request
+//
+// Returns:
+//
+// - *v1.MarkUserMovieReviewHelpfulResponse: the mark user movie review helpful response
+// - error: if there was an error marking the user movie review as helpful
+func (s *Service) MarkUserMovieReviewHelpful(
+	ctx context.Context,
+	request *v1.MarkUserMovieReviewHelpfulRequest,
+) (*v1.MarkUserMovieReviewHelpfulResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.setUserReviewVote(ctx, VoteTypeHelpful, request.GetUserId(), request.GetId(), true); err != nil {
+		return nil, err
+	}
+	return &v1.MarkUserMovieReviewHelpfulResponse{}, nil
+}
+
+// UnmarkUserMovieReviewHelpful removes the helpful mark from another user's movie review
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the unmark user movie review helpful request
+//
+// Returns:
+//
+// - *v1.UnmarkUserMovieReviewHelpfulResponse: the unmark user movie review helpful response
+// - error: if there was an error removing the helpful mark from the user movie review
+func (s *Service) UnmarkUserMovieReviewHelpful(
+	ctx context.Context,
+	request *v1.UnmarkUserMovieReviewHelpfulRequest,
+) (*v1.UnmarkUserMovieReviewHelpfulResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	if err := s.setUserReviewVote(ctx, VoteTypeHelpful, request.GetUserId(), request.GetId(), false); err != nil {
+		return nil, err
+	}
+	return &v1.UnmarkUserMovieReviewHelpfulResponse{}, nil
+}
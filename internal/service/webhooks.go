@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	v1 "github.com/ralvarezdev/proto-movies/gen/go/ralvarezdev/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+	internalwebhooks "github.com/ralvarezdev/connect-movies/internal/webhooks"
+)
+
+// validateWebhookSubscription validates the URL, the event types and the description of a webhook subscription
+//
+// Parameters:
+//
+// - rawURL: the webhook URL
+// - eventTypes: the event types to deliver, empty to deliver every event type
+// - description: the subscription description
+//
+// Returns:
+//
+// - string: the trimmed description
+// - error: if the URL is not an absolute http or https URL, an event type is invalid or duplicated, or the description
+// is too long
+func validateWebhookSubscription(rawURL string, eventTypes []string, description string) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return "", ConnErrInvalidWebhookURL
+	}
+
+	for i, eventType := range eventTypes {
+		if !slices.Contains(internaloutbox.EventTypes, eventType) || slices.Contains(eventTypes[:i], eventType) {
+			return "", ConnErrInvalidWebhookEventType
+		}
+	}
+
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > WebhookDescriptionMaxLength {
+		return "", ConnErrInvalidWebhookDescription
+	}
+	return description, nil
+}
+
+// CreateWebhookSubscription registers a webhook endpoint for the given event types. The signing secret is generated
+// here and only returned in this response
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the create webhook subscription request
+//
+// Returns:
+//
+// - *v1.CreateWebhookSubscriptionResponse: the create webhook subscription response
+// - error: if there was an error creating the webhook subscription
+func (s *Service) CreateWebhookSubscription(
+	ctx context.Context,
+	request *v1.CreateWebhookSubscriptionRequest,
+) (*v1.CreateWebhookSubscriptionResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin
+	adminUserID, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the subscription
+	description, err := validateWebhookSubscription(
+		request.GetUrl(),
+		request.GetEventTypes(),
+		request.GetDescription(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the signing secret
+	secret, err := internalwebhooks.GenerateSecret()
+	if err != nil {
+		panic(err)
+	}
+
+	eventTypes := request.GetEventTypes()
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	subscription := &v1.WebhookSubscription{
+		Url:         request.GetUrl(),
+		EventTypes:  eventTypes,
+		Description: description,
+		CreatedBy:   adminUserID,
+	}
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Insert the subscription
+			var outCreatedAt time.Time
+			if queryErr := tx.QueryRow(
+				ctx,
+				internalpostgres.InsertWebhookSubscriptionQuery,
+				subscription.GetUrl(),
+				secret,
+				subscription.GetEventTypes(),
+				description,
+				adminUserID,
+			).Scan(
+				&subscription.Id,
+				&outCreatedAt,
+			); queryErr != nil {
+				return queryErr
+			}
+			subscription.CreatedAt = timestamppb.New(outCreatedAt)
+
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				adminUserID,
+				AdminActionCreateWebhook,
+				fmt.Sprintf(WebhookTarget, subscription.GetId()),
+				map[string]any{
+					"url":         subscription.GetUrl(),
+					"event_types": subscription.GetEventTypes(),
+				},
+			)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateWebhookSubscriptionResponse{
+		Subscription: subscription,
+		Secret:       secret,
+	}, nil
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions, from the newest to the oldest one. The signing secrets
+// are never returned
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list webhook subscriptions request
+//
+// Returns:
+//
+// - *v1.ListWebhookSubscriptionsResponse: the list webhook subscriptions response
+// - error: if there was an error listing the webhook subscriptions
+func (s *Service) ListWebhookSubscriptions(
+	ctx context.Context,
+	request *v1.ListWebhookSubscriptionsRequest,
+) (*v1.ListWebhookSubscriptionsResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, internalpostgres.ListWebhookSubscriptionsQuery)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*v1.WebhookSubscription
+	for rows.Next() {
+		var (
+			subscription v1.WebhookSubscription
+			outCreatedAt time.Time
+		)
+		if scanErr := rows.Scan(
+			&subscription.Id,
+			&subscription.Url,
+			&subscription.EventTypes,
+			&subscription.Description,
+			&subscription.CreatedBy,
+			&outCreatedAt,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		subscription.CreatedAt = timestamppb.New(outCreatedAt)
+		subscriptions = append(subscriptions, &subscription)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	return &v1.ListWebhookSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription with its delivery log
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the delete webhook subscription request
+//
+// Returns:
+//
+// - *v1.DeleteWebhookSubscriptionResponse: the delete webhook subscription response
+// - error: if there was an error deleting the webhook subscription
+func (s *Service) DeleteWebhookSubscription(
+	ctx context.Context,
+	request *v1.DeleteWebhookSubscriptionRequest,
+) (*v1.DeleteWebhookSubscriptionResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin
+	adminUserID, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.runInTransaction(
+		ctx,
+		func(ctx context.Context, tx pgx.Tx) error {
+			commandTag, execErr := tx.Exec(ctx, internalpostgres.DeleteWebhookSubscriptionQuery, request.GetId())
+			if execErr != nil {
+				return execErr
+			}
+
+			// Check if the subscription was found
+			if commandTag.RowsAffected() == 0 {
+				return ConnErrWebhookSubscriptionNotFound
+			}
+
+			return writeAdminAuditLog(
+				ctx,
+				tx,
+				adminUserID,
+				AdminActionDeleteWebhook,
+				fmt.Sprintf(WebhookTarget, request.GetId()),
+				nil,
+			)
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+// ListWebhookDeliveries lists a page of the delivery log of a webhook subscription, from the newest to the oldest
+// delivery
+//
+// Parameters:
+//
+// - ctx: the context
+// - request: the list webhook deliveries request
+//
+// Returns:
+//
+// - *v1.ListWebhookDeliveriesResponse: the list webhook deliveries response
+// - error: if there was an error listing the webhook deliveries
+func (s *Service) ListWebhookDeliveries(
+	ctx context.Context,
+	request *v1.ListWebhookDeliveriesRequest,
+) (*v1.ListWebhookDeliveriesResponse, error) {
+	if s == nil {
+		panic(ErrNilService)
+	}
+
+	// Check if the authenticated user is an admin
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Decode the cursor
+	beforeCreatedAt, beforeID, err := DecodeCursor(request.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the subscription exists
+	var subscriptionFound bool
+	if queryErr := s.pool.QueryRow(
+		ctx,
+		internalpostgres.ExistsWebhookSubscriptionQuery,
+		request.GetSubscriptionId(),
+	).Scan(&subscriptionFound); queryErr != nil {
+		panic(queryErr)
+	}
+	if !subscriptionFound {
+		return nil, ConnErrWebhookSubscriptionNotFound
+	}
+
+	// Get the page size
+	pageSize := request.GetPageSize()
+	if pageSize <= 0 {
+		pageSize = WebhookDeliveriesDefaultPageSize
+	}
+	pageSize = min(pageSize, WebhookDeliveriesMaxPageSize)
+
+	// Query one more delivery than the page size to know if there is a next page
+	rows, err := s.pool.Query(
+		ctx,
+		internalpostgres.ListWebhookDeliveriesQuery,
+		request.GetSubscriptionId(),
+		beforeCreatedAt,
+		beforeID,
+		pageSize+1,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var (
+		deliveries          = make([]*v1.WebhookDelivery, 0, pageSize)
+		deliveriesCreatedAt = make([]time.Time, 0, pageSize)
+	)
+	for rows.Next() {
+		var (
+			delivery         v1.WebhookDelivery
+			outStatus        string
+			outStatusCode    sql.NullInt32
+			outError         sql.NullString
+			outCreatedAt     time.Time
+			outLastAttemptAt sql.NullTime
+			outDeliveredAt   sql.NullTime
+		)
+		if scanErr := rows.Scan(
+			&delivery.Id,
+			&delivery.EventId,
+			&delivery.EventType,
+			&outStatus,
+			&delivery.Attempts,
+			&outStatusCode,
+			&outError,
+			&outCreatedAt,
+			&outLastAttemptAt,
+			&outDeliveredAt,
+		); scanErr != nil {
+			panic(scanErr)
+		}
+		delivery.Status = MapToWebhookDeliveryStatus(outStatus)
+		if outStatusCode.Valid {
+			delivery.LastStatusCode = &outStatusCode.Int32
+		}
+		if outError.Valid {
+			delivery.LastError = &outError.String
+		}
+		delivery.CreatedAt = timestamppb.New(outCreatedAt)
+		delivery.LastAttemptAt = MapToOptionalTimestamp(outLastAttemptAt)
+		delivery.DeliveredAt = MapToOptionalTimestamp(outDeliveredAt)
+
+		deliveries = append(deliveries, &delivery)
+		deliveriesCreatedAt = append(deliveriesCreatedAt, outCreatedAt)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		panic(rowsErr)
+	}
+
+	// Get the cursor of the next page
+	var nextCursor string
+	if len(deliveries) > int(pageSize) {
+		deliveries = deliveries[:pageSize]
+		nextCursor = EncodeCursor(deliveriesCreatedAt[pageSize-1], deliveries[pageSize-1].GetId())
+	}
+
+	return &v1.ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		NextCursor: nextCursor,
+	}, nil
+}
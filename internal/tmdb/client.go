@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+)
+
+type (
+	// Client is the TMDB API client that extends the gotmdbapi client with the endpoints it does not cover yet
+	Client struct {
+		*gotmdbapi.Client
+		apiKey     string
+		httpClient *http.Client
+	}
+)
+
+// NewClient creates a new TMDB API client
+//
+// Parameters:
+//
+// - apiKey: the TMDB API key
+//
+// Returns:
+//
+// - *Client: the TMDB API client
+// - error: if there was an error creating the client
+func NewClient(apiKey string) (*Client, error) {
+	// Create the base TMDB API client
+	baseClient, err := gotmdbapi.NewClient(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Client:     baseClient,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: RequestTimeout},
+	}, nil
+}
+
+// get makes a GET request to the given TMDB API URL and decodes the JSON response into the given value
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - apiURL: the TMDB API URL
+// - query: the query parameters (optional)
+// - parsedResp: the value to decode the response into
+//
+// Returns:
+//
+// - int: the HTTP status code
+// - error: if there was an error making the request or parsing the response
+func (c Client) get(
+	ctx context.Context,
+	apiURL string,
+	query url.Values,
+	parsedResp any,
+) (statusCode int, err error) {
+	// Create the HTTP request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf(gotmdbapi.ErrBuildingRequest, err)
+	}
+
+	// Add the Authorization header
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	// Add query parameters
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	// Make the HTTP request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf(gotmdbapi.ErrAnErrOcurredDuringRequest, err)
+	}
+	defer resp.Body.Close()
+
+	// Check for non-200 status codes
+	if resp.StatusCode != http.StatusOK {
+		// nolint:errcheck
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, fmt.Errorf(gotmdbapi.ErrRequestFailed, resp.StatusCode, string(body))
+	}
+
+	// Parse the response
+	if parseErr := json.NewDecoder(resp.Body).Decode(parsedResp); parseErr != nil {
+		return resp.StatusCode, gotmdbapi.ErrResponseParsing
+	}
+	return resp.StatusCode, nil
+}
+
+// GetMovieWatchProviders fetches the watch providers of a given movie for every region
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - movieID: the ID of the movie
+//
+// Returns:
+//
+// - *MovieWatchProvidersResponse: the response containing the watch providers by region
+// - int: the HTTP status code
+// - error: if there was an error fetching the watch providers
+func (c Client) GetMovieWatchProviders(
+	ctx context.Context,
+	movieID int32,
+) (*MovieWatchProvidersResponse, int, error) {
+	parsedResp := &MovieWatchProvidersResponse{}
+	statusCode, err := c.get(
+		ctx,
+		fmt.Sprintf(GetMovieWatchProvidersURL, movieID),
+		nil,
+		parsedResp,
+	)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetWatchProviderRegions fetches the regions that have watch provider data
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - language: the language code (optional, defaults to "en-US")
+//
+// Returns:
+//
+// - *WatchProviderRegionsResponse: the response containing the regions
+// - int: the HTTP status code
+// - error: if there was an error fetching the regions
+func (c Client) GetWatchProviderRegions(
+	ctx context.Context,
+	language string,
+) (*WatchProviderRegionsResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+
+	parsedResp := &WatchProviderRegionsResponse{}
+	statusCode, err := c.get(ctx, GetWatchProviderRegionsURL, query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetMovieVideos fetches the videos of a given movie
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - movieID: the ID of the movie
+// - language: the language code (optional, defaults to "en-US")
+//
+// Returns:
+//
+// - *MovieVideosResponse: the response containing the movie videos
+// - int: the HTTP status code
+// - error: if there was an error fetching the movie videos
+func (c Client) GetMovieVideos(
+	ctx context.Context,
+	movieID int32,
+	language string,
+) (*MovieVideosResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+
+	parsedResp := &MovieVideosResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetMovieVideosURL, movieID), query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetMovieDetailsWithAppend fetches the details of a given movie together with the given sub-requests in a single
+// request, using TMDB's append to response
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - movieID: the ID of the movie
+// - language: the language code (optional, defaults to "en-US")
+// - appendToResponse: the sub-requests to append (e.g. AppendCredits, AppendVideos)
+//
+// Returns:
+//
+// - *MovieDetailsAppendResponse: the response containing the movie details and the appended sub-requests
+// - int: the HTTP status code
+// - error: if there was an error fetching the movie details
+func (c Client) GetMovieDetailsWithAppend(
+	ctx context.Context,
+	movieID int32,
+	language string,
+	appendToResponse []string,
+) (*MovieDetailsAppendResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+	if len(appendToResponse) > 0 {
+		query.Add(AppendToResponse, strings.Join(appendToResponse, ","))
+	}
+
+	// Include the images and videos in the requested language, in English and without language
+	if language != "" {
+		languageCode, _, _ := strings.Cut(language, "-")
+		includeLanguages := strings.Join([]string{languageCode, "en", "null"}, ",")
+		query.Add(IncludeImageLanguage, includeLanguages)
+		query.Add(IncludeVideoLanguage, includeLanguages)
+	}
+
+	parsedResp := &MovieDetailsAppendResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetMovieDetailsURL, movieID), query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// SearchKeywords searches for keywords by name
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - query: the search query
+// - page: the page number (optional, defaults to 1)
+//
+// Returns:
+//
+// - *KeywordListResponse: the response containing the matching keywords
+// - int: the HTTP status code
+// - error: if there was an error searching the keywords
+func (c Client) SearchKeywords(
+	ctx context.Context,
+	query string,
+	page int32,
+) (*KeywordListResponse, int, error) {
+	// Add query parameters
+	queryParameters := url.Values{}
+	queryParameters.Add(gotmdbapi.Query, query)
+	gotmdbapi.AddPageQueryParameter(queryParameters, page)
+
+	parsedResp := &KeywordListResponse{}
+	statusCode, err := c.get(ctx, SearchKeywordsURL, queryParameters, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// SearchCompanies searches for companies by name
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - query: the search query
+// - page: the page number (optional, defaults to 1)
+//
+// Returns:
+//
+// - *CompanyListResponse: the response containing the matching companies
+// - int: the HTTP status code
+// - error: if there was an error searching the companies
+func (c Client) SearchCompanies(
+	ctx context.Context,
+	query string,
+	page int32,
+) (*CompanyListResponse, int, error) {
+	// Add query parameters
+	queryParameters := url.Values{}
+	queryParameters.Add(gotmdbapi.Query, query)
+	gotmdbapi.AddPageQueryParameter(queryParameters, page)
+
+	parsedResp := &CompanyListResponse{}
+	statusCode, err := c.get(ctx, SearchCompaniesURL, queryParameters, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetCollection fetches the details of a given collection, including the movies that belong to it
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - collectionID: the ID of the collection
+// - language: the language code (optional, defaults to "en-US")
+//
+// Returns:
+//
+// - *CollectionResponse: the response containing the collection details
+// - int: the HTTP status code
+// - error: if there was an error fetching the collection
+func (c Client) GetCollection(
+	ctx context.Context,
+	collectionID int32,
+	language string,
+) (*CollectionResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+
+	parsedResp := &CollectionResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetCollectionURL, collectionID), query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetCompanyDetails fetches the details of a given company
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - companyID: the ID of the company
+//
+// Returns:
+//
+// - *CompanyDetailsResponse: the response containing the company details
+// - int: the HTTP status code
+// - error: if there was an error fetching the company details
+func (c Client) GetCompanyDetails(
+	ctx context.Context,
+	companyID int32,
+) (*CompanyDetailsResponse, int, error) {
+	parsedResp := &CompanyDetailsResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetCompanyDetailsURL, companyID), nil, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetMovieCertifications fetches the movie certifications of every country
+//
+// Parameters:
+//
+// - ctx: the context of the request
+//
+// Returns:
+//
+// - *CertificationsResponse: the response containing the certifications by country
+// - int: the HTTP status code
+// - error: if there was an error fetching the certifications
+func (c Client) GetMovieCertifications(ctx context.Context) (*CertificationsResponse, int, error) {
+	parsedResp := &CertificationsResponse{}
+	statusCode, err := c.get(ctx, GetMovieCertificationsURL, nil, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetMovieReleaseDates fetches the release dates and certifications of a given movie for every country
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - movieID: the ID of the movie
+//
+// Returns:
+//
+// - *MovieReleaseDatesResponse: the response containing the release dates by country
+// - int: the HTTP status code
+// - error: if there was an error fetching the release dates
+func (c Client) GetMovieReleaseDates(
+	ctx context.Context,
+	movieID int32,
+) (*MovieReleaseDatesResponse, int, error) {
+	parsedResp := &MovieReleaseDatesResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetMovieReleaseDatesURL, movieID), nil, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// GetTrendingMovies fetches the trending movies of a given time window
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - timeWindow: the time window (TrendingTimeWindowDay or TrendingTimeWindowWeek)
+// - language: the language code (optional, defaults to "en-US")
+// - page: the page number (optional, defaults to 1)
+//
+// Returns:
+//
+// - *gotmdbapi.MovieListResponse: the response containing the trending movies
+// - int: the HTTP status code
+// - error: if there was an error fetching the trending movies
+func (c Client) GetTrendingMovies(
+	ctx context.Context,
+	timeWindow string,
+	language string,
+	page int32,
+) (*gotmdbapi.MovieListResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+	gotmdbapi.AddPageQueryParameter(query, page)
+
+	parsedResp := &gotmdbapi.MovieListResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(GetTrendingMoviesURL, timeWindow), query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// CheckAPIKey validates the API key against the TMDB API
+//
+// Parameters:
+//
+// - ctx: the context of the request
+//
+// Returns:
+//
+// - *AuthenticationResponse: the response of the API key validation
+// - int: the HTTP status code
+// - error: if there was an error validating the API key, including a rejected key
+func (c Client) CheckAPIKey(ctx context.Context) (*AuthenticationResponse, int, error) {
+	parsedResp := &AuthenticationResponse{}
+	statusCode, err := c.get(ctx, GetAuthenticationURL, nil, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
+
+// FindByExternalID finds the movies with the given external ID
+//
+// Parameters:
+//
+// - ctx: the context of the request
+// - externalID: the external ID, such as an IMDb ID
+// - externalSource: the TMDB external source of the ID
+// - language: the language code (optional, defaults to "en-US")
+//
+// Returns:
+//
+// - *FindResponse: the response containing the found movies
+// - int: the HTTP status code
+// - error: if there was an error finding the movies
+func (c Client) FindByExternalID(
+	ctx context.Context,
+	externalID string,
+	externalSource string,
+	language string,
+) (*FindResponse, int, error) {
+	// Add query parameters
+	query := url.Values{}
+	query.Set(ExternalSource, externalSource)
+	gotmdbapi.AddLanguageQueryParameter(query, language)
+
+	parsedResp := &FindResponse{}
+	statusCode, err := c.get(ctx, fmt.Sprintf(FindByExternalIDURL, url.PathEscape(externalID)), query, parsedResp)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return parsedResp, statusCode, nil
+}
@@ -1,11 +1,19 @@
 package service
 
 import (
-	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+	"time"
 
 	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
 )
 
+const (
+	// FallbackLanguage is the language used when the requested language has no results
+	FallbackLanguage = "en-US"
+
+	// RequestTimeout is the timeout of the requests made to the TMDB API endpoints that gotmdbapi does not cover
+	RequestTimeout = 10 * time.Second
+)
+
 const (
 	// EnvTMDBAPIKey is the TMDB API key environment variable
 	EnvTMDBAPIKey = "TMDB_API_KEY"
@@ -32,6 +40,14 @@ const (
 
 	// EnvAvatarImageWidthSize is the TMDB image width size for user avatar images environment variable
 	EnvAvatarImageWidthSize = "TMDB_AVATAR_IMAGE_WIDTH_SIZE"
+
+	// EnvWatchProviderLogoImageWidthSize is the TMDB image width size for watch provider logo images environment
+	// variable
+	EnvWatchProviderLogoImageWidthSize = "TMDB_WATCH_PROVIDER_LOGO_IMAGE_WIDTH_SIZE"
+
+	// EnvMovieImageWidthSize is the TMDB image width size for movie backdrop, logo and poster images on the movie
+	// images gallery environment variable
+	EnvMovieImageWidthSize = "TMDB_MOVIE_IMAGE_WIDTH_SIZE"
 )
 
 var (
@@ -39,7 +55,7 @@ var (
 	TMDBAPIKey string
 
 	// TMDBClient is the TMDB API client
-	TMDBClient *gotmdbapi.Client
+	TMDBClient *Client
 
 	// CastMemberProfileImageWidthSize is the TMDB image width size for cast member profile images
 	CastMemberProfileImageWidthSize int
@@ -58,6 +74,13 @@ var (
 
 	// AvatarImageWidthSize is the TMDB image width size for user avatar images
 	AvatarImageWidthSize int
+
+	// WatchProviderLogoImageWidthSize is the TMDB image width size for watch provider logo images
+	WatchProviderLogoImageWidthSize int
+
+	// MovieImageWidthSize is the TMDB image width size for movie backdrop, logo and poster images on the movie images
+	// gallery
+	MovieImageWidthSize int
 )
 
 // Load loads the TMDB API key
@@ -78,6 +101,8 @@ func Load() {
 		EnvProductionCompanyLogoImageWidthSize: &ProductionCompanyLogoImageWidthSize,
 		EnvMovieDetailsPosterImageWidthSize:    &MovieDetailsPosterImageWidthSize,
 		EnvAvatarImageWidthSize:                &AvatarImageWidthSize,
+		EnvWatchProviderLogoImageWidthSize:     &WatchProviderLogoImageWidthSize,
+		EnvMovieImageWidthSize:                 &MovieImageWidthSize,
 	} {
 		if err := internalloader.Loader.LoadIntVariable(
 			env,
@@ -88,7 +113,7 @@ func Load() {
 	}
 
 	// Initialize the TMDB API client
-	tmdbClient, err := gotmdbapi.NewClient(TMDBAPIKey)
+	tmdbClient, err := NewClient(TMDBAPIKey)
 	if err != nil {
 		panic(err)
 	}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
@@ -825,3 +828,961 @@ func MapToWatchMonetizationTypes(types []v1.WatchMonetizationType) []gotmdbapi.W
 	}
 	return mappedTypes
 }
+
+// MapToWatchProvider maps a TMDB API watch provider to a gRPC watch provider
+//
+// Parameters:
+//
+// - provider: the TMDB API watch provider to map
+//
+// Returns:
+//
+// - *v1.WatchProvider: the mapped gRPC watch provider
+func MapToWatchProvider(provider *WatchProvider) *v1.WatchProvider {
+	if provider == nil {
+		return &v1.WatchProvider{}
+	}
+
+	// Parse logo path from relative to full URL
+	var logoURL *string
+	if provider.LogoPath != nil && *provider.LogoPath != "" {
+		logoURL = new(string)
+		*logoURL = fmt.Sprintf(
+			gotmdbapi.ImageVariableQualityURL,
+			WatchProviderLogoImageWidthSize,
+			(*provider.LogoPath)[1:],
+		)
+	}
+
+	return &v1.WatchProvider{
+		Id:              provider.ProviderID,
+		Name:            provider.ProviderName,
+		LogoUrl:         logoURL,
+		DisplayPriority: provider.DisplayPriority,
+	}
+}
+
+// MapToWatchProviders maps a slice of TMDB API watch providers to a slice of gRPC watch providers
+//
+// Parameters:
+//
+// - providers: the slice of TMDB API watch providers to map
+//
+// Returns:
+//
+// - []*v1.WatchProvider: the mapped slice of gRPC watch providers
+func MapToWatchProviders(providers []WatchProvider) []*v1.WatchProvider {
+	mappedProviders := make([]*v1.WatchProvider, len(providers))
+	for i := range providers {
+		mappedProviders[i] = MapToWatchProvider(&providers[i])
+	}
+	return mappedProviders
+}
+
+// MapToWatchProviderGroups maps the TMDB API watch providers of a region to gRPC watch provider groups, one per
+// watch monetization type that has at least one provider
+//
+// Parameters:
+//
+// - providers: the TMDB API watch providers of the region to map
+//
+// Returns:
+//
+// - []*v1.WatchProviderGroup: the mapped gRPC watch provider groups
+func MapToWatchProviderGroups(providers *RegionWatchProviders) []*v1.WatchProviderGroup {
+	if providers == nil {
+		return []*v1.WatchProviderGroup{}
+	}
+
+	mappedGroups := make([]*v1.WatchProviderGroup, 0, 5)
+	for _, group := range []struct {
+		monetizationType v1.WatchMonetizationType
+		providers        []WatchProvider
+	}{
+		{v1.WatchMonetizationType_FLATRATE, providers.Flatrate},
+		{v1.WatchMonetizationType_RENT, providers.Rent},
+		{v1.WatchMonetizationType_BUY, providers.Buy},
+		{v1.WatchMonetizationType_ADS, providers.Ads},
+		{v1.WatchMonetizationType_FREE, providers.Free},
+	} {
+		if len(group.providers) == 0 {
+			continue
+		}
+		mappedGroups = append(
+			mappedGroups, &v1.WatchProviderGroup{
+				MonetizationType: group.monetizationType,
+				Providers:        MapToWatchProviders(group.providers),
+			},
+		)
+	}
+	return mappedGroups
+}
+
+// MapToGetMovieWatchProvidersResponse maps a TMDB API movie watch providers response to a gRPC get movie watch
+// providers response for the given region
+//
+// Parameters:
+//
+// - response: the TMDB API movie watch providers response to map
+// - region: the ISO 3166-1 region code to map the watch providers of
+//
+// Returns:
+//
+// - *v1.GetMovieWatchProvidersResponse: the mapped gRPC get movie watch providers response
+func MapToGetMovieWatchProvidersResponse(
+	response *MovieWatchProvidersResponse,
+	region string,
+) *v1.GetMovieWatchProvidersResponse {
+	if response == nil {
+		return &v1.GetMovieWatchProvidersResponse{}
+	}
+
+	// Get the watch providers of the region, if any
+	regionProviders, ok := response.Results[region]
+	if !ok {
+		return &v1.GetMovieWatchProvidersResponse{
+			Id:     response.ID,
+			Region: region,
+			Groups: []*v1.WatchProviderGroup{},
+		}
+	}
+
+	return &v1.GetMovieWatchProvidersResponse{
+		Id:     response.ID,
+		Region: region,
+		Link:   regionProviders.Link,
+		Groups: MapToWatchProviderGroups(&regionProviders),
+	}
+}
+
+// MapToWatchProviderRegion maps a TMDB API watch provider region to a gRPC watch provider region
+//
+// Parameters:
+//
+// - region: the TMDB API watch provider region to map
+//
+// Returns:
+//
+// - *v1.WatchProviderRegion: the mapped gRPC watch provider region
+func MapToWatchProviderRegion(region *WatchProviderRegion) *v1.WatchProviderRegion {
+	if region == nil {
+		return &v1.WatchProviderRegion{}
+	}
+	return &v1.WatchProviderRegion{
+		Iso_3166_1:  region.ISO3166_1,
+		EnglishName: region.EnglishName,
+		NativeName:  region.NativeName,
+	}
+}
+
+// MapToListWatchProviderRegionsResponse maps a TMDB API watch provider regions response to a gRPC list watch
+// provider regions response
+//
+// Parameters:
+//
+// - response: the TMDB API watch provider regions response to map
+//
+// Returns:
+//
+// - *v1.ListWatchProviderRegionsResponse: the mapped gRPC list watch provider regions response
+func MapToListWatchProviderRegionsResponse(
+	response *WatchProviderRegionsResponse,
+) *v1.ListWatchProviderRegionsResponse {
+	if response == nil {
+		return &v1.ListWatchProviderRegionsResponse{}
+	}
+	mappedRegions := make([]*v1.WatchProviderRegion, len(response.Results))
+	for i := range response.Results {
+		mappedRegions[i] = MapToWatchProviderRegion(&response.Results[i])
+	}
+	return &v1.ListWatchProviderRegionsResponse{
+		Regions: mappedRegions,
+	}
+}
+
+// MapToVideoType maps a TMDB API video type to a gRPC video type
+//
+// Parameters:
+//
+// - videoType: the TMDB API video type to map
+//
+// Returns:
+//
+// - v1.VideoType: the mapped gRPC video type
+func MapToVideoType(videoType string) v1.VideoType {
+	switch videoType {
+	case VideoTypeTrailer:
+		return v1.VideoType_TRAILER
+	case VideoTypeTeaser:
+		return v1.VideoType_TEASER
+	case VideoTypeClip:
+		return v1.VideoType_CLIP
+	case VideoTypeFeaturette:
+		return v1.VideoType_FEATURETTE
+	default:
+		return v1.VideoType_VIDEO_TYPE_UNSPECIFIED
+	}
+}
+
+// MapToVideoURLs resolves the embed and watch URLs of a video from its site and key
+//
+// Parameters:
+//
+// - site: the TMDB API video site
+// - key: the TMDB API video key
+//
+// Returns:
+//
+// - *string: the embed URL, nil if the site is not supported
+// - *string: the watch URL, nil if the site is not supported
+func MapToVideoURLs(site, key string) (embedURL, watchURL *string) {
+	if key == "" {
+		return nil, nil
+	}
+
+	var embedFormat, watchFormat string
+	switch site {
+	case VideoSiteYouTube:
+		embedFormat, watchFormat = YouTubeEmbedURL, YouTubeWatchURL
+	case VideoSiteVimeo:
+		embedFormat, watchFormat = VimeoEmbedURL, VimeoWatchURL
+	default:
+		return nil, nil
+	}
+
+	embedURL = new(string)
+	*embedURL = fmt.Sprintf(embedFormat, key)
+	watchURL = new(string)
+	*watchURL = fmt.Sprintf(watchFormat, key)
+	return embedURL, watchURL
+}
+
+// MapToVideo maps a TMDB API video to a gRPC video
+//
+// Parameters:
+//
+// - video: the TMDB API video to map
+//
+// Returns:
+//
+// - *v1.Video: the mapped gRPC video
+func MapToVideo(video *Video) *v1.Video {
+	if video == nil {
+		return &v1.Video{}
+	}
+
+	// Resolve the embed and watch URLs
+	embedURL, watchURL := MapToVideoURLs(video.Site, video.Key)
+
+	return &v1.Video{
+		Id:       video.ID,
+		Name:     video.Name,
+		Key:      video.Key,
+		Site:     video.Site,
+		Size:     video.Size,
+		Type:     MapToVideoType(video.Type),
+		Official: video.Official,
+		Language: video.ISO639_1,
+		Region:   video.ISO3166_1,
+		PublishedAt: func() *timestamppb.Timestamp {
+			parsedTime, err := time.Parse(time.RFC3339, video.PublishedAt)
+			if err != nil {
+				return nil
+			}
+			return timestamppb.New(parsedTime)
+		}(),
+		EmbedUrl: embedURL,
+		WatchUrl: watchURL,
+	}
+}
+
+// MapToVideos maps a slice of TMDB API videos to a slice of gRPC videos, with the official trailers first and the
+// rest of the videos sorted from the most to the least recently published
+//
+// Parameters:
+//
+// - videos: the slice of TMDB API videos to map
+//
+// Returns:
+//
+// - []*v1.Video: the mapped slice of gRPC videos
+func MapToVideos(videos []Video) []*v1.Video {
+	mappedVideos := make([]*v1.Video, len(videos))
+	for i := range videos {
+		mappedVideos[i] = MapToVideo(&videos[i])
+	}
+
+	// Sort the official trailers first
+	isOfficialTrailer := func(video *v1.Video) bool {
+		return video.GetOfficial() && video.GetType() == v1.VideoType_TRAILER
+	}
+	sort.SliceStable(
+		mappedVideos, func(i, j int) bool {
+			iOfficialTrailer, jOfficialTrailer := isOfficialTrailer(mappedVideos[i]), isOfficialTrailer(mappedVideos[j])
+			if iOfficialTrailer != jOfficialTrailer {
+				return iOfficialTrailer
+			}
+			return mappedVideos[i].GetPublishedAt().AsTime().After(mappedVideos[j].GetPublishedAt().AsTime())
+		},
+	)
+	return mappedVideos
+}
+
+// MapToGetMovieVideosResponse maps a TMDB API movie videos response to a gRPC get movie videos response
+//
+// Parameters:
+//
+// - response: the TMDB API movie videos response to map
+//
+// Returns:
+//
+// - *v1.GetMovieVideosResponse: the mapped gRPC get movie videos response
+func MapToGetMovieVideosResponse(response *MovieVideosResponse) *v1.GetMovieVideosResponse {
+	if response == nil {
+		return &v1.GetMovieVideosResponse{}
+	}
+	return &v1.GetMovieVideosResponse{
+		Id:     response.ID,
+		Videos: MapToVideos(response.Results),
+	}
+}
+
+// MapToImage maps a TMDB API image to a gRPC movie image
+//
+// Parameters:
+//
+// - image: the TMDB API image to map
+//
+// Returns:
+//
+// - *v1.MovieImage: the mapped gRPC movie image
+func MapToImage(image *Image) *v1.MovieImage {
+	if image == nil {
+		return &v1.MovieImage{}
+	}
+
+	// Parse file path from relative to full URL
+	var imageURL string
+	if image.FilePath != "" {
+		imageURL = fmt.Sprintf(
+			gotmdbapi.ImageVariableQualityURL,
+			MovieImageWidthSize,
+			image.FilePath[1:],
+		)
+	}
+
+	return &v1.MovieImage{
+		Url:         imageURL,
+		Width:       image.Width,
+		Height:      image.Height,
+		AspectRatio: float64(image.AspectRatio),
+		Language:    image.ISO639_1,
+		VoteAverage: float64(image.VoteAverage),
+		VoteCount:   image.VoteCount,
+	}
+}
+
+// MapToImages maps a slice of TMDB API images to a slice of gRPC movie images
+//
+// Parameters:
+//
+// - images: the slice of TMDB API images to map
+//
+// Returns:
+//
+// - []*v1.MovieImage: the mapped slice of gRPC movie images
+func MapToImages(images []Image) []*v1.MovieImage {
+	mappedImages := make([]*v1.MovieImage, len(images))
+	for i := range images {
+		mappedImages[i] = MapToImage(&images[i])
+	}
+	return mappedImages
+}
+
+// MapToMovieImages maps a TMDB API movie images response to gRPC movie images
+//
+// Parameters:
+//
+// - response: the TMDB API movie images response to map
+//
+// Returns:
+//
+// - *v1.MovieImages: the mapped gRPC movie images
+func MapToMovieImages(response *MovieImagesResponse) *v1.MovieImages {
+	if response == nil {
+		return &v1.MovieImages{}
+	}
+	return &v1.MovieImages{
+		Backdrops: MapToImages(response.Backdrops),
+		Logos:     MapToImages(response.Logos),
+		Posters:   MapToImages(response.Posters),
+	}
+}
+
+// MapToReleaseType maps a TMDB API release type to a gRPC release type
+//
+// Parameters:
+//
+// - releaseType: the TMDB API release type to map
+//
+// Returns:
+//
+// - v1.ReleaseType: the mapped gRPC release type
+func MapToReleaseType(releaseType int32) v1.ReleaseType {
+	switch releaseType {
+	case int32(v1.ReleaseType_PREMIERE):
+		return v1.ReleaseType_PREMIERE
+	case int32(v1.ReleaseType_THEATRICAL_LIMITED):
+		return v1.ReleaseType_THEATRICAL_LIMITED
+	case int32(v1.ReleaseType_THEATRICAL):
+		return v1.ReleaseType_THEATRICAL
+	case int32(v1.ReleaseType_DIGITAL):
+		return v1.ReleaseType_DIGITAL
+	case int32(v1.ReleaseType_PHYSICAL):
+		return v1.ReleaseType_PHYSICAL
+	case int32(v1.ReleaseType_TV):
+		return v1.ReleaseType_TV
+	default:
+		return v1.ReleaseType_RELEASE_TYPE_UNSPECIFIED
+	}
+}
+
+// MapToReleaseDate maps a TMDB API release date to a gRPC release date
+//
+// Parameters:
+//
+// - releaseDate: the TMDB API release date to map
+//
+// Returns:
+//
+// - *v1.ReleaseDate: the mapped gRPC release date
+func MapToReleaseDate(releaseDate *ReleaseDate) *v1.ReleaseDate {
+	if releaseDate == nil {
+		return &v1.ReleaseDate{}
+	}
+
+	// TMDB returns the release date as a date time, keep only the date part
+	date, _, _ := strings.Cut(releaseDate.ReleaseDate, "T")
+
+	return &v1.ReleaseDate{
+		Certification: releaseDate.Certification,
+		Descriptors:   releaseDate.Descriptors,
+		Language:      releaseDate.ISO639_1,
+		Note:          releaseDate.Note,
+		ReleaseDate:   MapDateStringToTimestamp(date),
+		Type:          MapToReleaseType(releaseDate.Type),
+	}
+}
+
+// MapToCountryReleaseDates maps a TMDB API country release dates to a gRPC country release dates
+//
+// Parameters:
+//
+// - countryReleaseDates: the TMDB API country release dates to map
+//
+// Returns:
+//
+// - *v1.CountryReleaseDates: the mapped gRPC country release dates
+func MapToCountryReleaseDates(countryReleaseDates *CountryReleaseDates) *v1.CountryReleaseDates {
+	if countryReleaseDates == nil {
+		return &v1.CountryReleaseDates{}
+	}
+	mappedReleaseDates := make([]*v1.ReleaseDate, len(countryReleaseDates.ReleaseDates))
+	for i := range countryReleaseDates.ReleaseDates {
+		mappedReleaseDates[i] = MapToReleaseDate(&countryReleaseDates.ReleaseDates[i])
+	}
+	return &v1.CountryReleaseDates{
+		Iso_3166_1:   countryReleaseDates.ISO3166_1,
+		ReleaseDates: mappedReleaseDates,
+	}
+}
+
+// MapToCountriesReleaseDates maps a slice of TMDB API country release dates to a slice of gRPC country release dates
+//
+// Parameters:
+//
+// - countriesReleaseDates: the slice of TMDB API country release dates to map
+//
+// Returns:
+//
+// - []*v1.CountryReleaseDates: the mapped slice of gRPC country release dates
+func MapToCountriesReleaseDates(countriesReleaseDates []CountryReleaseDates) []*v1.CountryReleaseDates {
+	mappedCountriesReleaseDates := make([]*v1.CountryReleaseDates, len(countriesReleaseDates))
+	for i := range countriesReleaseDates {
+		mappedCountriesReleaseDates[i] = MapToCountryReleaseDates(&countriesReleaseDates[i])
+	}
+	return mappedCountriesReleaseDates
+}
+
+// MapToOptionalExternalID maps a TMDB API external ID to an optional external ID, as TMDB returns the missing IDs
+// either as null or as an empty string
+//
+// Parameters:
+//
+// - externalID: the TMDB API external ID to map
+//
+// Returns:
+//
+// - *string: the mapped external ID, nil if it is missing
+func MapToOptionalExternalID(externalID *string) *string {
+	if externalID == nil || *externalID == "" {
+		return nil
+	}
+	return externalID
+}
+
+// MapToMovieExternalIDs maps a TMDB API movie external IDs response to the gRPC movie external IDs
+//
+// Parameters:
+//
+// - response: the TMDB API movie external IDs response to map
+//
+// Returns:
+//
+// - *v1.MovieExternalIds: the mapped gRPC movie external IDs
+func MapToMovieExternalIDs(response *MovieExternalIDsResponse) *v1.MovieExternalIds {
+	if response == nil {
+		return &v1.MovieExternalIds{}
+	}
+	return &v1.MovieExternalIds{
+		ImdbId:      MapToOptionalExternalID(response.IMDbID),
+		WikidataId:  MapToOptionalExternalID(response.WikidataID),
+		FacebookId:  MapToOptionalExternalID(response.FacebookID),
+		InstagramId: MapToOptionalExternalID(response.InstagramID),
+		TwitterId:   MapToOptionalExternalID(response.TwitterID),
+	}
+}
+
+// MapToAppendToResponse maps a slice of gRPC movie details includes to a slice of TMDB API append to response values
+//
+// Parameters:
+//
+// - includes: the slice of gRPC movie details includes to map
+//
+// Returns:
+//
+// - []string: the mapped slice of TMDB API append to response values, without duplicates
+func MapToAppendToResponse(includes []v1.MovieDetailsInclude) []string {
+	mappedIncludes := make([]string, 0, len(includes))
+	for _, include := range includes {
+		var mappedInclude string
+		switch include {
+		case v1.MovieDetailsInclude_CREDITS:
+			mappedInclude = AppendCredits
+		case v1.MovieDetailsInclude_VIDEOS:
+			mappedInclude = AppendVideos
+		case v1.MovieDetailsInclude_IMAGES:
+			mappedInclude = AppendImages
+		case v1.MovieDetailsInclude_RELEASE_DATES:
+			mappedInclude = AppendReleaseDates
+		case v1.MovieDetailsInclude_SIMILAR:
+			mappedInclude = AppendSimilar
+		case v1.MovieDetailsInclude_EXTERNAL_IDS:
+			mappedInclude = AppendExternalIDs
+		default:
+			continue
+		}
+		if !slices.Contains(mappedIncludes, mappedInclude) {
+			mappedIncludes = append(mappedIncludes, mappedInclude)
+		}
+	}
+	return mappedIncludes
+}
+
+// MapToGetMovieDetailsAppendResponse maps a TMDB API movie details response with appended sub-requests to a gRPC
+// movie details response, filling only the appended sections that were returned
+//
+// Parameters:
+//
+// - response: the TMDB API movie details response with appended sub-requests to map
+//
+// Returns:
+//
+// - *v1.GetMovieDetailsResponse: the mapped gRPC movie details response
+func MapToGetMovieDetailsAppendResponse(response *MovieDetailsAppendResponse) *v1.GetMovieDetailsResponse {
+	if response == nil {
+		return &v1.GetMovieDetailsResponse{}
+	}
+
+	// Map the movie details
+	mappedResponse := MapToGetMovieDetailsResponse(&response.MovieDetailsResponse)
+
+	// Map the appended sub-requests
+	if response.Credits != nil {
+		mappedResponse.Credits = MapToGetMovieCreditsResponse(response.Credits)
+	}
+	if response.Videos != nil {
+		mappedResponse.Videos = MapToVideos(response.Videos.Results)
+	}
+	if response.Images != nil {
+		mappedResponse.Images = MapToMovieImages(response.Images)
+	}
+	if response.ReleaseDates != nil {
+		mappedResponse.ReleaseDates = MapToCountriesReleaseDates(response.ReleaseDates.Results)
+	}
+	if response.Similar != nil {
+		mappedResponse.Similar = MapToSimilarMoviesResponse(response.Similar)
+	}
+	if response.ExternalIDs != nil {
+		mappedResponse.ExternalIds = MapToMovieExternalIDs(response.ExternalIDs)
+	}
+	return mappedResponse
+}
+
+// MapToKeyword maps a TMDB API keyword to a gRPC keyword
+//
+// Parameters:
+//
+// - keyword: the TMDB API keyword to map
+//
+// Returns:
+//
+// - *v1.Keyword: the mapped gRPC keyword
+func MapToKeyword(keyword *Keyword) *v1.Keyword {
+	if keyword == nil {
+		return &v1.Keyword{}
+	}
+	return &v1.Keyword{
+		Id:   keyword.ID,
+		Name: keyword.Name,
+	}
+}
+
+// MapToKeywords maps a slice of TMDB API keywords to a slice of gRPC keywords
+//
+// Parameters:
+//
+// - keywords: the slice of TMDB API keywords to map
+//
+// Returns:
+//
+// - []*v1.Keyword: the mapped slice of gRPC keywords
+func MapToKeywords(keywords []Keyword) []*v1.Keyword {
+	mappedKeywords := make([]*v1.Keyword, len(keywords))
+	for i := range keywords {
+		mappedKeywords[i] = MapToKeyword(&keywords[i])
+	}
+	return mappedKeywords
+}
+
+// MapToSearchKeywordsResponse maps a TMDB API keyword list response to a gRPC search keywords response
+//
+// Parameters:
+//
+// - response: the TMDB API keyword list response to map
+//
+// Returns:
+//
+// - *v1.SearchKeywordsResponse: the mapped gRPC search keywords response
+func MapToSearchKeywordsResponse(response *KeywordListResponse) *v1.SearchKeywordsResponse {
+	if response == nil {
+		return &v1.SearchKeywordsResponse{}
+	}
+	return &v1.SearchKeywordsResponse{
+		Page:         response.Page,
+		Results:      MapToKeywords(response.Results),
+		TotalPages:   response.TotalPages,
+		TotalResults: response.TotalResults,
+	}
+}
+
+// MapToSearchCompaniesResponse maps a TMDB API company list response to a gRPC search companies response
+//
+// Parameters:
+//
+// - response: the TMDB API company list response to map
+//
+// Returns:
+//
+// - *v1.SearchCompaniesResponse: the mapped gRPC search companies response
+func MapToSearchCompaniesResponse(response *CompanyListResponse) *v1.SearchCompaniesResponse {
+	if response == nil {
+		return &v1.SearchCompaniesResponse{}
+	}
+	return &v1.SearchCompaniesResponse{
+		Page:         response.Page,
+		Results:      MapToProductionCompanies(response.Results),
+		TotalPages:   response.TotalPages,
+		TotalResults: response.TotalResults,
+	}
+}
+
+// MapToGetCollectionResponse maps a TMDB API collection response to a gRPC get collection response
+//
+// Parameters:
+//
+// - response: the TMDB API collection response to map
+//
+// Returns:
+//
+// - *v1.GetCollectionResponse: the mapped gRPC get collection response
+func MapToGetCollectionResponse(response *CollectionResponse) *v1.GetCollectionResponse {
+	if response == nil {
+		return &v1.GetCollectionResponse{}
+	}
+
+	// Parse poster path from relative to full URL
+	var posterURL string
+	if response.PosterPath != "" {
+		posterURL = fmt.Sprintf(
+			gotmdbapi.ImageVariableQualityURL,
+			MovieDetailsPosterImageWidthSize,
+			response.PosterPath[1:],
+		)
+	}
+
+	// Sort the movies of the collection by release date
+	parts := MapToSimpleMovies(response.Parts)
+	sort.SliceStable(
+		parts, func(i, j int) bool {
+			if parts[i].GetReleaseDate() == nil {
+				return false
+			}
+			if parts[j].GetReleaseDate() == nil {
+				return true
+			}
+			return parts[i].GetReleaseDate().AsTime().Before(parts[j].GetReleaseDate().AsTime())
+		},
+	)
+
+	return &v1.GetCollectionResponse{
+		Id:        response.ID,
+		Name:      response.Name,
+		Overview:  response.Overview,
+		PosterUrl: posterURL,
+		Parts:     parts,
+	}
+}
+
+// MapToGetCompanyDetailsResponse maps a TMDB API company details response to a gRPC get company details response
+//
+// Parameters:
+//
+// - response: the TMDB API company details response to map
+//
+// Returns:
+//
+// - *v1.GetCompanyDetailsResponse: the mapped gRPC get company details response
+func MapToGetCompanyDetailsResponse(response *CompanyDetailsResponse) *v1.GetCompanyDetailsResponse {
+	if response == nil {
+		return &v1.GetCompanyDetailsResponse{}
+	}
+
+	// Map the parent company, if any
+	var parentCompany *v1.ProductionCompany
+	if response.ParentCompany != nil {
+		parentCompany = MapToProductionCompany(response.ParentCompany)
+	}
+
+	return &v1.GetCompanyDetailsResponse{
+		Company:       MapToProductionCompany(&response.ProductionCompany),
+		Description:   response.Description,
+		Headquarters:  response.Headquarters,
+		Homepage:      response.Homepage,
+		ParentCompany: parentCompany,
+	}
+}
+
+// MapToCertification maps a TMDB API certification to a gRPC certification
+//
+// Parameters:
+//
+// - certification: the TMDB API certification to map
+//
+// Returns:
+//
+// - *v1.Certification: the mapped gRPC certification
+func MapToCertification(certification *Certification) *v1.Certification {
+	if certification == nil {
+		return &v1.Certification{}
+	}
+	return &v1.Certification{
+		Certification: certification.Certification,
+		Meaning:       certification.Meaning,
+		Order:         certification.Order,
+	}
+}
+
+// MapToGetMovieCertificationsResponse maps a TMDB API certifications response to a gRPC get movie certifications
+// response, sorted by country code and certification order
+//
+// Parameters:
+//
+// - response: the TMDB API certifications response to map
+// - country: the ISO 3166-1 country code to filter by (optional)
+//
+// Returns:
+//
+// - *v1.GetMovieCertificationsResponse: the mapped gRPC get movie certifications response
+func MapToGetMovieCertificationsResponse(
+	response *CertificationsResponse,
+	country string,
+) *v1.GetMovieCertificationsResponse {
+	if response == nil {
+		return &v1.GetMovieCertificationsResponse{}
+	}
+
+	// Get the sorted country codes
+	countries := make([]string, 0, len(response.Certifications))
+	for countryCode := range response.Certifications {
+		if country != "" && countryCode != country {
+			continue
+		}
+		countries = append(countries, countryCode)
+	}
+	slices.Sort(countries)
+
+	mappedCountries := make([]*v1.CountryCertifications, len(countries))
+	for i, countryCode := range countries {
+		certifications := response.Certifications[countryCode]
+		mappedCertifications := make([]*v1.Certification, len(certifications))
+		for j := range certifications {
+			mappedCertifications[j] = MapToCertification(&certifications[j])
+		}
+		sort.SliceStable(
+			mappedCertifications, func(i, j int) bool {
+				return mappedCertifications[i].GetOrder() < mappedCertifications[j].GetOrder()
+			},
+		)
+		mappedCountries[i] = &v1.CountryCertifications{
+			Iso_3166_1:     countryCode,
+			Certifications: mappedCertifications,
+		}
+	}
+	return &v1.GetMovieCertificationsResponse{
+		Countries: mappedCountries,
+	}
+}
+
+// MapToGetMovieReleaseDatesResponse maps a TMDB API movie release dates response to a gRPC get movie release dates
+// response
+//
+// Parameters:
+//
+// - response: the TMDB API movie release dates response to map
+// - country: the ISO 3166-1 country code to filter by (optional)
+// - types: the release types to filter by (optional)
+//
+// Returns:
+//
+// - *v1.GetMovieReleaseDatesResponse: the mapped gRPC get movie release dates response
+func MapToGetMovieReleaseDatesResponse(
+	response *MovieReleaseDatesResponse,
+	country string,
+	types []v1.ReleaseType,
+) *v1.GetMovieReleaseDatesResponse {
+	if response == nil {
+		return &v1.GetMovieReleaseDatesResponse{}
+	}
+
+	mappedResults := make([]*v1.CountryReleaseDates, 0, len(response.Results))
+	for _, mappedCountryReleaseDates := range MapToCountriesReleaseDates(response.Results) {
+		// Filter by country
+		if country != "" && mappedCountryReleaseDates.GetIso_3166_1() != country {
+			continue
+		}
+
+		// Filter by release type
+		if len(types) > 0 {
+			mappedCountryReleaseDates.ReleaseDates = slices.DeleteFunc(
+				mappedCountryReleaseDates.ReleaseDates,
+				func(releaseDate *v1.ReleaseDate) bool {
+					return !slices.Contains(types, releaseDate.GetType())
+				},
+			)
+			if len(mappedCountryReleaseDates.ReleaseDates) == 0 {
+				continue
+			}
+		}
+		mappedResults = append(mappedResults, mappedCountryReleaseDates)
+	}
+	return &v1.GetMovieReleaseDatesResponse{
+		Id:      response.ID,
+		Results: mappedResults,
+	}
+}
+
+// MapToTrendingTimeWindow maps a gRPC trending time window to a TMDB API trending time window
+//
+// Parameters:
+//
+// - timeWindow: the gRPC trending time window to map
+//
+// Returns:
+//
+// - string: the mapped TMDB API trending time window, defaults to TrendingTimeWindowDay
+func MapToTrendingTimeWindow(timeWindow v1.TrendingTimeWindow) string {
+	switch timeWindow {
+	case v1.TrendingTimeWindow_WEEK:
+		return TrendingTimeWindowWeek
+	default:
+		return TrendingTimeWindowDay
+	}
+}
+
+// MapToGetTrendingMoviesResponse maps a TMDB API movie list response to a gRPC trending movies response
+//
+// Parameters:
+//
+// - response: the TMDB API movie list response to map
+//
+// Returns:
+//
+// - *v1.GetTrendingMoviesResponse: the mapped gRPC trending movies response
+func MapToGetTrendingMoviesResponse(response *gotmdbapi.MovieListResponse) *v1.GetTrendingMoviesResponse {
+	if response == nil {
+		return &v1.GetTrendingMoviesResponse{}
+	}
+	return &v1.GetTrendingMoviesResponse{
+		Page:         response.Page,
+		Results:      MapToSimpleMovies(response.Results),
+		TotalPages:   response.TotalPages,
+		TotalResults: response.TotalResults,
+	}
+}
+
+// MapMovieDetailsToSimpleMovie maps a TMDB API movie details response to a simple movie
+//
+// Parameters:
+//
+// - response: the TMDB API movie details response to map
+//
+// Returns:
+//
+// - *v1.SimpleMovie: the mapped simple movie
+func MapMovieDetailsToSimpleMovie(response *gotmdbapi.MovieDetailsResponse) *v1.SimpleMovie {
+	if response == nil {
+		return &v1.SimpleMovie{}
+	}
+
+	// Parse poster path from relative to full URL
+	var posterURL string
+	if response.PosterPath != "" {
+		posterURL = fmt.Sprintf(
+			gotmdbapi.ImageVariableQualityURL,
+			SimpleMoviePosterImageWidthSize,
+			response.PosterPath[1:],
+		)
+	}
+
+	// Get the genre IDs
+	genreIDs := make([]int32, len(response.Genres))
+	for i, genre := range response.Genres {
+		genreIDs[i] = genre.ID
+	}
+
+	return &v1.SimpleMovie{
+		Adult:                response.Adult,
+		GenreIds:             genreIDs,
+		Id:                   response.ID,
+		OriginalLanguage:     response.OriginalLanguage,
+		OriginalTitle:        response.OriginalTitle,
+		Overview:             response.Overview,
+		Popularity:           MapToOptionalFloat64(response.Popularity),
+		PosterUrl:            posterURL,
+		ReleaseDate:          MapDateStringToTimestamp(response.ReleaseDate),
+		Title:                response.Title,
+		RatingAverageCritics: MapToOptionalFloat64(response.VoteAverage),
+		RatingCountCritics:   response.VoteCount,
+	}
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	gotmdbapi "github.com/ralvarezdev/go-tmdb-api"
+)
+
+const (
+	// GetMovieWatchProvidersURL is the TMDB API URL for getting the watch providers of a movie
+	GetMovieWatchProvidersURL = "https://api.themoviedb.org/3/movie/%d/watch/providers"
+
+	// GetWatchProviderRegionsURL is the TMDB API URL for getting the regions with watch provider data
+	GetWatchProviderRegionsURL = "https://api.themoviedb.org/3/watch/providers/regions"
+
+	// GetMovieVideosURL is the TMDB API URL for getting the videos of a movie
+	GetMovieVideosURL = "https://api.themoviedb.org/3/movie/%d/videos"
+
+	// GetMovieDetailsURL is the TMDB API URL for getting the details of a movie
+	GetMovieDetailsURL = "https://api.themoviedb.org/3/movie/%d"
+
+	// SearchKeywordsURL is the TMDB API URL for searching keywords
+	SearchKeywordsURL = "https://api.themoviedb.org/3/search/keyword"
+
+	// SearchCompaniesURL is the TMDB API URL for searching companies
+	SearchCompaniesURL = "https://api.themoviedb.org/3/search/company"
+
+	// GetCollectionURL is the TMDB API URL for getting the details of a collection
+	GetCollectionURL = "https://api.themoviedb.org/3/collection/%d"
+
+	// GetCompanyDetailsURL is the TMDB API URL for getting the details of a company
+	GetCompanyDetailsURL = "https://api.themoviedb.org/3/company/%d"
+
+	// GetMovieCertificationsURL is the TMDB API URL for getting the movie certifications of every country
+	GetMovieCertificationsURL = "https://api.themoviedb.org/3/certification/movie/list"
+
+	// GetMovieReleaseDatesURL is the TMDB API URL for getting the release dates of a movie
+	GetMovieReleaseDatesURL = "https://api.themoviedb.org/3/movie/%d/release_dates"
+
+	// GetTrendingMoviesURL is the TMDB API URL for getting the trending movies of a time window
+	GetTrendingMoviesURL = "https://api.themoviedb.org/3/trending/movie/%s"
+
+	// GetAuthenticationURL is the TMDB API URL for validating the API key
+	GetAuthenticationURL = "https://api.themoviedb.org/3/authentication"
+
+	// FindByExternalIDURL is the TMDB API URL for finding the TMDB objects by an external ID
+	FindByExternalIDURL = "https://api.themoviedb.org/3/find/%s"
+)
+
+const (
+	// ExternalSource is the query parameter for the source of the external ID to find
+	ExternalSource = "external_source"
+
+	// ExternalSourceIMDb is the TMDB external source of the IMDb IDs
+	ExternalSourceIMDb = "imdb_id"
+
+	// ExternalSourceWikidata is the TMDB external source of the Wikidata IDs
+	ExternalSourceWikidata = "wikidata_id"
+)
+
+const (
+	// TrendingTimeWindowDay is the TMDB trending time window for the last day
+	TrendingTimeWindowDay = "day"
+
+	// TrendingTimeWindowWeek is the TMDB trending time window for the last week
+	TrendingTimeWindowWeek = "week"
+)
+
+const (
+	// AppendToResponse is the query parameter for appending sub-requests to a movie details request
+	AppendToResponse = "append_to_response"
+
+	// IncludeImageLanguage is the query parameter for the languages of the appended images
+	IncludeImageLanguage = "include_image_language"
+
+	// IncludeVideoLanguage is the query parameter for the languages of the appended videos
+	IncludeVideoLanguage = "include_video_language"
+)
+
+const (
+	// AppendCredits is the append to response value for the movie credits
+	AppendCredits = "credits"
+
+	// AppendVideos is the append to response value for the movie videos
+	AppendVideos = "videos"
+
+	// AppendImages is the append to response value for the movie images
+	AppendImages = "images"
+
+	// AppendReleaseDates is the append to response value for the movie release dates
+	AppendReleaseDates = "release_dates"
+
+	// AppendSimilar is the append to response value for the similar movies
+	AppendSimilar = "similar"
+
+	// AppendExternalIDs is the append to response value for the movie external IDs
+	AppendExternalIDs = "external_ids"
+)
+
+const (
+	// VideoSiteYouTube is the TMDB video site for YouTube videos
+	VideoSiteYouTube = "YouTube"
+
+	// VideoSiteVimeo is the TMDB video site for Vimeo videos
+	VideoSiteVimeo = "Vimeo"
+
+	// VideoTypeTrailer is the TMDB video type for trailers
+	VideoTypeTrailer = "Trailer"
+
+	// VideoTypeTeaser is the TMDB video type for teasers
+	VideoTypeTeaser = "Teaser"
+
+	// VideoTypeClip is the TMDB video type for clips
+	VideoTypeClip = "Clip"
+
+	// VideoTypeFeaturette is the TMDB video type for featurettes
+	VideoTypeFeaturette = "Featurette"
+)
+
+const (
+	// YouTubeEmbedURL is the YouTube embed URL for a video key
+	YouTubeEmbedURL = "https://www.youtube.com/embed/%s"
+
+	// YouTubeWatchURL is the YouTube watch URL for a video key
+	YouTubeWatchURL = "https://www.youtube.com/watch?v=%s"
+
+	// VimeoEmbedURL is the Vimeo embed URL for a video key
+	VimeoEmbedURL = "https://player.vimeo.com/video/%s"
+
+	// VimeoWatchURL is the Vimeo watch URL for a video key
+	VimeoWatchURL = "https://vimeo.com/%s"
+)
+
+type (
+	// WatchProvider represents a watch provider of a movie
+	WatchProvider struct {
+		DisplayPriority int32   `json:"display_priority"`
+		LogoPath        *string `json:"logo_path,omitempty"`
+		ProviderID      int32   `json:"provider_id"`
+		ProviderName    string  `json:"provider_name"`
+	}
+
+	// RegionWatchProviders represents the watch providers of a movie in a region, grouped by monetization type
+	RegionWatchProviders struct {
+		Link     string          `json:"link"`
+		Flatrate []WatchProvider `json:"flatrate"`
+		Rent     []WatchProvider `json:"rent"`
+		Buy      []WatchProvider `json:"buy"`
+		Ads      []WatchProvider `json:"ads"`
+		Free     []WatchProvider `json:"free"`
+	}
+
+	// MovieWatchProvidersResponse represents a movie watch providers response, keyed by ISO 3166-1 region code
+	MovieWatchProvidersResponse struct {
+		ID      int32                           `json:"id"`
+		Results map[string]RegionWatchProviders `json:"results"`
+	}
+
+	// WatchProviderRegion represents a region with watch provider data
+	WatchProviderRegion struct {
+		// nolint:revive
+		ISO3166_1   string `json:"iso_3166_1"`
+		EnglishName string `json:"english_name"`
+		NativeName  string `json:"native_name"`
+	}
+
+	// WatchProviderRegionsResponse represents a watch provider regions response
+	WatchProviderRegionsResponse struct {
+		Results []WatchProviderRegion `json:"results"`
+	}
+
+	// Video represents a video of a movie
+	Video struct {
+		ID string `json:"id"`
+		// nolint:revive
+		ISO639_1 string `json:"iso_639_1"`
+		// nolint:revive
+		ISO3166_1   string `json:"iso_3166_1"`
+		Key         string `json:"key"`
+		Name        string `json:"name"`
+		Official    bool   `json:"official"`
+		PublishedAt string `json:"published_at"`
+		Site        string `json:"site"`
+		Size        int32  `json:"size"`
+		Type        string `json:"type"`
+	}
+
+	// MovieVideosResponse represents a movie videos response
+	MovieVideosResponse struct {
+		ID      int32   `json:"id"`
+		Results []Video `json:"results"`
+	}
+
+	// Image represents an image of a movie
+	Image struct {
+		AspectRatio float32 `json:"aspect_ratio"`
+		FilePath    string  `json:"file_path"`
+		Height      int32   `json:"height"`
+		// nolint:revive
+		ISO639_1    *string `json:"iso_639_1,omitempty"`
+		VoteAverage float32 `json:"vote_average"`
+		VoteCount   int32   `json:"vote_count"`
+		Width       int32   `json:"width"`
+	}
+
+	// MovieImagesResponse represents a movie images response
+	MovieImagesResponse struct {
+		ID        int32   `json:"id"`
+		Backdrops []Image `json:"backdrops"`
+		Logos     []Image `json:"logos"`
+		Posters   []Image `json:"posters"`
+	}
+
+	// ReleaseDate represents a release of a movie in a country
+	ReleaseDate struct {
+		Certification string   `json:"certification"`
+		Descriptors   []string `json:"descriptors"`
+		// nolint:revive
+		ISO639_1    string `json:"iso_639_1"`
+		Note        string `json:"note"`
+		ReleaseDate string `json:"release_date"`
+		Type        int32  `json:"type"`
+	}
+
+	// CountryReleaseDates represents the releases of a movie in a country
+	CountryReleaseDates struct {
+		// nolint:revive
+		ISO3166_1    string        `json:"iso_3166_1"`
+		ReleaseDates []ReleaseDate `json:"release_dates"`
+	}
+
+	// MovieReleaseDatesResponse represents a movie release dates response
+	MovieReleaseDatesResponse struct {
+		ID      int32                 `json:"id"`
+		Results []CountryReleaseDates `json:"results"`
+	}
+
+	// MovieExternalIDsResponse represents a movie external IDs response
+	MovieExternalIDsResponse struct {
+		IMDbID      *string `json:"imdb_id"`
+		WikidataID  *string `json:"wikidata_id"`
+		FacebookID  *string `json:"facebook_id"`
+		InstagramID *string `json:"instagram_id"`
+		TwitterID   *string `json:"twitter_id"`
+	}
+
+	// MovieDetailsAppendResponse represents a movie details response with the appended sub-requests
+	MovieDetailsAppendResponse struct {
+		gotmdbapi.MovieDetailsResponse
+		Credits      *gotmdbapi.MovieCreditsResponse `json:"credits,omitempty"`
+		Videos       *MovieVideosResponse            `json:"videos,omitempty"`
+		Images       *MovieImagesResponse            `json:"images,omitempty"`
+		ReleaseDates *MovieReleaseDatesResponse      `json:"release_dates,omitempty"`
+		Similar      *gotmdbapi.MovieListResponse    `json:"similar,omitempty"`
+		ExternalIDs  *MovieExternalIDsResponse       `json:"external_ids,omitempty"`
+	}
+
+	// Keyword represents a keyword
+	Keyword struct {
+		ID   int32  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// KeywordListResponse represents a keyword list response
+	KeywordListResponse struct {
+		Page         int32     `json:"page"`
+		Results      []Keyword `json:"results"`
+		TotalPages   int32     `json:"total_pages"`
+		TotalResults int32     `json:"total_results"`
+	}
+
+	// CompanyListResponse represents a company list response
+	CompanyListResponse struct {
+		Page         int32                         `json:"page"`
+		Results      []gotmdbapi.ProductionCompany `json:"results"`
+		TotalPages   int32                         `json:"total_pages"`
+		TotalResults int32                         `json:"total_results"`
+	}
+
+	// CollectionResponse represents a collection response
+	CollectionResponse struct {
+		BackdropPath string                  `json:"backdrop_path"`
+		ID           int32                   `json:"id"`
+		Name         string                  `json:"name"`
+		Overview     string                  `json:"overview"`
+		Parts        []gotmdbapi.SimpleMovie `json:"parts"`
+		PosterPath   string                  `json:"poster_path"`
+	}
+
+	// CompanyDetailsResponse represents a company details response
+	CompanyDetailsResponse struct {
+		gotmdbapi.ProductionCompany
+		Description   string                       `json:"description"`
+		Headquarters  string                       `json:"headquarters"`
+		Homepage      string                       `json:"homepage"`
+		ParentCompany *gotmdbapi.ProductionCompany `json:"parent_company,omitempty"`
+	}
+
+	// Certification represents a movie certification of a country
+	Certification struct {
+		Certification string `json:"certification"`
+		Meaning       string `json:"meaning"`
+		Order         int32  `json:"order"`
+	}
+
+	// CertificationsResponse represents a movie certifications response, keyed by ISO 3166-1 country code
+	CertificationsResponse struct {
+		Certifications map[string][]Certification `json:"certifications"`
+	}
+
+	// AuthenticationResponse represents an API key validation response
+	AuthenticationResponse struct {
+		Success       bool   `json:"success"`
+		StatusCode    int32  `json:"status_code"`
+		StatusMessage string `json:"status_message"`
+	}
+
+	// FindResponse represents a find by external ID response. Only the movie results are decoded
+	FindResponse struct {
+		MovieResults []gotmdbapi.SimpleMovie `json:"movie_results"`
+	}
+)
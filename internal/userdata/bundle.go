@@ -0,0 +1,291 @@
+package userdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// MovieList is an exported user movie list
+	MovieList struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		IsPublic    bool            `json:"is_public"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedAt   time.Time       `json:"updated_at"`
+		Items       []MovieListItem `json:"items"`
+	}
+
+	// MovieListItem is an exported movie of a user movie list
+	MovieListItem struct {
+		MovieID int32     `json:"movie_id"`
+		Note    string    `json:"note,omitempty"`
+		AddedAt time.Time `json:"added_at"`
+	}
+
+	// Comment is an exported comment written by a user
+	Comment struct {
+		ID           int64      `json:"id"`
+		ReviewUserID string     `json:"review_user_id"`
+		MovieID      int32      `json:"movie_id"`
+		ParentID     *int64     `json:"parent_id,omitempty"`
+		Content      string     `json:"content"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+		DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	}
+
+	// Vote is an exported vote cast by a user on a review
+	Vote struct {
+		ReviewUserID string    `json:"review_user_id"`
+		MovieID      int32     `json:"movie_id"`
+		VoteType     string    `json:"vote_type"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+
+	// Follow is an exported user followed by a user
+	Follow struct {
+		UserID    string    `json:"user_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+// ListMovieLists lists every movie list of a user with its items, from the oldest to the newest list
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []MovieList: the movie lists of the user
+// - error: if there was an error listing the movie lists
+func (m *Manager) ListMovieLists(ctx context.Context, userID string) ([]MovieList, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	rows, err := m.pool.Query(ctx, internalpostgres.ListUserMovieListsForExportQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (MovieList, error) {
+			var list MovieList
+			scanErr := row.Scan(
+				&list.Name,
+				&list.Description,
+				&list.IsPublic,
+				&list.CreatedAt,
+				&list.UpdatedAt,
+				&list.Items,
+			)
+			return list, scanErr
+		},
+	)
+}
+
+// ListComments lists every comment written by a user, deleted ones included, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []Comment: the comments of the user
+// - error: if there was an error listing the comments
+func (m *Manager) ListComments(ctx context.Context, userID string) ([]Comment, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	rows, err := m.pool.Query(ctx, internalpostgres.ListUserCommentsForExportQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (Comment, error) {
+			var (
+				comment      Comment
+				outParentID  sql.NullInt64
+				outUpdatedAt sql.NullTime
+				outDeletedAt sql.NullTime
+			)
+			if scanErr := row.Scan(
+				&comment.ID,
+				&comment.ReviewUserID,
+				&comment.MovieID,
+				&outParentID,
+				&comment.Content,
+				&comment.CreatedAt,
+				&outUpdatedAt,
+				&outDeletedAt,
+			); scanErr != nil {
+				return comment, scanErr
+			}
+
+			if outParentID.Valid {
+				comment.ParentID = &outParentID.Int64
+			}
+			if outUpdatedAt.Valid {
+				comment.UpdatedAt = &outUpdatedAt.Time
+			}
+			if outDeletedAt.Valid {
+				comment.DeletedAt = &outDeletedAt.Time
+			}
+			return comment, nil
+		},
+	)
+}
+
+// ListVotes lists every vote cast by a user, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []Vote: the votes cast by the user
+// - error: if there was an error listing the votes
+func (m *Manager) ListVotes(ctx context.Context, userID string) ([]Vote, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	rows, err := m.pool.Query(ctx, internalpostgres.ListUserVotesForExportQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[Vote])
+}
+
+// ListFollowing lists every user followed by a user, from the oldest to the newest follow
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []Follow: the users followed by the user
+// - error: if there was an error listing the followed users
+func (m *Manager) ListFollowing(ctx context.Context, userID string) ([]Follow, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	rows, err := m.pool.Query(ctx, internalpostgres.ListUserFollowingForExportQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[Follow])
+}
+
+// diary gets the diary entries of a user, the reviews with a watched on date, from the oldest to the newest watch
+//
+// Parameters:
+//
+// - reviews: the reviews of the user
+//
+// Returns:
+//
+// - []Review: the diary entries
+func diary(reviews []Review) []Review {
+	entries := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		if review.WatchedOn != nil {
+			entries = append(entries, review)
+		}
+	}
+	slices.SortStableFunc(
+		entries,
+		func(a, b Review) int {
+			return strings.Compare(*a.WatchedOn, *b.WatchedOn)
+		},
+	)
+	return entries
+}
+
+// BuildBundle builds the ZIP bundle of the data of a user, with a JSON file per kind of data
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []byte: the ZIP bundle
+// - error: if there was an error reading the data or building the bundle
+func (m *Manager) BuildBundle(ctx context.Context, userID string) ([]byte, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	reviews, err := m.ListReviews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	movieLists, err := m.ListMovieLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := m.ListComments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := m.ListVotes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := m.ListFollowing(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range []struct {
+		name  string
+		value any
+	}{
+		{ReviewsFileName, reviews},
+		{DiaryFileName, diary(reviews)},
+		{MovieListsFileName, movieLists},
+		{CommentsFileName, comments},
+		{VotesFileName, votes},
+		{FollowingFileName, following},
+	} {
+		fileWriter, createErr := writer.Create(file.name)
+		if createErr != nil {
+			return nil, createErr
+		}
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(file.value); encodeErr != nil {
+			return nil, encodeErr
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package userdata
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvExportPollInterval is the environment variable for the interval between the data exporter polls
+	EnvExportPollInterval = "USER_DATA_EXPORT_POLL_INTERVAL"
+
+	// EnvExportBatchSize is the environment variable for the number of data exports built per batch
+	EnvExportBatchSize = "USER_DATA_EXPORT_BATCH_SIZE"
+
+	// EnvExportTTL is the environment variable for the time the data export bundles are kept before being deleted
+	EnvExportTTL = "USER_DATA_EXPORT_TTL"
+)
+
+const (
+	// ExportLease is the time a data export can stay running before another exporter claims it again
+	ExportLease = 10 * time.Minute
+
+	// MaxErrorLength is the maximum number of bytes of the error kept for a failed data export
+	MaxErrorLength = 1000
+)
+
+const (
+	// ReviewsFileName is the bundle file name of the user reviews
+	ReviewsFileName = "reviews.json"
+
+	// DiaryFileName is the bundle file name of the user diary, the reviews with a watched on date
+	DiaryFileName = "diary.json"
+
+	// MovieListsFileName is the bundle file name of the user movie lists
+	MovieListsFileName = "lists.json"
+
+	// CommentsFileName is the bundle file name of the user comments
+	CommentsFileName = "comments.json"
+
+	// VotesFileName is the bundle file name of the votes cast by the user
+	VotesFileName = "votes.json"
+
+	// FollowingFileName is the bundle file name of the users followed by the user
+	FollowingFileName = "following.json"
+)
+
+var (
+	// ExportPollInterval is the interval between the data exporter polls
+	ExportPollInterval time.Duration
+
+	// ExportBatchSize is the number of data exports built per batch
+	ExportBatchSize int
+
+	// ExportTTL is the time the data export bundles are kept before being deleted
+	ExportTTL time.Duration
+)
+
+// Load loads the user data constants
+func Load() {
+	// Get the batch size from the environment variable
+	if err := internalloader.Loader.LoadIntVariable(
+		EnvExportBatchSize,
+		&ExportBatchSize,
+	); err != nil {
+		panic(err)
+	}
+
+	// Get the poll interval and the time to live of the bundles from the environment variables
+	for env, dest := range map[string]*time.Duration{
+		EnvExportPollInterval: &ExportPollInterval,
+		EnvExportTTL:          &ExportTTL,
+	} {
+		if err := internalloader.Loader.LoadDurationVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package userdata
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilManager  = errors.New("user data manager is nil")
+	ErrNilExporter = errors.New("user data exporter is nil")
+)
@@ -0,0 +1,214 @@
+package userdata
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+)
+
+type (
+	// Exporter builds the data exports requested by the users in the background. It claims the pending exports in
+	// Postgres, so several exporters can run side by side, stores their ZIP bundles until they expire and deletes the
+	// expired ones
+	Exporter struct {
+		pool    *pgxpool.Pool
+		manager *Manager
+		logger  *slog.Logger
+	}
+
+	// claimedExport is a data export claimed to be built
+	claimedExport struct {
+		id     int64
+		userID string
+	}
+)
+
+// NewExporter creates a new data exporter
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - manager: the user data manager
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Exporter: the data exporter
+// - error: if there was an error creating the data exporter
+func NewExporter(pool *pgxpool.Pool, manager *Manager, logger *slog.Logger) (*Exporter, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the user data manager is nil
+	if manager == nil {
+		return nil, ErrNilManager
+	}
+
+	// Create the logger for the exporter
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "user_data_exporter"),
+		)
+	}
+
+	return &Exporter{
+		pool:    pool,
+		manager: manager,
+		logger:  logger,
+	}, nil
+}
+
+// Run builds the pending data exports on every poll interval until the context is done. Full batches are followed
+// right away by the next batch, so a backlog is drained without waiting for the next poll
+//
+// Parameters:
+//
+// - ctx: the context
+func (e *Exporter) Run(ctx context.Context) {
+	if e == nil {
+		panic(ErrNilExporter)
+	}
+
+	ticker := time.NewTicker(ExportPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Delete the expired bundles
+		if _, err := e.pool.Exec(ctx, internalpostgres.DeleteExpiredUserDataExportsQuery); err != nil &&
+			e.logger != nil && ctx.Err() == nil {
+			e.logger.Error(
+				"Could not delete the expired data exports",
+				slog.String("error", err.Error()),
+			)
+		}
+
+		exportedCount, err := e.ExportBatch(ctx)
+		if err != nil && e.logger != nil && ctx.Err() == nil {
+			e.logger.Error(
+				"Could not build the data exports",
+				slog.String("error", err.Error()),
+			)
+		}
+		if err == nil && exportedCount == ExportBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExportBatch claims and builds a batch of the pending data exports. A failed export is recorded with its error, so
+// the user can request a new one
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - int: the number of claimed exports
+// - error: if there was an error claiming the exports or recording their outcome
+func (e *Exporter) ExportBatch(ctx context.Context) (int, error) {
+	if e == nil {
+		panic(ErrNilExporter)
+	}
+
+	// Claim a batch of the pending exports
+	rows, err := e.pool.Query(
+		ctx,
+		internalpostgres.ClaimUserDataExportsQuery,
+		ExportBatchSize,
+		ExportLease.Seconds(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	exports, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (claimedExport, error) {
+			var export claimedExport
+			scanErr := row.Scan(&export.id, &export.userID)
+			return export, scanErr
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, export := range exports {
+		if exportErr := e.export(ctx, export); exportErr != nil {
+			return len(exports), exportErr
+		}
+	}
+	return len(exports), nil
+}
+
+// export builds the bundle of a claimed data export and records the outcome
+//
+// Parameters:
+//
+// - ctx: the context
+// - export: the claimed data export
+//
+// Returns:
+//
+// - error: if there was an error recording the outcome
+func (e *Exporter) export(ctx context.Context, export claimedExport) error {
+	bundle, buildErr := e.manager.BuildBundle(ctx, export.userID)
+	if buildErr == nil {
+		_, err := e.pool.Exec(
+			ctx,
+			internalpostgres.CompleteUserDataExportQuery,
+			export.id,
+			bundle,
+			ExportTTL.Seconds(),
+		)
+		if err == nil && e.logger != nil {
+			e.logger.Info(
+				"Built data export",
+				slog.Int64("export_id", export.id),
+				slog.String("user_id", export.userID),
+				slog.Int("bundle_size", len(bundle)),
+			)
+		}
+		return err
+	}
+
+	// Leave the export running to be claimed again if the context is done
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if e.logger != nil {
+		e.logger.Error(
+			"Could not build data export",
+			slog.Int64("export_id", export.id),
+			slog.String("user_id", export.userID),
+			slog.String("error", buildErr.Error()),
+		)
+	}
+	errorMessage := buildErr.Error()
+	if len(errorMessage) > MaxErrorLength {
+		errorMessage = errorMessage[:MaxErrorLength]
+	}
+	_, err := e.pool.Exec(
+		ctx,
+		internalpostgres.FailUserDataExportQuery,
+		export.id,
+		errorMessage,
+		ExportTTL.Seconds(),
+	)
+	return err
+}
@@ -0,0 +1,263 @@
+package userdata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+	godatabasespgxpool "github.com/ralvarezdev/go-databases/sql/pgxpool"
+
+	internalcache "github.com/ralvarezdev/connect-movies/internal/cache"
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+)
+
+type (
+	// Manager reads and erases the data the service keeps about a user, for the operators and for the users' data
+	// requests
+	Manager struct {
+		pool   *pgxpool.Pool
+		cache  *internalcache.Cache
+		logger *slog.Logger
+	}
+
+	// Review is an exported user review
+	Review struct {
+		MovieID          int32      `json:"movie_id"`
+		Rating           int32      `json:"rating"`
+		Review           string     `json:"review,omitempty"`
+		ContainsSpoilers bool       `json:"contains_spoilers"`
+		Tags             []string   `json:"tags"`
+		WatchedOn        *string    `json:"watched_on,omitempty"`
+		ModerationStatus string     `json:"moderation_status"`
+		Version          int64      `json:"version"`
+		CreatedAt        time.Time  `json:"created_at"`
+		UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	}
+
+	// ErasureReport is the number of rows erased or anonymized for a user, by kind
+	ErasureReport struct {
+		Reviews                int64 `json:"reviews"`
+		Votes                  int64 `json:"votes"`
+		Comments               int64 `json:"comments"`
+		ContentReports         int64 `json:"content_reports"`
+		ModerationAuditEntries int64 `json:"moderation_audit_entries"`
+		MovieLists             int64 `json:"movie_lists"`
+		Follows                int64 `json:"follows"`
+	}
+)
+
+// NewManager creates a new user data manager
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - cache: the Redis cache
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Manager: the user data manager
+// - error: if there was an error creating the user data manager
+func NewManager(pool *pgxpool.Pool, cache *internalcache.Cache, logger *slog.Logger) (*Manager, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the cache is nil
+	if cache == nil {
+		return nil, internalcache.ErrNilCache
+	}
+
+	// Create the logger for the manager
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "user_data"),
+		)
+	}
+
+	return &Manager{
+		pool:   pool,
+		cache:  cache,
+		logger: logger,
+	}, nil
+}
+
+// ListReviews lists every review of a user, whatever its moderation status, from the oldest to the newest
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - []Review: the reviews of the user
+// - error: if there was an error listing the reviews
+func (m *Manager) ListReviews(ctx context.Context, userID string) ([]Review, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	rows, err := m.pool.Query(ctx, internalpostgres.ListUserReviewsForExportQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (Review, error) {
+			var (
+				review       Review
+				outReview    sql.NullString
+				outWatchedOn sql.NullTime
+				outUpdatedAt sql.NullTime
+			)
+			if scanErr := row.Scan(
+				&review.MovieID,
+				&review.Rating,
+				&outReview,
+				&review.ContainsSpoilers,
+				&review.Tags,
+				&outWatchedOn,
+				&review.ModerationStatus,
+				&review.Version,
+				&review.CreatedAt,
+				&outUpdatedAt,
+			); scanErr != nil {
+				return review, scanErr
+			}
+
+			review.Review = outReview.String
+			if outWatchedOn.Valid {
+				watchedOn := outWatchedOn.Time.Format(time.DateOnly)
+				review.WatchedOn = &watchedOn
+			}
+			if outUpdatedAt.Valid {
+				review.UpdatedAt = &outUpdatedAt.Time
+			}
+			return review, nil
+		},
+	)
+}
+
+// Erase erases the data of a user in a single transaction. Reviews, votes, reports, lists, follows and data exports
+// are deleted, fixing the vote counts of the reviews the user voted on, while comments and the moderation audit trail
+// are anonymized so the threads and the moderation decisions of the other users are kept. A deleted event is written
+// to the outbox for every deleted review, and the cached activity feeds of the user and their followers are
+// invalidated once the transaction is committed
+//
+// Parameters:
+//
+// - ctx: the context
+// - userID: the ID of the user
+//
+// Returns:
+//
+// - *ErasureReport: the number of erased or anonymized rows, by kind
+// - error: if there was an error erasing the data
+func (m *Manager) Erase(ctx context.Context, userID string) (*ErasureReport, error) {
+	if m == nil {
+		panic(ErrNilManager)
+	}
+
+	var (
+		report      ErasureReport
+		followerIDs []string
+	)
+	if err := godatabasespgxpool.CreateTransaction(
+		ctx,
+		m.pool,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// Get the followers before deleting the follows, to invalidate their feeds
+			rows, err := tx.Query(ctx, internalpostgres.ListUserFollowerIDsQuery, userID)
+			if err != nil {
+				return err
+			}
+			if followerIDs, err = pgx.CollectRows(rows, pgx.RowTo[string]); err != nil {
+				return err
+			}
+
+			// Fix the vote counts before deleting the votes
+			if _, err = tx.Exec(ctx, internalpostgres.SubtractUserVotesFromCountsQuery, userID); err != nil {
+				return err
+			}
+
+			for _, statement := range []struct {
+				query string
+				count *int64
+			}{
+				{internalpostgres.DeleteUserVotesQuery, &report.Votes},
+				{internalpostgres.AnonymizeUserCommentsQuery, &report.Comments},
+				{internalpostgres.DeleteUserContentReportsQuery, &report.ContentReports},
+				{internalpostgres.AnonymizeUserModerationAuditQuery, &report.ModerationAuditEntries},
+				{internalpostgres.DeleteUserMovieListsQuery, &report.MovieLists},
+				{internalpostgres.DeleteUserFollowsQuery, &report.Follows},
+				{internalpostgres.DeleteUserDataExportsQuery, nil},
+				{internalpostgres.DeleteUserReviewImportsQuery, nil},
+				{internalpostgres.DeleteUserRevisionsQuery, nil},
+				{internalpostgres.DeleteUserQuery, nil},
+			} {
+				commandTag, execErr := tx.Exec(ctx, statement.query, userID)
+				if execErr != nil {
+					return execErr
+				}
+				if statement.count != nil {
+					*statement.count = commandTag.RowsAffected()
+				}
+			}
+
+			// Delete the reviews, writing their deleted events to the outbox
+			rows, err = tx.Query(ctx, internalpostgres.DeleteUserReviewsQuery, userID)
+			if err != nil {
+				return err
+			}
+			movieIDs, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+			if err != nil {
+				return err
+			}
+			for _, movieID := range movieIDs {
+				if writeErr := internaloutbox.Write(
+					ctx,
+					tx,
+					internaloutbox.Event{
+						Type:        internaloutbox.EventUserReviewDeleted,
+						AggregateID: fmt.Sprintf(internaloutbox.UserReviewAggregateID, userID, movieID),
+						Payload: internaloutbox.UserReviewPayload{
+							UserID:    userID,
+							MovieID:   movieID,
+							DeletedBy: userID,
+						},
+					},
+				); writeErr != nil {
+					return writeErr
+				}
+			}
+			report.Reviews = int64(len(movieIDs))
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Invalidate the cached activity feeds that may show the erased activity
+	m.cache.Invalidate(ctx, fmt.Sprintf(internalcache.FeedPattern, userID))
+	for _, followerID := range followerIDs {
+		m.cache.Invalidate(ctx, fmt.Sprintf(internalcache.FeedPattern, followerID))
+	}
+
+	if m.logger != nil {
+		m.logger.Info(
+			"Erased user data",
+			slog.String("user_id", userID),
+			slog.Int64("reviews", report.Reviews),
+			slog.Int64("movie_lists", report.MovieLists),
+		)
+	}
+	return &report, nil
+}
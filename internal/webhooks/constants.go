@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"time"
+
+	internalloader "github.com/ralvarezdev/connect-movies/internal/loader"
+)
+
+const (
+	// EnvConsumerGroup is the environment variable for the Redis consumer group the dispatchers read the outbox
+	// stream with
+	EnvConsumerGroup = "WEBHOOKS_CONSUMER_GROUP"
+
+	// EnvConsumerName is the environment variable for the name of this dispatcher in the Redis consumer group
+	EnvConsumerName = "WEBHOOKS_CONSUMER_NAME"
+
+	// EnvPollInterval is the environment variable for the interval between the dispatcher polls
+	EnvPollInterval = "WEBHOOKS_POLL_INTERVAL"
+
+	// EnvBatchSize is the environment variable for the number of events read and deliveries attempted per batch
+	EnvBatchSize = "WEBHOOKS_BATCH_SIZE"
+
+	// EnvMaxAttempts is the environment variable for the number of attempts of a webhook delivery before giving up
+	EnvMaxAttempts = "WEBHOOKS_MAX_ATTEMPTS"
+
+	// EnvRetryBaseDelay is the environment variable for the delay before retrying a failed webhook delivery, doubled
+	// on every failed attempt
+	EnvRetryBaseDelay = "WEBHOOKS_RETRY_BASE_DELAY"
+
+	// EnvRetryMaxDelay is the environment variable for the maximum delay before retrying a failed webhook delivery
+	EnvRetryMaxDelay = "WEBHOOKS_RETRY_MAX_DELAY"
+
+	// EnvRequestTimeout is the environment variable for the timeout of the webhook requests
+	EnvRequestTimeout = "WEBHOOKS_REQUEST_TIMEOUT"
+)
+
+const (
+	// HeaderEvent is the webhook request header with the event type
+	HeaderEvent = "X-Webhook-Event"
+
+	// HeaderDelivery is the webhook request header with the delivery ID, which receivers use to deduplicate the
+	// deliveries received more than once
+	HeaderDelivery = "X-Webhook-Delivery"
+
+	// HeaderTimestamp is the webhook request header with the Unix time the request was signed at
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	// HeaderSignature is the webhook request header with the HMAC-SHA256 signature of the request
+	HeaderSignature = "X-Webhook-Signature"
+
+	// SignaturePrefix is the prefix of the hex-encoded signature in the signature header
+	SignaturePrefix = "sha256="
+
+	// SecretLength is the number of random bytes of the generated signing secrets
+	SecretLength = 32
+
+	// MaxErrorLength is the maximum number of bytes of the error kept in the delivery log
+	MaxErrorLength = 1000
+)
+
+var (
+	// ConsumerGroup is the Redis consumer group the dispatchers read the outbox stream with
+	ConsumerGroup string
+
+	// ConsumerName is the name of this dispatcher in the Redis consumer group
+	ConsumerName string
+
+	// PollInterval is the interval between the dispatcher polls
+	PollInterval time.Duration
+
+	// BatchSize is the number of events read and deliveries attempted per batch
+	BatchSize int
+
+	// MaxAttempts is the number of attempts of a webhook delivery before giving up
+	MaxAttempts int
+
+	// RetryBaseDelay is the delay before retrying a failed webhook delivery, doubled on every failed attempt
+	RetryBaseDelay time.Duration
+
+	// RetryMaxDelay is the maximum delay before retrying a failed webhook delivery
+	RetryMaxDelay time.Duration
+
+	// RequestTimeout is the timeout of the webhook requests
+	RequestTimeout time.Duration
+)
+
+// Load loads the webhooks constants
+func Load() {
+	// Get the consumer group and name from the environment variables
+	for env, dest := range map[string]*string{
+		EnvConsumerGroup: &ConsumerGroup,
+		EnvConsumerName:  &ConsumerName,
+	} {
+		if err := internalloader.Loader.LoadVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the batch size and the maximum attempts from the environment variables
+	for env, dest := range map[string]*int{
+		EnvBatchSize:   &BatchSize,
+		EnvMaxAttempts: &MaxAttempts,
+	} {
+		if err := internalloader.Loader.LoadIntVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+
+	// Get the poll interval, the retry delays and the request timeout from the environment variables
+	for env, dest := range map[string]*time.Duration{
+		EnvPollInterval:   &PollInterval,
+		EnvRetryBaseDelay: &RetryBaseDelay,
+		EnvRetryMaxDelay:  &RetryMaxDelay,
+		EnvRequestTimeout: &RequestTimeout,
+	} {
+		if err := internalloader.Loader.LoadDurationVariable(
+			env,
+			dest,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,487 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	godatabases "github.com/ralvarezdev/go-databases"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+
+	internalpostgres "github.com/ralvarezdev/connect-movies/internal/databases/postgres"
+	internaloutbox "github.com/ralvarezdev/connect-movies/internal/outbox"
+)
+
+type (
+	// Dispatcher delivers the domain events to the webhook subscriptions. It reads the outbox stream with a Redis
+	// consumer group, queues a delivery per matching subscription in Postgres, and attempts the due deliveries with
+	// signed HTTP requests, retrying the failed ones with an exponential backoff
+	Dispatcher struct {
+		pool        *pgxpool.Pool
+		redisClient *redis.Client
+		httpClient  *http.Client
+		logger      *slog.Logger
+		readID      string
+	}
+
+	// eventBody is the JSON body of the webhook requests
+	eventBody struct {
+		ID          string          `json:"id"`
+		Type        string          `json:"type"`
+		AggregateID string          `json:"aggregate_id"`
+		CreatedAt   string          `json:"created_at"`
+		Data        json.RawMessage `json:"data"`
+	}
+
+	// claimedDelivery is a webhook delivery claimed to be attempted
+	claimedDelivery struct {
+		id        int64
+		eventID   int64
+		eventType string
+		body      string
+		attempts  int
+		url       string
+		secret    string
+	}
+
+	// failedAttempt is the outcome of a failed attempt of a webhook delivery
+	failedAttempt struct {
+		statusCode   *int
+		errorMessage string
+		exhausted    bool
+		retryDelay   time.Duration
+	}
+)
+
+// NewDispatcher creates a new webhook dispatcher
+//
+// Parameters:
+//
+// - pool: the Postgres connection pool
+// - redisClient: the Redis client
+// - httpClient: the HTTP client of the webhook requests (optional, defaults to a client with the request timeout)
+// - logger: the logger (optional)
+//
+// Returns:
+//
+// - *Dispatcher: the webhook dispatcher
+// - error: if there was an error creating the webhook dispatcher
+func NewDispatcher(
+	pool *pgxpool.Pool,
+	redisClient *redis.Client,
+	httpClient *http.Client,
+	logger *slog.Logger,
+) (*Dispatcher, error) {
+	// Check if the Postgres pool is nil
+	if pool == nil {
+		return nil, godatabases.ErrNilPool
+	}
+
+	// Check if the Redis client is nil
+	if redisClient == nil {
+		return nil, ErrNilRedisClient
+	}
+
+	// Default to a client with the request timeout
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: RequestTimeout}
+	}
+
+	// Create the logger for the dispatcher
+	if logger != nil {
+		logger = logger.With(
+			slog.String("component", "webhook_dispatcher"),
+		)
+	}
+
+	return &Dispatcher{
+		pool:        pool,
+		redisClient: redisClient,
+		httpClient:  httpClient,
+		logger:      logger,
+		readID:      "0",
+	}, nil
+}
+
+// Run queues and attempts the webhook deliveries until the context is done
+//
+// Parameters:
+//
+// - ctx: the context
+func (d *Dispatcher) Run(ctx context.Context) {
+	if d == nil {
+		panic(ErrNilDispatcher)
+	}
+
+	for ctx.Err() == nil {
+		err := d.EnsureConsumerGroup(ctx)
+		if err == nil {
+			_, err = d.Enqueue(ctx)
+		}
+		if err == nil {
+			_, err = d.DeliverBatch(ctx)
+		}
+		if err == nil {
+			continue
+		}
+
+		if d.logger != nil && ctx.Err() == nil {
+			d.logger.Error(
+				"Could not dispatch webhooks",
+				slog.String("error", err.Error()),
+			)
+		}
+
+		// Wait before retrying, so a failing dependency is not hammered
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// EnsureConsumerGroup creates the consumer group of the outbox stream if it does not exist. New groups start at the
+// end of the stream, so new subscriptions do not receive the events published before them
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - error: if there was an error creating the consumer group
+func (d *Dispatcher) EnsureConsumerGroup(ctx context.Context) error {
+	if d == nil {
+		panic(ErrNilDispatcher)
+	}
+
+	err := d.redisClient.XGroupCreateMkStream(ctx, internaloutbox.Stream, ConsumerGroup, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// Enqueue reads a batch of events from the outbox stream and queues a delivery for each matching webhook
+// subscription. The events this dispatcher read but did not acknowledge before stopping are read first, and events
+// are only acknowledged once their deliveries are queued
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - int: the number of read events
+// - error: if there was an error reading the events or queueing their deliveries
+func (d *Dispatcher) Enqueue(ctx context.Context) (int, error) {
+	if d == nil {
+		panic(ErrNilDispatcher)
+	}
+
+	// Block waiting for new events, but not when reading the pending ones
+	block := time.Duration(-1)
+	if d.readID == ">" {
+		block = PollInterval
+	}
+	streams, err := d.redisClient.XReadGroup(
+		ctx,
+		&redis.XReadGroupArgs{
+			Group:    ConsumerGroup,
+			Consumer: ConsumerName,
+			Streams:  []string{internaloutbox.Stream, d.readID},
+			Count:    int64(BatchSize),
+			Block:    block,
+		},
+	).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	var messages []redis.XMessage
+	for _, stream := range streams {
+		messages = append(messages, stream.Messages...)
+	}
+
+	// Switch to the new events once there are no pending ones left
+	if len(messages) == 0 {
+		d.readID = ">"
+		return 0, nil
+	}
+
+	for _, message := range messages {
+		if queueErr := d.queueDeliveries(ctx, message); queueErr != nil {
+			// Read the pending events again on the next batch
+			d.readID = "0"
+			return 0, queueErr
+		}
+		if ackErr := d.redisClient.XAck(ctx, internaloutbox.Stream, ConsumerGroup, message.ID).Err(); ackErr != nil {
+			d.readID = "0"
+			return 0, ackErr
+		}
+	}
+	return len(messages), nil
+}
+
+// queueDeliveries queues a delivery of an outbox stream event for each matching webhook subscription
+//
+// Parameters:
+//
+// - ctx: the context
+// - message: the outbox stream entry
+//
+// Returns:
+//
+// - error: if there was an error queueing the deliveries
+func (d *Dispatcher) queueDeliveries(ctx context.Context, message redis.XMessage) error {
+	field := func(name string) string {
+		value, _ := message.Values[name].(string)
+		return value
+	}
+
+	// Skip the malformed entries, since reading them again would not fix them
+	eventID, err := strconv.ParseInt(field(internaloutbox.StreamFieldEventID), 10, 64)
+	if err != nil {
+		if d.logger != nil {
+			d.logger.Warn(
+				"Skipping malformed outbox stream entry",
+				slog.String("entry_id", message.ID),
+			)
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(
+		eventBody{
+			ID:          field(internaloutbox.StreamFieldEventID),
+			Type:        field(internaloutbox.StreamFieldEventType),
+			AggregateID: field(internaloutbox.StreamFieldAggregateID),
+			CreatedAt:   field(internaloutbox.StreamFieldCreatedAt),
+			Data:        json.RawMessage(field(internaloutbox.StreamFieldPayload)),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.pool.Exec(
+		ctx,
+		internalpostgres.InsertWebhookDeliveriesQuery,
+		eventID,
+		field(internaloutbox.StreamFieldEventType),
+		string(body),
+	)
+	return err
+}
+
+// DeliverBatch attempts a batch of the due webhook deliveries concurrently
+//
+// Parameters:
+//
+// - ctx: the context
+//
+// Returns:
+//
+// - int: the number of attempted deliveries
+// - error: if there was an error claiming the deliveries or recording their attempts
+func (d *Dispatcher) DeliverBatch(ctx context.Context) (int, error) {
+	if d == nil {
+		panic(ErrNilDispatcher)
+	}
+
+	// Claim the due deliveries for twice the request timeout, so they are attempted again if this dispatcher stops
+	rows, err := d.pool.Query(
+		ctx,
+		internalpostgres.ClaimWebhookDeliveriesQuery,
+		BatchSize,
+		(2 * RequestTimeout).Seconds(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	deliveries, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (claimedDelivery, error) {
+			var delivery claimedDelivery
+			scanErr := row.Scan(
+				&delivery.id,
+				&delivery.eventID,
+				&delivery.eventType,
+				&delivery.body,
+				&delivery.attempts,
+				&delivery.url,
+				&delivery.secret,
+			)
+			return delivery, scanErr
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var group errgroup.Group
+	for _, delivery := range deliveries {
+		group.Go(
+			func() error {
+				return d.attempt(ctx, delivery)
+			},
+		)
+	}
+	return len(deliveries), group.Wait()
+}
+
+// attempt sends a webhook delivery and records the outcome of the attempt
+//
+// Parameters:
+//
+// - ctx: the context
+// - delivery: the claimed delivery
+//
+// Returns:
+//
+// - error: if there was an error recording the outcome of the attempt
+func (d *Dispatcher) attempt(ctx context.Context, delivery claimedDelivery) error {
+	statusCode, sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		_, err := d.pool.Exec(ctx, internalpostgres.MarkWebhookDeliverySucceededQuery, delivery.id, statusCode)
+		return err
+	}
+
+	// Give up on the delivery after the maximum number of attempts
+	outcome := newFailedAttempt(delivery, statusCode, sendErr)
+	if outcome.exhausted {
+		if d.logger != nil {
+			d.logger.Warn(
+				"Giving up on webhook delivery",
+				slog.Int64("delivery_id", delivery.id),
+				slog.String("error", outcome.errorMessage),
+			)
+		}
+		_, err := d.pool.Exec(
+			ctx,
+			internalpostgres.MarkWebhookDeliveryExhaustedQuery,
+			delivery.id,
+			outcome.statusCode,
+			outcome.errorMessage,
+		)
+		return err
+	}
+
+	_, err := d.pool.Exec(
+		ctx,
+		internalpostgres.MarkWebhookDeliveryFailedQuery,
+		delivery.id,
+		outcome.statusCode,
+		outcome.errorMessage,
+		outcome.retryDelay.Seconds(),
+	)
+	return err
+}
+
+// newFailedAttempt gets the outcome of a failed attempt of a webhook delivery, which is exhausted after the maximum
+// number of attempts, or retried after the backoff delay otherwise
+//
+// Parameters:
+//
+// - delivery: the claimed delivery
+// - statusCode: the response status code, 0 if no response was received
+// - sendErr: the error of the attempt
+//
+// Returns:
+//
+// - failedAttempt: the outcome of the failed attempt
+func newFailedAttempt(delivery claimedDelivery, statusCode int, sendErr error) failedAttempt {
+	var outcome failedAttempt
+
+	// Keep the status code null when no response was received
+	if statusCode != 0 {
+		outcome.statusCode = &statusCode
+	}
+	outcome.errorMessage = sendErr.Error()
+	if len(outcome.errorMessage) > MaxErrorLength {
+		outcome.errorMessage = outcome.errorMessage[:MaxErrorLength]
+	}
+
+	attempts := delivery.attempts + 1
+	if attempts >= MaxAttempts {
+		outcome.exhausted = true
+		return outcome
+	}
+	outcome.retryDelay = retryDelay(attempts)
+	return outcome
+}
+
+// send sends the signed HTTP request of a webhook delivery. Any 2xx response is a successful delivery
+//
+// Parameters:
+//
+// - ctx: the context
+// - delivery: the claimed delivery
+//
+// Returns:
+//
+// - int: the response status code, 0 if no response was received
+// - error: if the request failed or the response status code is not 2xx
+func (d *Dispatcher) send(ctx context.Context, delivery claimedDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	body := []byte(delivery.body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	// Sign the request
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEvent, delivery.eventType)
+	request.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.id, 10))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(delivery.secret, timestamp, body))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// Drain the response body so the connection can be reused
+	// nolint:errcheck
+	io.Copy(io.Discard, io.LimitReader(response.Body, MaxErrorLength))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf(ErrUnexpectedStatusCode, response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// retryDelay gets the delay before the next attempt of a webhook delivery, doubling the base delay on every failed
+// attempt up to the maximum delay
+//
+// Parameters:
+//
+// - attempts: the number of failed attempts
+//
+// Returns:
+//
+// - time.Duration: the delay before the next attempt
+func retryDelay(attempts int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempts && delay < RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, RetryMaxDelay)
+}
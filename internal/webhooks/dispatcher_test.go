@@ -0,0 +1,239 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setTestConfig sets the configuration the tests depend on, restoring the previous one when the test ends
+//
+// Parameters:
+//
+// - t: the test
+func setTestConfig(t *testing.T) {
+	t.Helper()
+
+	maxAttempts := MaxAttempts
+	retryBaseDelay := RetryBaseDelay
+	retryMaxDelay := RetryMaxDelay
+	requestTimeout := RequestTimeout
+	t.Cleanup(
+		func() {
+			MaxAttempts = maxAttempts
+			RetryBaseDelay = retryBaseDelay
+			RetryMaxDelay = retryMaxDelay
+			RequestTimeout = requestTimeout
+		},
+	)
+
+	MaxAttempts = 5
+	RetryBaseDelay = 30 * time.Second
+	RetryMaxDelay = 5 * time.Minute
+	RequestTimeout = 5 * time.Second
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	setTestConfig(t)
+
+	delivery := claimedDelivery{
+		id:        42,
+		eventType: "user_review.created",
+		body:      `{"id":"1","type":"user_review.created"}`,
+		secret:    "secret",
+	}
+
+	received := make(chan *http.Request, 1)
+	receivedBody := make(chan []byte, 1)
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received <- r
+				receivedBody <- body
+				w.WriteHeader(http.StatusNoContent)
+			},
+		),
+	)
+	defer server.Close()
+	delivery.url = server.URL
+
+	dispatcher := &Dispatcher{httpClient: server.Client()}
+	statusCode, err := dispatcher.send(context.Background(), delivery)
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if statusCode != http.StatusNoContent {
+		t.Errorf("send() status code = %d, want %d", statusCode, http.StatusNoContent)
+	}
+
+	request, body := <-received, <-receivedBody
+	if request.Method != http.MethodPost {
+		t.Errorf("method = %s, want %s", request.Method, http.MethodPost)
+	}
+	if string(body) != delivery.body {
+		t.Errorf("body = %s, want %s", body, delivery.body)
+	}
+	if got := request.Header.Get(HeaderEvent); got != delivery.eventType {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, delivery.eventType)
+	}
+	if got := request.Header.Get(HeaderDelivery); got != "42" {
+		t.Errorf("%s = %q, want %q", HeaderDelivery, got, "42")
+	}
+
+	timestamp, err := strconv.ParseInt(request.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s is not a Unix time: %v", HeaderTimestamp, err)
+	}
+	signature := request.Header.Get(HeaderSignature)
+	if !Verify(delivery.secret, timestamp, body, signature) {
+		t.Errorf("%s = %q does not verify", HeaderSignature, signature)
+	}
+	if Verify("other", timestamp, body, signature) {
+		t.Errorf("%s verifies with another secret", HeaderSignature)
+	}
+	if Verify(delivery.secret, timestamp+1, body, signature) {
+		t.Errorf("%s verifies with another timestamp", HeaderSignature)
+	}
+}
+
+func TestSendStatusCodes(t *testing.T) {
+	setTestConfig(t)
+
+	for _, test := range []struct {
+		statusCode int
+		wantErr    bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusAccepted, false},
+		{http.StatusMovedPermanently, true},
+		{http.StatusBadRequest, true},
+		{http.StatusInternalServerError, true},
+	} {
+		t.Run(
+			strconv.Itoa(test.statusCode), func(t *testing.T) {
+				server := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							w.WriteHeader(test.statusCode)
+						},
+					),
+				)
+				defer server.Close()
+
+				dispatcher := &Dispatcher{
+					httpClient: &http.Client{
+						// Return the redirects instead of following them
+						CheckRedirect: func(*http.Request, []*http.Request) error {
+							return http.ErrUseLastResponse
+						},
+					},
+				}
+				statusCode, err := dispatcher.send(context.Background(), claimedDelivery{url: server.URL})
+				if statusCode != test.statusCode {
+					t.Errorf("send() status code = %d, want %d", statusCode, test.statusCode)
+				}
+				if (err != nil) != test.wantErr {
+					t.Errorf("send() error = %v, want error %t", err, test.wantErr)
+				}
+			},
+		)
+	}
+}
+
+func TestSendWithoutResponse(t *testing.T) {
+	setTestConfig(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	dispatcher := &Dispatcher{httpClient: &http.Client{}}
+	statusCode, err := dispatcher.send(context.Background(), claimedDelivery{url: url})
+	if err == nil {
+		t.Fatal("send() error = nil, want an error")
+	}
+	if statusCode != 0 {
+		t.Errorf("send() status code = %d, want 0", statusCode)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	setTestConfig(t)
+
+	for _, test := range []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{20, 5 * time.Minute},
+	} {
+		if got := retryDelay(test.attempts); got != test.want {
+			t.Errorf("retryDelay(%d) = %s, want %s", test.attempts, got, test.want)
+		}
+	}
+}
+
+func TestNewFailedAttempt(t *testing.T) {
+	setTestConfig(t)
+
+	sendErr := errors.New("connection refused")
+	for _, test := range []struct {
+		name          string
+		attempts      int
+		wantExhausted bool
+		wantDelay     time.Duration
+	}{
+		{"first attempt", 0, false, 30 * time.Second},
+		{"second attempt", 1, false, time.Minute},
+		{"before last attempt", 3, false, 4 * time.Minute},
+		{"last attempt", 4, true, 0},
+		{"past last attempt", 7, true, 0},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				outcome := newFailedAttempt(claimedDelivery{attempts: test.attempts}, 0, sendErr)
+				if outcome.exhausted != test.wantExhausted {
+					t.Errorf("exhausted = %t, want %t", outcome.exhausted, test.wantExhausted)
+				}
+				if outcome.retryDelay != test.wantDelay {
+					t.Errorf("retry delay = %s, want %s", outcome.retryDelay, test.wantDelay)
+				}
+			},
+		)
+	}
+}
+
+func TestNewFailedAttemptRecordsError(t *testing.T) {
+	setTestConfig(t)
+
+	outcome := newFailedAttempt(claimedDelivery{}, 0, errors.New("timeout"))
+	if outcome.statusCode != nil {
+		t.Errorf("status code = %d, want nil without a response", *outcome.statusCode)
+	}
+	if outcome.errorMessage != "timeout" {
+		t.Errorf("error message = %q, want %q", outcome.errorMessage, "timeout")
+	}
+
+	outcome = newFailedAttempt(
+		claimedDelivery{},
+		http.StatusBadGateway,
+		errors.New(strings.Repeat("x", MaxErrorLength+1)),
+	)
+	if outcome.statusCode == nil || *outcome.statusCode != http.StatusBadGateway {
+		t.Errorf("status code = %v, want %d", outcome.statusCode, http.StatusBadGateway)
+	}
+	if len(outcome.errorMessage) != MaxErrorLength {
+		t.Errorf("error message length = %d, want %d", len(outcome.errorMessage), MaxErrorLength)
+	}
+}
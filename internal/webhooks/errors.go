@@ -0,0 +1,14 @@
+package webhooks
+
+import (
+	"errors"
+)
+
+const (
+	ErrUnexpectedStatusCode = "unexpected webhook response status code: %d"
+)
+
+var (
+	ErrNilDispatcher  = errors.New("webhook dispatcher is nil")
+	ErrNilRedisClient = errors.New("redis client is nil")
+)
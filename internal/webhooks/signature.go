@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// GenerateSecret generates a random signing secret for a webhook subscription
+//
+// Returns:
+//
+// - string: the hex-encoded signing secret
+// - error: if there was an error reading the random bytes
+func GenerateSecret() (string, error) {
+	secret := make([]byte, SecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign signs a webhook request body. The signature is the HMAC-SHA256 of the timestamp and the body joined by a dot,
+// so a captured request cannot be replayed with a different timestamp
+//
+// Parameters:
+//
+// - secret: the signing secret of the webhook subscription
+// - timestamp: the Unix time the request is signed at
+// - body: the request body
+//
+// Returns:
+//
+// - string: the value of the signature header
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request body in constant time. Receivers should also reject timestamps
+// too far from their clock
+//
+// Parameters:
+//
+// - secret: the signing secret of the webhook subscription
+// - timestamp: the Unix time of the timestamp header
+// - body: the request body
+// - signature: the value of the signature header
+//
+// Returns:
+//
+// - bool: true if the signature is valid, false otherwise
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, SignaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -26,5 +26,11 @@ if [ -z "$GRPC_PORT" ]; then
 fi
 export GRPC_PORT
 
+# Check if the migrate on startup variable is set, default to false if not provided
+if [ -z "$MIGRATE_ON_STARTUP" ]; then
+  MIGRATE_ON_STARTUP=false
+fi
+export MIGRATE_ON_STARTUP
+
 # Execute the Go binary on port specified
-exec bin/server/server -mode=$MODE
\ No newline at end of file
+exec bin/server/server -mode=$MODE -migrate=$MIGRATE_ON_STARTUP
\ No newline at end of file